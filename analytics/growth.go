@@ -0,0 +1,128 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+)
+
+// Granularity 粉丝增长时间序列的统计粒度
+type Granularity string
+
+const (
+	GranularityDay   Granularity = "day"
+	GranularityWeek  Granularity = "week"  // 周一开始
+	GranularityMonth Granularity = "month" // 自然月
+)
+
+// MaxRange 一次查询允许的最大时间跨度
+const MaxRange = 366 * 24 * time.Hour
+
+var (
+	// ErrInvalidGranularity 表示统计粒度不是day、week或month
+	ErrInvalidGranularity = errors.New("invalid granularity")
+	// ErrInvalidRange 表示起始日期晚于结束日期或时间跨度超过MaxRange
+	ErrInvalidRange = errors.New("invalid range")
+)
+
+// ParseGranularity 解析统计粒度，为空时使用GranularityDay
+func ParseGranularity(value string) (Granularity, error) {
+	switch Granularity(value) {
+	case "":
+		return GranularityDay, nil
+	case GranularityDay, GranularityWeek, GranularityMonth:
+		return Granularity(value), nil
+	}
+	return "", ErrInvalidGranularity
+}
+
+// StatsSource 提供每日粉丝变化汇总，由store.FollowStore实现
+type StatsSource interface {
+	DailyStats(ctx context.Context, userID string, from, to time.Time) ([]models.FollowStatsDay, error)
+}
+
+// Point 时间序列中的一个时间段
+type Point struct {
+	Start  time.Time // 时间段第一天的UTC零点
+	Gained int64
+	Lost   int64
+	Net    int64
+}
+
+// Growth 用户在[From, To]内的粉丝增长时间序列及合计，日期均为UTC
+type Growth struct {
+	From        time.Time
+	To          time.Time
+	Granularity Granularity
+	Points      []Point
+	Total       Point
+}
+
+// FollowerGrowth 按granularity汇总userID在[from, to]内的粉丝变化，
+// 没有变化的时间段也会出现在Points中，首尾时间段只统计范围内的日期
+func FollowerGrowth(ctx context.Context, source StatsSource, userID string, from, to time.Time, granularity Granularity) (*Growth, error) {
+	from, to = models.StatsDay(from), models.StatsDay(to)
+	if to.Before(from) || to.Sub(from) > MaxRange {
+		return nil, ErrInvalidRange
+	}
+
+	days, err := source.DailyStats(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	growth := &Growth{
+		From:        from,
+		To:          to,
+		Granularity: granularity,
+		Points:      make([]Point, 0),
+		Total:       Point{Start: from},
+	}
+	index := make(map[time.Time]int)
+	for start := periodStart(from, granularity); !start.After(to); start = nextPeriod(start, granularity) {
+		index[start] = len(growth.Points)
+		pointStart := start
+		if pointStart.Before(from) {
+			pointStart = from
+		}
+		growth.Points = append(growth.Points, Point{Start: pointStart})
+	}
+
+	for _, day := range days {
+		i, ok := index[periodStart(day.Day, granularity)]
+		if !ok {
+			continue
+		}
+		point := &growth.Points[i]
+		point.Gained += day.Gained
+		point.Lost += day.Lost
+		point.Net = point.Gained - point.Lost
+		growth.Total.Gained += day.Gained
+		growth.Total.Lost += day.Lost
+	}
+	growth.Total.Net = growth.Total.Gained - growth.Total.Lost
+	return growth, nil
+}
+
+// periodStart 返回day所在时间段的第一天
+func periodStart(day time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case GranularityMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+func nextPeriod(start time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case GranularityWeek:
+		return start.AddDate(0, 0, 7)
+	case GranularityMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
@@ -0,0 +1,186 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeStats 按日期返回固定的每日汇总，并像store.FollowStore一样只返回[from, to]内的日期
+type fakeStats struct {
+	days []models.FollowStatsDay
+	err  error
+}
+
+func (f *fakeStats) DailyStats(ctx context.Context, userID string, from, to time.Time) ([]models.FollowStatsDay, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	days := make([]models.FollowStatsDay, 0)
+	for _, day := range f.days {
+		if !day.Day.Before(from) && !day.Day.After(to) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func statsDay(day time.Time, gained, lost int64) models.FollowStatsDay {
+	return models.FollowStatsDay{UserID: "u", Day: day, Gained: gained, Lost: lost}
+}
+
+func TestFollowerGrowth(t *testing.T) {
+	// 2024-03-04为周一
+	source := &fakeStats{days: []models.FollowStatsDay{
+		statsDay(date(2024, 2, 28), 9, 9),
+		statsDay(date(2024, 3, 1), 3, 1),
+		statsDay(date(2024, 3, 3), 2, 0),
+		statsDay(date(2024, 3, 4), 5, 2),
+		statsDay(date(2024, 3, 12), 1, 4),
+		statsDay(date(2024, 4, 2), 7, 0),
+	}}
+
+	tests := []struct {
+		name        string
+		from        time.Time
+		to          time.Time
+		granularity Granularity
+		want        []Point
+		total       Point
+	}{
+		{
+			name:        "按天，没有变化的日期为0",
+			from:        date(2024, 3, 1),
+			to:          date(2024, 3, 4),
+			granularity: GranularityDay,
+			want: []Point{
+				{Start: date(2024, 3, 1), Gained: 3, Lost: 1, Net: 2},
+				{Start: date(2024, 3, 2)},
+				{Start: date(2024, 3, 3), Gained: 2, Net: 2},
+				{Start: date(2024, 3, 4), Gained: 5, Lost: 2, Net: 3},
+			},
+			total: Point{Start: date(2024, 3, 1), Gained: 10, Lost: 3, Net: 7},
+		},
+		{
+			name:        "按周，第一周从范围起始日开始",
+			from:        date(2024, 3, 1),
+			to:          date(2024, 3, 12),
+			granularity: GranularityWeek,
+			want: []Point{
+				{Start: date(2024, 3, 1), Gained: 5, Lost: 1, Net: 4},
+				{Start: date(2024, 3, 4), Gained: 5, Lost: 2, Net: 3},
+				{Start: date(2024, 3, 11), Gained: 1, Lost: 4, Net: -3},
+			},
+			total: Point{Start: date(2024, 3, 1), Gained: 11, Lost: 7, Net: 4},
+		},
+		{
+			name:        "按月，首尾月份只统计范围内的日期",
+			from:        date(2024, 2, 29),
+			to:          date(2024, 4, 1),
+			granularity: GranularityMonth,
+			want: []Point{
+				{Start: date(2024, 2, 29)},
+				{Start: date(2024, 3, 1), Gained: 11, Lost: 7, Net: 4},
+				{Start: date(2024, 4, 1)},
+			},
+			total: Point{Start: date(2024, 2, 29), Gained: 11, Lost: 7, Net: 4},
+		},
+		{
+			name:        "起止时间截断到UTC日期",
+			from:        time.Date(2024, 3, 3, 23, 30, 0, 0, time.UTC),
+			to:          time.Date(2024, 3, 4, 7, 59, 0, 0, time.FixedZone("UTC+8", 8*3600)),
+			granularity: GranularityDay,
+			want: []Point{
+				{Start: date(2024, 3, 3), Gained: 2, Net: 2},
+			},
+			total: Point{Start: date(2024, 3, 3), Gained: 2, Net: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			growth, err := FollowerGrowth(context.Background(), source, "u", tt.from, tt.to, tt.granularity)
+			if err != nil {
+				t.Fatalf("FollowerGrowth() error = %v", err)
+			}
+			if !reflect.DeepEqual(growth.Points, tt.want) {
+				t.Errorf("Points = %v, want %v", growth.Points, tt.want)
+			}
+			if growth.Total != tt.total {
+				t.Errorf("Total = %v, want %v", growth.Total, tt.total)
+			}
+		})
+	}
+}
+
+func TestFollowerGrowthErrors(t *testing.T) {
+	sourceErr := errors.New("unavailable")
+
+	tests := []struct {
+		name    string
+		source  StatsSource
+		from    time.Time
+		to      time.Time
+		wantErr error
+	}{
+		{
+			name:    "起始日期晚于结束日期",
+			source:  &fakeStats{},
+			from:    date(2024, 3, 2),
+			to:      date(2024, 3, 1),
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "超过最大时间跨度",
+			source:  &fakeStats{},
+			from:    date(2023, 1, 1),
+			to:      date(2024, 1, 3),
+			wantErr: ErrInvalidRange,
+		},
+		{
+			name:    "读取汇总失败",
+			source:  &fakeStats{err: sourceErr},
+			from:    date(2024, 3, 1),
+			to:      date(2024, 3, 2),
+			wantErr: sourceErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FollowerGrowth(context.Background(), tt.source, "u", tt.from, tt.to, GranularityDay); !errors.Is(err, tt.wantErr) {
+				t.Errorf("FollowerGrowth() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseGranularity(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Granularity
+		wantErr error
+	}{
+		{value: "", want: GranularityDay},
+		{value: "day", want: GranularityDay},
+		{value: "week", want: GranularityWeek},
+		{value: "month", want: GranularityMonth},
+		{value: "year", wantErr: ErrInvalidGranularity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseGranularity(tt.value)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseGranularity(%q) = %q, %v, want %q, %v", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
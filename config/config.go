@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -12,6 +14,20 @@ type Config struct {
 	MongoDB     MongoDBConfig `mapstructure:"mongodb"`
 	UserService ServiceConfig `mapstructure:"user_service"`
 	PostService ServiceConfig `mapstructure:"post_service"`
+
+	Enrichment EnrichmentConfig `mapstructure:"enrichment"`
+	Counters   CountersConfig   `mapstructure:"counters"`
+	Outbox     OutboxConfig     `mapstructure:"outbox"`
+	Webhooks   WebhooksConfig   `mapstructure:"webhooks"`
+	Admin      AdminConfig      `mapstructure:"admin"`
+
+	AccountDeletion AccountDeletionConfig `mapstructure:"account_deletion"`
+
+	Suggestions SuggestionsConfig `mapstructure:"suggestions"`
+	Timeline    TimelineConfig    `mapstructure:"timeline"`
+
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+	Migrations  MigrationsConfig  `mapstructure:"migrations"`
 }
 
 type ServerConfig struct {
@@ -29,6 +45,93 @@ type ServiceConfig struct {
 	Host string `mapstructure:"host"`
 }
 
+// EnrichmentConfig 列表接口补充用户信息和最新帖子时的配置
+type EnrichmentConfig struct {
+	// Concurrency 同时向用户服务和帖子服务发起的最大请求数
+	Concurrency int                `mapstructure:"concurrency"`
+	Cache       ProfileCacheConfig `mapstructure:"cache"`
+}
+
+// ProfileCacheConfig 用户信息和最新帖子缓存的配置，Size为0时不启用缓存
+type ProfileCacheConfig struct {
+	Size        int           `mapstructure:"size"`
+	UserTTL     time.Duration `mapstructure:"user_ttl"`
+	PostTTL     time.Duration `mapstructure:"post_ttl"`
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"` // 用户不存在时的缓存时间
+}
+
+// CountersConfig 关注数和粉丝数冗余计数的配置
+type CountersConfig struct {
+	// ReconcileInterval 对账任务的执行间隔，为0时不启动对账任务
+	ReconcileInterval time.Duration `mapstructure:"reconcile_interval"`
+}
+
+// OutboxConfig 关注事件发件箱中继任务的配置
+type OutboxConfig struct {
+	// Publisher 事件发布方式：log输出到标准输出，file追加写入FilePath，为空时中继任务只创建回调
+	Publisher    string        `mapstructure:"publisher"`
+	FilePath     string        `mapstructure:"file_path"`
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	MaxBackoff   time.Duration `mapstructure:"max_backoff"` // 发布失败后重试间隔的上限
+	// Retention 已发布事件的保留时间，之后自动删除，为0时使用7天
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// WebhooksConfig 回调投递任务的配置
+type WebhooksConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	Concurrency  int           `mapstructure:"concurrency"`  // 同时发送的最大回调请求数
+	Timeout      time.Duration `mapstructure:"timeout"`      // 单次回调请求的超时时间
+	MaxAttempts  int           `mapstructure:"max_attempts"` // 达到后移入死信
+	MaxBackoff   time.Duration `mapstructure:"max_backoff"`
+}
+
+// AccountDeletionConfig 注销账号清理任务的配置，为0的字段使用默认值
+type AccountDeletionConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"` // 默认5秒
+	BatchSize    int           `mapstructure:"batch_size"`    // 每批结束的关注关系数，默认500
+	Lease        time.Duration `mapstructure:"lease"`         // 执行任务的实例崩溃后其他实例接管的等待时间，默认2分钟
+}
+
+// AdminConfig 管理接口的配置
+type AdminConfig struct {
+	// UserIDs 允许访问管理接口的用户ID
+	UserIDs []string `mapstructure:"user_ids"`
+}
+
+// SuggestionsConfig 推荐关注的配置
+type SuggestionsConfig struct {
+	// NewUserWindow 注册多久以内的用户会作为新用户被推荐，为0时使用7天
+	NewUserWindow time.Duration `mapstructure:"new_user_window"`
+	// OnlineTTL 用户资料副本中在线状态的有效期，超过后同城推荐视为不在线，为0时使用5分钟
+	OnlineTTL time.Duration `mapstructure:"online_ttl"`
+	// RecorderBatchSize 和 RecorderFlushInterval 控制用户资料副本的批量写入，为0时分别使用100和5秒
+	RecorderBatchSize     int           `mapstructure:"recorder_batch_size"`
+	RecorderFlushInterval time.Duration `mapstructure:"recorder_flush_interval"`
+}
+
+// TimelineConfig 关注信息流的配置，为0的字段使用默认值
+type TimelineConfig struct {
+	MaxAuthors     int `mapstructure:"max_authors"`      // 只合并最近关注的多少个用户的帖子
+	PerAuthorLimit int `mapstructure:"per_author_limit"` // 每页中同一作者最多出现的帖子数
+	ScanLimit      int `mapstructure:"scan_limit"`       // 翻页时每个作者最多向前扫描的帖子数
+	Concurrency    int `mapstructure:"concurrency"`      // 同时向帖子服务发起的最大请求数
+}
+
+// IdempotencyConfig Idempotency-Key请求头的配置
+type IdempotencyConfig struct {
+	// TTL 保存请求结果的时间，为0时使用24小时
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// MigrationsConfig 数据迁移的配置
+type MigrationsConfig struct {
+	// AutoMigrate 为true时服务启动时自动执行未完成的迁移，否则需要手动运行migrate up
+	AutoMigrate bool `mapstructure:"auto_migrate"`
+}
+
 func LoadConfig(path string) (*Config, error) {
 	viper.SetConfigFile(path)
 	viper.AutomaticEnv()
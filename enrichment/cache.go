@@ -0,0 +1,197 @@
+package enrichment
+
+import (
+	"context"
+	"followservice/config"
+	"followservice/proto"
+	"log"
+	"sync/atomic"
+	"time"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultUserTTL     = 5 * time.Minute
+	defaultPostTTL     = time.Minute
+	defaultNegativeTTL = 30 * time.Second
+)
+
+// 缓存值的首字节标记值是否存在，用于区分负缓存
+const (
+	markerMissing byte = 0
+	markerPresent byte = 1
+)
+
+// RemoteBackend 可选的远程缓存后端（例如Redis），用于在多个实例之间共享缓存。
+// 本地缓存未命中时查询远程后端，远程后端出错时视为未命中
+type RemoteBackend interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// CacheStats 缓存命中统计
+type CacheStats struct {
+	Hits         int64 // 命中存在的值
+	NegativeHits int64 // 命中"用户不存在"的负缓存
+	Misses       int64
+	Evictions    int64
+	Size         int // 本地缓存当前条目数
+}
+
+// ProfileCache 缓存用户信息和最新帖子内容，用户不存在的结果会以较短的TTL进行负缓存
+type ProfileCache struct {
+	local       *lru
+	remote      RemoteBackend
+	userTTL     time.Duration
+	postTTL     time.Duration
+	negativeTTL time.Duration
+
+	hits         atomic.Int64
+	negativeHits atomic.Int64
+	misses       atomic.Int64
+	evictions    atomic.Int64
+}
+
+// NewProfileCache 根据配置创建缓存，Size不大于0时返回nil表示不使用缓存；remote可以为nil
+func NewProfileCache(cfg config.ProfileCacheConfig, remote RemoteBackend) *ProfileCache {
+	if cfg.Size <= 0 {
+		return nil
+	}
+
+	cache := &ProfileCache{
+		local:       newLRU(cfg.Size),
+		remote:      remote,
+		userTTL:     cfg.UserTTL,
+		postTTL:     cfg.PostTTL,
+		negativeTTL: cfg.NegativeTTL,
+	}
+	if cache.userTTL <= 0 {
+		cache.userTTL = defaultUserTTL
+	}
+	if cache.postTTL <= 0 {
+		cache.postTTL = defaultPostTTL
+	}
+	if cache.negativeTTL <= 0 {
+		cache.negativeTTL = defaultNegativeTTL
+	}
+	return cache
+}
+
+func userKey(userID string) string {
+	return "user:" + userID
+}
+
+func postKey(userID string) string {
+	return "post:" + userID
+}
+
+// GetUser 返回缓存的用户信息。found为false表示未命中；found为true且userInfo为nil表示用户不存在
+func (c *ProfileCache) GetUser(ctx context.Context, userID string) (userInfo *proto.UserInfo, found bool) {
+	value, ok := c.get(ctx, userKey(userID), c.userTTL)
+	if !ok {
+		return nil, false
+	}
+	if value[0] == markerMissing {
+		c.negativeHits.Add(1)
+		return nil, true
+	}
+
+	userInfo = &proto.UserInfo{}
+	if err := protobuf.Unmarshal(value[1:], userInfo); err != nil {
+		c.local.delete(userKey(userID))
+		return nil, false
+	}
+	c.hits.Add(1)
+	return userInfo, true
+}
+
+// SetUser 缓存用户信息
+func (c *ProfileCache) SetUser(ctx context.Context, userInfo *proto.UserInfo) {
+	payload, err := protobuf.Marshal(userInfo)
+	if err != nil {
+		return
+	}
+	c.set(ctx, userKey(userInfo.Id), append([]byte{markerPresent}, payload...), c.userTTL)
+}
+
+// SetUserMissing 记录用户不存在
+func (c *ProfileCache) SetUserMissing(ctx context.Context, userID string) {
+	c.set(ctx, userKey(userID), []byte{markerMissing}, c.negativeTTL)
+}
+
+// GetLatestPost 返回缓存的最新帖子内容，用户没有帖子时content为空
+func (c *ProfileCache) GetLatestPost(ctx context.Context, userID string) (content string, found bool) {
+	value, ok := c.get(ctx, postKey(userID), c.postTTL)
+	if !ok {
+		return "", false
+	}
+	c.hits.Add(1)
+	return string(value[1:]), true
+}
+
+// SetLatestPost 缓存最新帖子内容
+func (c *ProfileCache) SetLatestPost(ctx context.Context, userID, content string) {
+	c.set(ctx, postKey(userID), append([]byte{markerPresent}, content...), c.postTTL)
+}
+
+// Invalidate 删除用户的全部缓存，在用户资料或帖子变更时调用。
+// 只能删除本实例的本地缓存和远程后端中的值，其他实例的本地缓存要等到TTL过期
+func (c *ProfileCache) Invalidate(ctx context.Context, userID string) {
+	for _, key := range []string{userKey(userID), postKey(userID)} {
+		c.local.delete(key)
+		if c.remote != nil {
+			if err := c.remote.Delete(ctx, key); err != nil {
+				log.Printf("删除远程缓存失败: key=%s, err=%v", key, err)
+			}
+		}
+	}
+}
+
+// Stats 返回缓存命中统计
+func (c *ProfileCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:         c.hits.Load(),
+		NegativeHits: c.negativeHits.Load(),
+		Misses:       c.misses.Load(),
+		Evictions:    c.evictions.Load(),
+		Size:         c.local.len(),
+	}
+}
+
+// get 依次查询本地缓存和远程后端，远程命中时回填本地缓存
+func (c *ProfileCache) get(ctx context.Context, key string, ttl time.Duration) ([]byte, bool) {
+	if value, ok := c.local.get(key); ok && len(value) > 0 {
+		return value, true
+	}
+
+	if c.remote != nil {
+		value, ok, err := c.remote.Get(ctx, key)
+		if err == nil && ok && len(value) > 0 {
+			if value[0] == markerMissing {
+				ttl = c.negativeTTL
+			}
+			c.setLocal(key, value, ttl)
+			return value, true
+		}
+	}
+
+	c.misses.Add(1)
+	return nil, false
+}
+
+func (c *ProfileCache) set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	c.setLocal(key, value, ttl)
+	if c.remote != nil {
+		if err := c.remote.Set(ctx, key, value, ttl); err != nil {
+			log.Printf("写入远程缓存失败: key=%s, err=%v", key, err)
+		}
+	}
+}
+
+func (c *ProfileCache) setLocal(key string, value []byte, ttl time.Duration) {
+	if c.local.set(key, value, ttl) {
+		c.evictions.Add(1)
+	}
+}
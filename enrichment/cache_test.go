@@ -0,0 +1,154 @@
+package enrichment
+
+import (
+	"context"
+	"followservice/config"
+	"followservice/proto"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mapBackend 基于map的远程缓存后端，忽略TTL
+type mapBackend struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (b *mapBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	value, ok := b.values[key]
+	return value, ok, nil
+}
+
+func (b *mapBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.values == nil {
+		b.values = make(map[string][]byte)
+	}
+	b.values[key] = value
+	return nil
+}
+
+func (b *mapBackend) Delete(ctx context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.values, key)
+	return nil
+}
+
+func TestNewProfileCacheDisabled(t *testing.T) {
+	if cache := NewProfileCache(config.ProfileCacheConfig{}, nil); cache != nil {
+		t.Errorf("NewProfileCache() with size 0 = %v, want nil", cache)
+	}
+}
+
+func TestProfileCacheUserAndNegativeEntries(t *testing.T) {
+	ctx := context.Background()
+	cache := NewProfileCache(config.ProfileCacheConfig{Size: 10}, nil)
+
+	if _, found := cache.GetUser(ctx, "a"); found {
+		t.Fatal("GetUser() found a user in an empty cache")
+	}
+	cache.SetUser(ctx, &proto.UserInfo{Id: "a", Username: "alice"})
+	cache.SetUserMissing(ctx, "gone")
+
+	if user, found := cache.GetUser(ctx, "a"); !found || user.GetUsername() != "alice" {
+		t.Errorf("GetUser(a) = %v, %v, want alice", user, found)
+	}
+	if user, found := cache.GetUser(ctx, "gone"); !found || user != nil {
+		t.Errorf("GetUser(gone) = %v, %v, want a negative hit", user, found)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.NegativeHits != 1 || stats.Misses != 1 || stats.Size != 2 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestProfileCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	cache := NewProfileCache(config.ProfileCacheConfig{Size: 10, PostTTL: time.Millisecond, NegativeTTL: time.Millisecond}, nil)
+	cache.SetLatestPost(ctx, "a", "")
+	cache.SetUserMissing(ctx, "b")
+
+	// 没有帖子也会缓存
+	if content, found := cache.GetLatestPost(ctx, "a"); !found || content != "" {
+		t.Errorf("GetLatestPost(a) = %q, %v, want an empty cached post", content, found)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, found := cache.GetLatestPost(ctx, "a"); found {
+		t.Error("post entry survived its TTL")
+	}
+	if _, found := cache.GetUser(ctx, "b"); found {
+		t.Error("negative entry survived its TTL")
+	}
+}
+
+func TestProfileCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	cache := NewProfileCache(config.ProfileCacheConfig{Size: 2}, nil)
+	cache.SetUser(ctx, &proto.UserInfo{Id: "a"})
+	cache.SetUser(ctx, &proto.UserInfo{Id: "b"})
+	cache.GetUser(ctx, "a") // a成为最近使用的条目
+	cache.SetUser(ctx, &proto.UserInfo{Id: "c"})
+
+	if _, found := cache.GetUser(ctx, "b"); found {
+		t.Error("least recently used entry was not evicted")
+	}
+	if _, found := cache.GetUser(ctx, "a"); !found {
+		t.Error("recently used entry was evicted")
+	}
+	if stats := cache.Stats(); stats.Evictions != 1 || stats.Size != 2 {
+		t.Errorf("Stats() = %+v, want 1 eviction and size 2", stats)
+	}
+}
+
+// TestProfileCacheInvalidate 失效只作用于本实例的本地缓存和远程后端，
+// 共享同一远程后端的其他实例在本地缓存过期前仍返回旧值
+func TestProfileCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	remote := &mapBackend{}
+	local := NewProfileCache(config.ProfileCacheConfig{Size: 10}, remote)
+	other := NewProfileCache(config.ProfileCacheConfig{Size: 10}, remote)
+
+	local.SetUser(ctx, &proto.UserInfo{Id: "a", Username: "old"})
+	local.SetLatestPost(ctx, "a", "hello")
+	if user, found := other.GetUser(ctx, "a"); !found || user.GetUsername() != "old" {
+		t.Fatalf("other.GetUser(a) = %v, %v, want the remote value", user, found)
+	}
+
+	local.Invalidate(ctx, "a")
+	if _, found := local.GetUser(ctx, "a"); found {
+		t.Error("user entry survived Invalidate")
+	}
+	if _, found := local.GetLatestPost(ctx, "a"); found {
+		t.Error("post entry survived Invalidate")
+	}
+	if _, ok, _ := remote.Get(ctx, userKey("a")); ok {
+		t.Error("remote entry survived Invalidate")
+	}
+	if _, found := other.GetUser(ctx, "a"); !found {
+		t.Error("other instance's local entry was cleared, expected it to live until its TTL")
+	}
+}
+
+func TestEnrichUsesCache(t *testing.T) {
+	ctx := context.Background()
+	users := &countingUsers{}
+	cache := NewProfileCache(config.ProfileCacheConfig{Size: 10}, nil)
+	enricher := NewEnricher(users, &stubPosts{}, 0, cache)
+
+	enricher.Enrich(ctx, []string{"a", "missing-1"}, Options{})
+	enricher.Enrich(ctx, []string{"a", "missing-1"}, Options{})
+	if calls := users.totalCalls(); calls != 2 {
+		t.Errorf("GetUserInfo calls = %d, want the second lookup served from cache", calls)
+	}
+
+	enricher.Enrich(ctx, []string{"a"}, Options{SkipUserCache: true})
+	if calls := users.totalCalls(); calls != 3 {
+		t.Errorf("GetUserInfo calls = %d, want SkipUserCache to bypass the cache", calls)
+	}
+}
@@ -0,0 +1,194 @@
+package enrichment
+
+import (
+	"context"
+	"followservice/proto"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultConcurrency 未配置时同时进行的最大RPC数
+const DefaultConcurrency = 16
+
+// Profile 用户的展示信息
+type Profile struct {
+	User              *proto.UserInfo
+	LatestPostContent string
+}
+
+// Options 控制需要补充哪些信息
+type Options struct {
+	// LatestPost 为true时同时获取用户最新帖子的内容
+	LatestPost bool
+	// SkipUserCache 为true时不读取缓存的用户信息，直接请求用户服务（结果仍会写入缓存），
+	// 用于在线状态等不能接受缓存延迟的字段
+	SkipUserCache bool
+}
+
+// Enricher 通过用户服务和帖子服务批量获取列表中用户的展示信息。
+// 相同的用户ID只请求一次，所有RPC在concurrency的并发上限内并行执行，并遵循调用方ctx的截止时间。
+// cache不为nil时优先从缓存读取
+type Enricher struct {
+	userClient  proto.UserServiceClient
+	postClient  proto.PostServiceClient
+	concurrency int
+	cache       *ProfileCache
+	// observer 不为nil时，每次从用户服务获取到用户信息后调用，需立即返回
+	observer func(*proto.UserInfo)
+}
+
+func NewEnricher(userClient proto.UserServiceClient, postClient proto.PostServiceClient, concurrency int, cache *ProfileCache) *Enricher {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	return &Enricher{
+		userClient:  userClient,
+		postClient:  postClient,
+		concurrency: concurrency,
+		cache:       cache,
+	}
+}
+
+// SetUserObserver 设置从用户服务获取到用户信息后的回调，需在开始处理请求前调用
+func (e *Enricher) SetUserObserver(observer func(*proto.UserInfo)) {
+	e.observer = observer
+}
+
+// Enrich 返回以用户ID为键的展示信息，获取用户信息失败的用户不在结果中。
+// 最新帖子获取失败时LatestPostContent为空，不影响用户出现在结果中
+func (e *Enricher) Enrich(ctx context.Context, userIDs []string, opts Options) map[string]*Profile {
+	userIDs = dedup(userIDs)
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		users    = make(map[string]*proto.UserInfo, len(userIDs))
+		contents = make(map[string]string, len(userIDs))
+		sem      = make(chan struct{}, e.concurrency)
+	)
+
+	// run 在并发上限内执行task，ctx结束后不再启动新的任务
+	run := func(task func()) {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task()
+		}()
+	}
+
+	for _, userID := range userIDs {
+		userID := userID
+
+		// 已启动的任务可能正在写入结果，读取缓存后同样需要加锁写入
+		var userCached bool
+		if e.cache != nil && !opts.SkipUserCache {
+			var userInfo *proto.UserInfo
+			if userInfo, userCached = e.cache.GetUser(ctx, userID); userCached && userInfo != nil {
+				mu.Lock()
+				users[userID] = userInfo
+				mu.Unlock()
+			}
+		}
+		if !userCached {
+			run(func() {
+				if userInfo := e.fetchUser(ctx, userID); userInfo != nil {
+					mu.Lock()
+					users[userID] = userInfo
+					mu.Unlock()
+				}
+			})
+		}
+
+		if !opts.LatestPost {
+			continue
+		}
+		if e.cache != nil {
+			if content, found := e.cache.GetLatestPost(ctx, userID); found {
+				mu.Lock()
+				contents[userID] = content
+				mu.Unlock()
+				continue
+			}
+		}
+		run(func() {
+			if content, ok := e.fetchLatestPost(ctx, userID); ok {
+				mu.Lock()
+				contents[userID] = content
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	profiles := make(map[string]*Profile, len(users))
+	for userID, userInfo := range users {
+		profiles[userID] = &Profile{
+			User:              userInfo,
+			LatestPostContent: contents[userID],
+		}
+	}
+	return profiles
+}
+
+// fetchUser 从用户服务获取用户信息并写入缓存，获取失败时返回nil
+func (e *Enricher) fetchUser(ctx context.Context, userID string) *proto.UserInfo {
+	userInfo, err := e.userClient.GetUserInfo(ctx, &proto.GetUserInfoRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		// 只对用户不存在进行负缓存，其他错误可能是暂时性的
+		if e.cache != nil && status.Code(err) == codes.NotFound {
+			e.cache.SetUserMissing(ctx, userID)
+		}
+		return nil
+	}
+	if e.cache != nil {
+		e.cache.SetUser(ctx, userInfo)
+	}
+	if e.observer != nil {
+		e.observer(userInfo)
+	}
+	return userInfo
+}
+
+// fetchLatestPost 从帖子服务获取最新帖子内容并写入缓存，用户没有帖子时返回空字符串
+func (e *Enricher) fetchLatestPost(ctx context.Context, userID string) (string, bool) {
+	posts, err := e.postClient.GetUserPosts(ctx, &proto.GetUserPostsRequest{
+		UserId: userID,
+		Limit:  1,
+		Offset: 0,
+	})
+	if err != nil {
+		return "", false
+	}
+
+	var content string
+	if len(posts.Posts) > 0 {
+		content = posts.Posts[0].Content
+	}
+	if e.cache != nil {
+		e.cache.SetLatestPost(ctx, userID, content)
+	}
+	return content, true
+}
+
+func dedup(userIDs []string) []string {
+	seen := make(map[string]bool, len(userIDs))
+	unique := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == "" || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		unique = append(unique, userID)
+	}
+	return unique
+}
@@ -0,0 +1,148 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"followservice/proto"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// countingUsers 记录GetUserInfo的调用次数和最大并发数，ID以"missing"开头的用户不存在
+type countingUsers struct {
+	proto.UserServiceClient
+	delay time.Duration
+
+	mu       sync.Mutex
+	calls    map[string]int
+	inFlight int
+	peak     int
+}
+
+func (u *countingUsers) GetUserInfo(ctx context.Context, in *proto.GetUserInfoRequest, opts ...grpc.CallOption) (*proto.UserInfo, error) {
+	u.mu.Lock()
+	if u.calls == nil {
+		u.calls = make(map[string]int)
+	}
+	u.calls[in.UserId]++
+	u.inFlight++
+	if u.inFlight > u.peak {
+		u.peak = u.inFlight
+	}
+	u.mu.Unlock()
+	defer func() {
+		u.mu.Lock()
+		u.inFlight--
+		u.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(u.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if len(in.UserId) >= 7 && in.UserId[:7] == "missing" {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &proto.UserInfo{Id: in.UserId, Username: "name-" + in.UserId}, nil
+}
+
+func (u *countingUsers) totalCalls() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	total := 0
+	for _, n := range u.calls {
+		total += n
+	}
+	return total
+}
+
+// stubPosts 返回每个用户一条内容为"post-<ID>"的帖子，failing中的用户返回错误
+type stubPosts struct {
+	proto.PostServiceClient
+	failing map[string]bool
+
+	calls atomic.Int64
+}
+
+func (p *stubPosts) GetUserPosts(ctx context.Context, in *proto.GetUserPostsRequest, opts ...grpc.CallOption) (*proto.GetUserPostsResponse, error) {
+	p.calls.Add(1)
+	if p.failing[in.UserId] {
+		return nil, errors.New("post service unavailable")
+	}
+	return &proto.GetUserPostsResponse{Posts: []*proto.Post{{Content: "post-" + in.UserId}}}, nil
+}
+
+func TestEnrichDedupsAndSkipsFailures(t *testing.T) {
+	users := &countingUsers{}
+	posts := &stubPosts{failing: map[string]bool{"b": true}}
+	enricher := NewEnricher(users, posts, 4, nil)
+
+	profiles := enricher.Enrich(context.Background(), []string{"a", "b", "a", "", "missing-1", "b"}, Options{LatestPost: true})
+
+	if users.totalCalls() != 3 {
+		t.Errorf("GetUserInfo calls = %v, want one per unique id", users.calls)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("got %d profiles, want a and b", len(profiles))
+	}
+	if got := profiles["a"]; got.User.Username != "name-a" || got.LatestPostContent != "post-a" {
+		t.Errorf("profile a = %+v", got)
+	}
+	// 最新帖子获取失败不影响用户出现在结果中
+	if got := profiles["b"]; got.User.Username != "name-b" || got.LatestPostContent != "" {
+		t.Errorf("profile b = %+v, want user without post", got)
+	}
+}
+
+func TestEnrichWithoutLatestPost(t *testing.T) {
+	posts := &stubPosts{}
+	profiles := NewEnricher(&countingUsers{}, posts, 0, nil).Enrich(context.Background(), []string{"a"}, Options{})
+	if posts.calls.Load() != 0 {
+		t.Errorf("GetUserPosts called %d times without LatestPost", posts.calls.Load())
+	}
+	if len(profiles) != 1 {
+		t.Errorf("got %d profiles, want 1", len(profiles))
+	}
+}
+
+func TestEnrichBoundsConcurrency(t *testing.T) {
+	users := &countingUsers{delay: 5 * time.Millisecond}
+	ids := make([]string, 20)
+	for i := range ids {
+		ids[i] = string(rune('a' + i))
+	}
+
+	profiles := NewEnricher(users, &stubPosts{}, 3, nil).Enrich(context.Background(), ids, Options{})
+	if len(profiles) != len(ids) {
+		t.Errorf("got %d profiles, want %d", len(profiles), len(ids))
+	}
+	if users.peak > 3 {
+		t.Errorf("peak concurrent calls = %d, want at most 3", users.peak)
+	}
+	if users.peak < 2 {
+		t.Errorf("peak concurrent calls = %d, calls were not parallel", users.peak)
+	}
+}
+
+// TestEnrichHonorsDeadline 超过调用方的截止时间后不再等待未完成的RPC
+func TestEnrichHonorsDeadline(t *testing.T) {
+	users := &countingUsers{delay: time.Second}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	profiles := NewEnricher(users, &stubPosts{}, 2, nil).Enrich(ctx, []string{"a", "b", "c", "d"}, Options{})
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Enrich took %v after the deadline", elapsed)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("got %d profiles, want none", len(profiles))
+	}
+}
@@ -0,0 +1,41 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPublisher 将消息保存在内存中，用于单元测试
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+	// Fail 不为nil时在发布前调用，返回的错误会使本次发布失败，用于模拟下游故障
+	Fail func(message Message) error
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, message Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Fail != nil {
+		if err := p.Fail(message); err != nil {
+			return err
+		}
+	}
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+// Messages 返回已发布消息的副本
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	messages := make([]Message, len(p.messages))
+	copy(messages, p.messages)
+	return messages
+}
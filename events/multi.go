@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"followservice/models"
+)
+
+// MultiPublisher 依次将消息交给每个发布器，任一发布器失败时整条消息会被重试，
+// 因此排在前面的发布器可能收到重复消息
+type MultiPublisher struct {
+	publishers []EventPublisher
+}
+
+func NewMultiPublisher(publishers ...EventPublisher) *MultiPublisher {
+	return &MultiPublisher{
+		publishers: publishers,
+	}
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, message Message) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TypeFilter 只将指定类型的消息交给publisher，其余消息直接视为发布成功
+type TypeFilter struct {
+	publisher EventPublisher
+	types     map[models.EventType]bool
+}
+
+func NewTypeFilter(publisher EventPublisher, types ...models.EventType) *TypeFilter {
+	filter := &TypeFilter{
+		publisher: publisher,
+		types:     make(map[models.EventType]bool, len(types)),
+	}
+	for _, eventType := range types {
+		filter.types[eventType] = true
+	}
+	return filter
+}
+
+func (p *TypeFilter) Publish(ctx context.Context, message Message) error {
+	if !p.types[message.Type] {
+		return nil
+	}
+	return p.publisher.Publish(ctx, message)
+}
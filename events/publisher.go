@@ -0,0 +1,33 @@
+package events
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+)
+
+// Message 发布到下游的关注关系事件，下游需按ID去重（投递语义为至少一次）
+type Message struct {
+	ID          string           `json:"id"`
+	Type        models.EventType `json:"type"`
+	FollowerID  string           `json:"followerId"`
+	FollowingID string           `json:"followingId"`
+	OccurredAt  time.Time        `json:"occurredAt"`
+}
+
+// NewMessage 由发件箱事件构建待发布的消息
+func NewMessage(event models.OutboxEvent) Message {
+	return Message{
+		ID:          event.ID,
+		Type:        event.Type,
+		FollowerID:  event.FollowerID,
+		FollowingID: event.FollowingID,
+		OccurredAt:  event.OccurredAt,
+	}
+}
+
+// EventPublisher 定义事件发布接口，可替换为消息队列等实现
+type EventPublisher interface {
+	// Publish 发布一条消息，返回错误时该消息会被重试
+	Publish(ctx context.Context, message Message) error
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/store"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// blockAndSever 拉黑目标用户，并解除双方之间的关注关系、静音和待处理的关注请求。
+// 已经拉黑时仍会执行解除步骤后再返回ErrAlreadyBlocked，使上次拉黑后解除失败的请求可以通过重试修复
+func blockAndSever(ctx context.Context, follows store.FollowStore, requests store.FollowRequestStore, blocks store.BlockStore, mutes store.MuteStore, lists store.ListStore, blockerID, blockedID string) error {
+	_, blockErr := blocks.Block(ctx, blockerID, blockedID)
+	if blockErr != nil && !errors.Is(blockErr, store.ErrAlreadyBlocked) {
+		return blockErr
+	}
+
+	if err := endFollow(ctx, follows, lists, blockerID, blockedID, models.UnfollowReasonBlock); err != nil && !errors.Is(err, store.ErrNotFollowing) {
+		return err
+	}
+	if err := endFollow(ctx, follows, lists, blockedID, blockerID, models.UnfollowReasonBlock); err != nil && !errors.Is(err, store.ErrNotFollowing) {
+		return err
+	}
+	if err := mutes.Unmute(ctx, blockerID, blockedID); err != nil && !errors.Is(err, store.ErrNotMuted) {
+		return err
+	}
+	if err := mutes.Unmute(ctx, blockedID, blockerID); err != nil && !errors.Is(err, store.ErrNotMuted) {
+		return err
+	}
+	if err := requests.CancelBetween(ctx, blockerID, blockedID); err != nil {
+		return err
+	}
+	return blockErr
+}
+
+// blockedBetween 返回userID是否拉黑了targetID，以及targetID是否拉黑了userID
+func blockedBetween(ctx context.Context, blocks store.BlockStore, userID, targetID string) (bool, bool, error) {
+	blockedByUser, err := blocks.IsBlocked(ctx, userID, targetID)
+	if err != nil {
+		return false, false, err
+	}
+	blockedByTarget, err := blocks.IsBlocked(ctx, targetID, userID)
+	if err != nil {
+		return false, false, err
+	}
+	return blockedByUser, blockedByTarget, nil
+}
+
+// BlockUserRequest 定义拉黑用户的请求参数
+type BlockUserRequest struct {
+	TargetUserID string `json:"targetUserId" binding:"required,len=36"`
+}
+
+// BlockUser 拉黑用户，同时解除双方的关注关系和静音
+func (h *FollowHandler) BlockUser(c *gin.Context) {
+	var req BlockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	if userID.(string) == req.TargetUserID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不能拉黑自己"})
+		return
+	}
+
+	err := blockAndSever(c.Request.Context(), h.store, h.requests, h.blocks, h.mutes, h.lists, userID.(string), req.TargetUserID)
+	if errors.Is(err, store.ErrAlreadyBlocked) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "已经拉黑该用户"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "拉黑成功",
+	})
+}
+
+// UnblockUser 解除对用户的拉黑，不会恢复之前的关注关系
+func (h *FollowHandler) UnblockUser(c *gin.Context) {
+	// 获取目标用户ID
+	targetUserID := c.Query("targetUserId")
+	if len(targetUserID) != 36 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	err := h.blocks.Unblock(c.Request.Context(), userID.(string), targetUserID)
+	if errors.Is(err, store.ErrNotBlocked) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "未拉黑该用户"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "解除拉黑成功",
+	})
+}
+
+// GetBlockedUsersRequest 定义获取拉黑列表的请求参数
+type GetBlockedUsersRequest struct {
+	Limit  int `form:"limit,default=10"`
+	Offset int `form:"offset,default=0"`
+}
+
+// BlockedUsersResponse 定义拉黑列表的响应结构
+type BlockedUsersResponse struct {
+	BlockedUsers []BlockedUserDetail `json:"blockedUsers"`
+	TotalCount   int64               `json:"totalCount"`
+}
+
+// BlockedUserDetail 定义每个被拉黑用户的详细信息
+type BlockedUserDetail struct {
+	TargetUser UserSummary `json:"targetUser"`
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+// GetBlockedUsers 获取当前用户的拉黑列表
+func (h *FollowHandler) GetBlockedUsers(c *gin.Context) {
+	var req GetBlockedUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 验证参数
+	if req.Limit < 1 {
+		req.Limit = 10
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	page, err := h.blocks.ListBlocked(c.Request.Context(), userID.(string), store.ListOptions{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	// 构建响应数据
+	response := BlockedUsersResponse{
+		BlockedUsers: make([]BlockedUserDetail, 0, len(page.Blocks)),
+		TotalCount:   page.TotalCount,
+	}
+
+	// 获取被拉黑用户的信息
+	userIDs := make([]string, 0, len(page.Blocks))
+	for _, block := range page.Blocks {
+		userIDs = append(userIDs, block.BlockedID)
+	}
+	profiles := h.enricher.Enrich(c.Request.Context(), userIDs, enrichment.Options{})
+
+	for _, block := range page.Blocks {
+		profile, ok := profiles[block.BlockedID]
+		if !ok {
+			continue // 跳过获取失败的用户
+		}
+
+		response.BlockedUsers = append(response.BlockedUsers, BlockedUserDetail{
+			TargetUser: newUserSummary(profile.User),
+			Timestamp:  block.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
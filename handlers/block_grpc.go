@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/proto"
+	"followservice/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *FollowGrpcServer) BlockUser(ctx context.Context, req *proto.BlockUserRequest) (*proto.BlockUserResponse, error) {
+	if req.UserId == "" || req.TargetId == "" || req.UserId == req.TargetId {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id or target_id")
+	}
+
+	err := blockAndSever(ctx, s.store, s.requests, s.blocks, s.mutes, s.lists, req.UserId, req.TargetId)
+	if err != nil && !errors.Is(err, store.ErrAlreadyBlocked) {
+		return nil, err
+	}
+
+	return &proto.BlockUserResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *FollowGrpcServer) UnblockUser(ctx context.Context, req *proto.UnblockUserRequest) (*proto.UnblockUserResponse, error) {
+	err := s.blocks.Unblock(ctx, req.UserId, req.TargetId)
+	if err != nil && !errors.Is(err, store.ErrNotBlocked) {
+		return nil, err
+	}
+
+	return &proto.UnblockUserResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *FollowGrpcServer) IsBlocked(ctx context.Context, req *proto.IsBlockedRequest) (*proto.IsBlockedResponse, error) {
+	blockedByUser, blockedByTarget, err := blockedBetween(ctx, s.blocks, req.UserId, req.TargetId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.IsBlockedResponse{
+		Blocked:         blockedByUser || blockedByTarget,
+		BlockedByUser:   blockedByUser,
+		BlockedByTarget: blockedByTarget,
+	}, nil
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/proto"
+	"followservice/store"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// failOnceRequests 第一次调用CancelBetween时返回错误，模拟拉黑后解除关系的步骤失败
+type failOnceRequests struct {
+	store.FollowRequestStore
+	failed bool
+}
+
+func (r *failOnceRequests) CancelBetween(ctx context.Context, userA, userB string) error {
+	if !r.failed {
+		r.failed = true
+		return errors.New("write conflict")
+	}
+	return r.FollowRequestStore.CancelBetween(ctx, userA, userB)
+}
+
+// severed 检查alice和bob之间的关注、静音和待处理的关注请求是否都已解除
+func severed(t *testing.T, s *testStores) bool {
+	t.Helper()
+	ctx := context.Background()
+	if s.isFollowing(t, alice, bob) || s.isFollowing(t, bob, alice) {
+		return false
+	}
+	for _, userID := range []string{alice, bob} {
+		muted, err := s.mutes.MutedUserIDs(ctx, userID)
+		if err != nil {
+			t.Fatalf("MutedUserIDs() error = %v", err)
+		}
+		if len(muted) > 0 {
+			return false
+		}
+		outgoing, err := s.requests.ListOutgoing(ctx, userID, store.ListOptions{})
+		if err != nil {
+			t.Fatalf("ListOutgoing() error = %v", err)
+		}
+		if outgoing.TotalCount > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// connect 让alice和bob互相关注、互相静音，并由bob向alice发送关注请求
+func connect(t *testing.T, s *testStores) {
+	t.Helper()
+	ctx := context.Background()
+	s.follow(t, alice, bob)
+	s.follow(t, bob, alice)
+	if _, err := s.mutes.Mute(ctx, alice, bob); err != nil {
+		t.Fatalf("Mute() error = %v", err)
+	}
+	if _, err := s.mutes.Mute(ctx, bob, alice); err != nil {
+		t.Fatalf("Mute() error = %v", err)
+	}
+	if _, err := s.requests.CreateRequest(ctx, bob, alice); err != nil {
+		t.Fatalf("CreateRequest() error = %v", err)
+	}
+}
+
+func TestBlockUserSeversRelationships(t *testing.T) {
+	s := newTestStores()
+	connect(t, s)
+	s.follow(t, alice, carol)
+
+	w := serve(s.handler().BlockUser, http.MethodPost, "/block", "/block", alice, targetBody(bob))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	if !severed(t, s) {
+		t.Error("relationships between alice and bob survived the block")
+	}
+	if !s.isFollowing(t, alice, carol) {
+		t.Error("block removed an unrelated follow")
+	}
+}
+
+// TestBlockUserRetryRepairsSever 拉黑成功但解除关系失败时，重试拉黑会完成解除
+func TestBlockUserRetryRepairsSever(t *testing.T) {
+	s := newTestStores()
+	connect(t, s)
+	requests := &failOnceRequests{FollowRequestStore: s.requests}
+	h := NewFollowHandler(s.follows, requests, s.settings, s.blocks, s.mutes, s.lists, s.enricher(), nil, nil)
+
+	if w := serve(h.BlockUser, http.MethodPost, "/block", "/block", alice, targetBody(bob)); w.Code != http.StatusInternalServerError {
+		t.Fatalf("first block status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if blocked, _ := s.blocks.IsBlocked(context.Background(), alice, bob); !blocked {
+		t.Fatal("block was not saved before the failing step")
+	}
+
+	if w := serve(h.BlockUser, http.MethodPost, "/block", "/block", alice, targetBody(bob)); w.Code != http.StatusBadRequest {
+		t.Errorf("retry status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !severed(t, s) {
+		t.Error("retrying the block did not sever the remaining relationships")
+	}
+}
+
+// TestGrpcBlockUserAlreadyBlocked gRPC重复拉黑返回成功，并解除拉黑后遗留的关系
+func TestGrpcBlockUserAlreadyBlocked(t *testing.T) {
+	s := newTestStores()
+	if _, err := s.blocks.Block(context.Background(), alice, bob); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	connect(t, s)
+
+	response, err := s.grpcServer().BlockUser(context.Background(), &proto.BlockUserRequest{UserId: alice, TargetId: bob})
+	if err != nil || !response.Success {
+		t.Fatalf("BlockUser() = %v, %v, want success", response, err)
+	}
+	if !severed(t, s) {
+		t.Error("relationships between alice and bob survived the repeated block")
+	}
+}
+
+func TestBlockUserValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "缺少目标用户", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "不能拉黑自己", body: targetBody(alice), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			if w := serve(s.handler().BlockUser, http.MethodPost, "/block", "/block", alice, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestFollowUserBlocked(t *testing.T) {
+	for _, blocker := range []string{alice, bob} {
+		t.Run("blocker "+blocker[len(blocker)-1:], func(t *testing.T) {
+			s := newTestStores()
+			other := bob
+			if blocker == bob {
+				other = alice
+			}
+			if _, err := s.blocks.Block(context.Background(), blocker, other); err != nil {
+				t.Fatalf("Block() error = %v", err)
+			}
+
+			w := serve(s.handler().FollowUser, http.MethodPost, "/user", "/user", alice, targetBody(bob))
+			if w.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+			}
+			if s.isFollowing(t, alice, bob) {
+				t.Error("follow was created across a block")
+			}
+		})
+	}
+}
+
+func TestUnblockUser(t *testing.T) {
+	s := newTestStores()
+	h := s.handler()
+	if _, err := s.blocks.Block(context.Background(), alice, bob); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+
+	if w := serve(h.UnblockUser, http.MethodDelete, "/block", "/block?targetUserId="+bob, alice, ""); w.Code != http.StatusOK {
+		t.Fatalf("unblock status = %d, body %s", w.Code, w.Body.String())
+	}
+	if w := serve(h.UnblockUser, http.MethodDelete, "/block", "/block?targetUserId="+bob, alice, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("second unblock status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	// 解除拉黑后可以重新关注
+	if w := serve(h.FollowUser, http.MethodPost, "/user", "/user", alice, targetBody(bob)); w.Code != http.StatusOK {
+		t.Errorf("follow after unblock status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
+
+func TestIsBlocked(t *testing.T) {
+	s := newTestStores()
+	if _, err := s.blocks.Block(context.Background(), bob, alice); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+
+	response, err := s.grpcServer().IsBlocked(context.Background(), &proto.IsBlockedRequest{UserId: alice, TargetId: bob})
+	if err != nil {
+		t.Fatalf("IsBlocked() error = %v", err)
+	}
+	if !response.Blocked || response.BlockedByUser || !response.BlockedByTarget {
+		t.Errorf("IsBlocked() = %+v, want blocked by target only", response)
+	}
+}
+
+func TestBlockedUsersHiddenFromFans(t *testing.T) {
+	s := newTestStores()
+	s.follow(t, bob, alice)
+	s.follow(t, carol, alice)
+	if _, err := s.blocks.Block(context.Background(), carol, alice); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+
+	w := serve(s.handler().GetMyFans, http.MethodGet, "/my-fans", "/my-fans", alice, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	response := decode[FansResponse](t, w)
+	got := make([]string, 0, len(response.Fans))
+	for _, fan := range response.Fans {
+		got = append(got, fan.TargetUser.ID)
+	}
+	if !reflect.DeepEqual(got, []string{bob}) || response.TotalCount != 1 {
+		t.Errorf("fans = %v (total %d), want [bob]", got, response.TotalCount)
+	}
+}
+
+func TestGetBlockedUsers(t *testing.T) {
+	s := newTestStores()
+	h := s.handler()
+	for _, userID := range []string{bob, carol, dave} {
+		if w := serve(h.BlockUser, http.MethodPost, "/block", "/block", alice, targetBody(userID)); w.Code != http.StatusOK {
+			t.Fatalf("block status = %d", w.Code)
+		}
+	}
+
+	w := serve(h.GetBlockedUsers, http.MethodGet, "/blocks", "/blocks?limit=2&offset=1", alice, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	response := decode[BlockedUsersResponse](t, w)
+	got := make([]string, 0, len(response.BlockedUsers))
+	for _, blocked := range response.BlockedUsers {
+		got = append(got, blocked.TargetUser.ID)
+	}
+	if !reflect.DeepEqual(got, []string{carol, bob}) || response.TotalCount != 3 {
+		t.Errorf("blocked = %v (total %d), want [carol bob] of 3", got, response.TotalCount)
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+)
+
+// InvalidateProfileCache 清除本实例的用户信息缓存，其他实例的本地缓存不受影响，在TTL过期后更新
+func (s *FollowGrpcServer) InvalidateProfileCache(ctx context.Context, req *proto.InvalidateProfileCacheRequest) (*proto.InvalidateProfileCacheResponse, error) {
+	if s.profileCache != nil {
+		for _, userId := range req.UserIds {
+			s.profileCache.Invalidate(ctx, userId)
+		}
+	}
+
+	return &proto.InvalidateProfileCacheResponse{
+		Success: true,
+	}, nil
+}
+
+func (s *FollowGrpcServer) GetProfileCacheStats(ctx context.Context, req *proto.GetProfileCacheStatsRequest) (*proto.GetProfileCacheStatsResponse, error) {
+	if s.profileCache == nil {
+		return &proto.GetProfileCacheStatsResponse{}, nil
+	}
+
+	stats := s.profileCache.Stats()
+	return &proto.GetProfileCacheStatsResponse{
+		Enabled:      true,
+		Hits:         stats.Hits,
+		NegativeHits: stats.NegativeHits,
+		Misses:       stats.Misses,
+		Evictions:    stats.Evictions,
+		Size:         int64(stats.Size),
+	}, nil
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/store"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultBetweenUsersLimit = 10
+	// maxBetweenUsersLimit 单页的最大数量，每个用户都需要请求用户服务补充信息
+	maxBetweenUsersLimit = 50
+)
+
+// GetBetweenUsersRequest 定义查询当前用户与另一个用户之间关系的请求参数
+type GetBetweenUsersRequest struct {
+	Limit  int    `form:"limit,default=10"`
+	Cursor string `form:"cursor"`
+}
+
+// BetweenUsersResponse 定义共同关注和"关注的人中谁关注了TA"的响应
+type BetweenUsersResponse struct {
+	Users      []UserSummary `json:"users"`
+	TotalCount int64         `json:"totalCount"`
+	NextCursor string        `json:"nextCursor"`
+}
+
+// GetCommonFollowing 获取当前用户和目标用户共同关注的用户，按当前用户的关注时间倒序。
+// 结果是目标用户关注列表的一部分，受目标用户关注列表的可见范围限制
+func (h *FollowHandler) GetCommonFollowing(c *gin.Context) {
+	h.listBetweenUsers(c, (*models.UserSettings).FollowsVisibilityOrDefault, h.store.ListCommonFollowing)
+}
+
+// GetFollowedBy 获取当前用户关注的人中也关注了目标用户的用户，按当前用户的关注时间倒序。
+// 结果是目标用户粉丝列表的一部分，受目标用户粉丝列表的可见范围限制
+func (h *FollowHandler) GetFollowedBy(c *gin.Context) {
+	h.listBetweenUsers(c, (*models.UserSettings).FansVisibilityOrDefault, h.store.ListFollowingWhoFollow)
+}
+
+// listBetweenUsers 检查当前用户能否查看目标用户的列表后，使用list查询当前用户与路径参数中的目标用户之间的关系，
+// 并补充用户信息。visibility返回结果所属的目标用户列表的可见范围
+func (h *FollowHandler) listBetweenUsers(
+	c *gin.Context,
+	visibility func(*models.UserSettings) models.ListVisibility,
+	list func(ctx context.Context, userID, targetID string, opts store.ListOptions) (*store.FollowPage, error),
+) {
+	var req GetBetweenUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	req.Limit = clampBetweenUsersLimit(req.Limit)
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+	targetID := c.Param("id")
+	if len(targetID) != 36 || targetID == userID.(string) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 存在拉黑关系或目标用户未向当前用户公开列表时不展示双方之间的关系
+	allowed, err := canViewUserFollows(c.Request.Context(), h.store, h.blocks, h.settings, userID.(string), targetID, visibility)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "该用户未公开此列表"})
+		return
+	}
+
+	// 隐藏存在拉黑关系的用户
+	hiddenUserIDs, err := h.blocks.RelatedUserIDs(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	page, err := list(c.Request.Context(), userID.(string), targetID, store.ListOptions{
+		Limit:          req.Limit,
+		Cursor:         cursor,
+		ExcludeUserIDs: hiddenUserIDs,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	response := BetweenUsersResponse{
+		Users:      make([]UserSummary, 0, len(page.Follows)),
+		TotalCount: page.TotalCount,
+		NextCursor: page.NextCursor,
+	}
+	profiles := h.enricher.Enrich(c.Request.Context(), followingIDs(page.Follows), enrichment.Options{})
+	for _, follow := range page.Follows {
+		profile, ok := profiles[follow.FollowingID]
+		if !ok {
+			continue // 跳过获取失败的用户
+		}
+		response.Users = append(response.Users, newUserSummary(profile.User))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func clampBetweenUsersLimit(limit int) int {
+	if limit <= 0 {
+		return defaultBetweenUsersLimit
+	}
+	if limit > maxBetweenUsersLimit {
+		return maxBetweenUsersLimit
+	}
+	return limit
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *FollowGrpcServer) ListCommonFollowing(ctx context.Context, req *proto.ListBetweenUsersRequest) (*proto.ListFollowsResponse, error) {
+	return s.listBetweenUsers(ctx, req, (*models.UserSettings).FollowsVisibilityOrDefault, s.store.ListCommonFollowing)
+}
+
+func (s *FollowGrpcServer) ListFollowedBy(ctx context.Context, req *proto.ListBetweenUsersRequest) (*proto.ListFollowsResponse, error) {
+	return s.listBetweenUsers(ctx, req, (*models.UserSettings).FansVisibilityOrDefault, s.store.ListFollowingWhoFollow)
+}
+
+// listBetweenUsers 使用游标分页查询user_id与target_id之间的关系，返回user_id关注的用户。
+// user_id无权查看target_id的对应列表时返回PERMISSION_DENIED
+func (s *FollowGrpcServer) listBetweenUsers(
+	ctx context.Context,
+	req *proto.ListBetweenUsersRequest,
+	visibility func(*models.UserSettings) models.ListVisibility,
+	list func(ctx context.Context, userID, targetID string, opts store.ListOptions) (*store.FollowPage, error),
+) (*proto.ListFollowsResponse, error) {
+	if req.TargetId == "" {
+		return nil, status.Error(codes.InvalidArgument, "target_id is required")
+	}
+	if req.UserId != "" && req.UserId != req.TargetId {
+		allowed, err := canViewUserFollows(ctx, s.store, s.blocks, s.settings, req.UserId, req.TargetId, visibility)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, status.Error(codes.PermissionDenied, "target's list is not visible to user")
+		}
+	}
+
+	return s.listFollows(ctx, &proto.ListFollowsRequest{
+		UserId:   req.UserId,
+		PageSize: req.PageSize,
+		Cursor:   req.Cursor,
+	}, func(ctx context.Context, userID string, opts store.ListOptions) (*store.FollowPage, error) {
+		return list(ctx, userID, req.TargetId, opts)
+	}, func(f models.Follow) string {
+		return f.FollowingID
+	})
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"followservice/models"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetCommonFollowing(t *testing.T) {
+	s := newTestStores()
+	for _, userID := range []string{carol, dave} {
+		s.follow(t, alice, userID)
+		s.follow(t, bob, userID)
+	}
+	s.follow(t, alice, missing)
+
+	w := serve(s.handler().GetCommonFollowing, http.MethodGet, "/users/:id/common-following", "/users/"+bob+"/common-following", alice, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	response := decode[BetweenUsersResponse](t, w)
+	ids := make([]string, 0, len(response.Users))
+	for _, user := range response.Users {
+		ids = append(ids, user.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{dave, carol}) || response.TotalCount != 2 || response.NextCursor != "" {
+		t.Errorf("response = %v (total %d, cursor %q), want [dave carol]", ids, response.TotalCount, response.NextCursor)
+	}
+}
+
+// TestGetBetweenUsersLimit limit超过上限时按maxBetweenUsersLimit返回，而不是一次补充所有用户的信息
+func TestGetBetweenUsersLimit(t *testing.T) {
+	s := newTestStores()
+	for i := 0; i < maxBetweenUsersLimit+5; i++ {
+		userID := fmt.Sprintf("00000000-0000-0000-0001-%012d", i)
+		s.follow(t, alice, userID)
+		s.follow(t, userID, bob)
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{query: "", want: defaultBetweenUsersLimit},
+		{query: "?limit=0", want: defaultBetweenUsersLimit},
+		{query: "?limit=3", want: 3},
+		{query: "?limit=1000", want: maxBetweenUsersLimit},
+	}
+	for _, tt := range tests {
+		w := serve(s.handler().GetFollowedBy, http.MethodGet, "/users/:id/followed-by", "/users/"+bob+"/followed-by"+tt.query, alice, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: status = %d, body %s", tt.query, w.Code, w.Body.String())
+		}
+		response := decode[BetweenUsersResponse](t, w)
+		if len(response.Users) != tt.want || response.NextCursor == "" {
+			t.Errorf("%q: got %d users (cursor %q), want %d and a next page", tt.query, len(response.Users), response.NextCursor, tt.want)
+		}
+	}
+}
+
+func TestGetBetweenUsersForbidden(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   models.UserSettings
+		target     string
+		wantStatus int
+	}{
+		{name: "关注列表仅自己可见", settings: models.UserSettings{UserID: bob, FollowsVisibility: models.ListVisibilityHidden}, target: "common-following", wantStatus: http.StatusForbidden},
+		{name: "粉丝列表不受关注列表可见范围影响", settings: models.UserSettings{UserID: bob, FollowsVisibility: models.ListVisibilityHidden}, target: "followed-by", wantStatus: http.StatusOK},
+		{name: "粉丝列表仅互关可见", settings: models.UserSettings{UserID: bob, FansVisibility: models.ListVisibilityMutuals}, target: "followed-by", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			settings := tt.settings
+			if err := s.settings.SaveSettings(context.Background(), &settings); err != nil {
+				t.Fatalf("SaveSettings() error = %v", err)
+			}
+			handler := s.handler().GetCommonFollowing
+			if tt.target == "followed-by" {
+				handler = s.handler().GetFollowedBy
+			}
+
+			w := serve(handler, http.MethodGet, "/users/:id/"+tt.target, "/users/"+bob+"/"+tt.target, alice, "")
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+
+	// 目标用户为当前用户
+	w := serve(newTestStores().handler().GetCommonFollowing, http.MethodGet, "/users/:id/common-following", "/users/"+alice+"/common-following", alice, "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("self status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
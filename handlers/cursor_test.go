@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestGetMyFollowsCursorPaging 按nextCursor翻完关注列表，每个用户只出现一次
+func TestGetMyFollowsCursorPaging(t *testing.T) {
+	s := newTestStores()
+	h := s.handler()
+	for _, target := range []string{bob, carol, dave, missing} {
+		s.follow(t, alice, target)
+	}
+
+	seen := make(map[string]int)
+	target := "/my-follows?limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("paging did not terminate")
+		}
+		w := serve(h.GetMyFollows, http.MethodGet, "/my-follows", target, alice, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+		}
+		response := decode[FollowResponse](t, w)
+		for _, follow := range response.Follows {
+			seen[follow.TargetUser.ID]++
+		}
+		if response.NextCursor == "" {
+			break
+		}
+		target = "/my-follows?limit=2&cursor=" + url.QueryEscape(response.NextCursor)
+	}
+
+	// missing在用户服务中不存在，不出现在结果中
+	if len(seen) != 3 || seen[bob] != 1 || seen[carol] != 1 || seen[dave] != 1 {
+		t.Errorf("seen = %v, want bob, carol and dave once each", seen)
+	}
+}
+
+func TestGetMyFollowsInvalidCursor(t *testing.T) {
+	s := newTestStores()
+	w := serve(s.handler().GetMyFollows, http.MethodGet, "/my-follows", "/my-follows?cursor=!!!", alice, "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGrpcListFollowersCursorPaging(t *testing.T) {
+	s := newTestStores()
+	server := s.grpcServer()
+	for _, follower := range []string{bob, carol, dave} {
+		s.follow(t, follower, alice)
+	}
+
+	seen := make(map[string]bool)
+	req := &proto.ListFollowsRequest{UserId: alice, PageSize: 2}
+	for pages := 0; ; pages++ {
+		if pages > 2 {
+			t.Fatal("paging did not terminate")
+		}
+		response, err := server.ListFollowers(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ListFollowers() error = %v", err)
+		}
+		if response.TotalCount != 3 {
+			t.Errorf("TotalCount = %d, want 3", response.TotalCount)
+		}
+		for _, entry := range response.Entries {
+			if seen[entry.UserId] {
+				t.Errorf("%s returned twice", entry.UserId)
+			}
+			seen[entry.UserId] = true
+		}
+		if response.NextCursor == "" {
+			break
+		}
+		req.Cursor = response.NextCursor
+	}
+	if len(seen) != 3 {
+		t.Errorf("seen %d followers, want 3", len(seen))
+	}
+
+	_, err := server.ListFollowers(context.Background(), &proto.ListFollowsRequest{UserId: alice, Cursor: "!!!"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid cursor error = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"followservice/models"
+	"followservice/proto"
+)
+
+// UserSummary 定义列表中展示的用户信息
+type UserSummary struct {
+	ID       string `json:"id"`
+	Avatar   string `json:"avatar"`
+	Username string `json:"username"`
+}
+
+func newUserSummary(userInfo *proto.UserInfo) UserSummary {
+	return UserSummary{
+		ID:       userInfo.Id,
+		Avatar:   userInfo.Avatar,
+		Username: userInfo.Username,
+	}
+}
+
+// followingIDs 返回关注关系中被关注方的用户ID
+func followingIDs(follows []models.Follow) []string {
+	userIDs := make([]string, 0, len(follows))
+	for _, follow := range follows {
+		userIDs = append(userIDs, follow.FollowingID)
+	}
+	return userIDs
+}
+
+// followerIDs 返回关注关系中关注方的用户ID
+func followerIDs(follows []models.Follow) []string {
+	userIDs := make([]string, 0, len(follows))
+	for _, follow := range follows {
+		userIDs = append(userIDs, follow.FollowerID)
+	}
+	return userIDs
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"followservice/proto"
+	"followservice/timeline"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 50
+)
+
+// GetFeedRequest 定义获取关注信息流的请求参数
+type GetFeedRequest struct {
+	Limit  int    `form:"limit,default=20"`
+	Cursor string `form:"cursor"`
+}
+
+// FeedPost 定义信息流中的一条帖子
+type FeedPost struct {
+	ID         string      `json:"id"`
+	Content    string      `json:"content"`
+	Images     []string    `json:"images"`
+	Author     UserSummary `json:"author"`
+	City       string      `json:"city"`
+	Visibility string      `json:"visibility"`
+	Likes      int32       `json:"likes"`
+	Comments   int32       `json:"comments"`
+	Shares     int32       `json:"shares"`
+	IsLiked    bool        `json:"isLiked"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
+
+// FeedLimits 定义信息流的合并上限以及本页是否受其影响
+type FeedLimits struct {
+	MaxAuthors       int  `json:"maxAuthors"`
+	PerAuthorLimit   int  `json:"perAuthorLimit"`
+	ScanLimit        int  `json:"scanLimit"`
+	AuthorsTruncated bool `json:"authorsTruncated"` // 关注的人超过maxAuthors，更早关注的人的帖子不在信息流中
+	ScanTruncated    bool `json:"scanTruncated"`    // 有作者达到scanLimit，其更早的帖子不会出现在本页和后续页中
+}
+
+// GetFeed 获取当前用户关注的人的帖子，按发布时间倒序
+func (h *FollowHandler) GetFeed(c *gin.Context) {
+	var req GetFeedRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	page, err := h.timeline.Feed(c.Request.Context(), userID.(string), cursor, clampFeedLimit(req.Limit))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	posts := make([]FeedPost, 0, len(page.Posts))
+	for _, post := range page.Posts {
+		posts = append(posts, newFeedPost(post))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"posts":      posts,
+		"nextCursor": page.NextCursor,
+		"limits":     newFeedLimits(h.timeline.Options(), page),
+	})
+}
+
+func newFeedLimits(opts timeline.Options, page *timeline.Page) FeedLimits {
+	return FeedLimits{
+		MaxAuthors:       opts.MaxAuthors,
+		PerAuthorLimit:   opts.PerAuthorLimit,
+		ScanLimit:        opts.ScanLimit,
+		AuthorsTruncated: page.AuthorsTruncated,
+		ScanTruncated:    page.ScanTruncated,
+	}
+}
+
+func clampFeedLimit(limit int) int {
+	if limit <= 0 {
+		return defaultFeedLimit
+	}
+	if limit > maxFeedLimit {
+		return maxFeedLimit
+	}
+	return limit
+}
+
+func newFeedPost(post *proto.Post) FeedPost {
+	feedPost := FeedPost{
+		ID:         post.Id,
+		Content:    post.Content,
+		Images:     post.Images,
+		City:       post.City,
+		Visibility: post.Visibility,
+		Likes:      post.Likes,
+		Comments:   post.Comments,
+		Shares:     post.Shares,
+		IsLiked:    post.IsLiked,
+		CreatedAt:  post.CreatedAt.AsTime(),
+	}
+	if feedPost.Images == nil {
+		feedPost.Images = []string{}
+	}
+	if post.Author != nil {
+		feedPost.Author = UserSummary{
+			ID:       post.Author.Id,
+			Avatar:   post.Author.Avatar,
+			Username: post.Author.Username,
+		}
+	}
+	return feedPost
+}
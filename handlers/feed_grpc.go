@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/proto"
+	"followservice/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *FollowGrpcServer) GetFollowingFeed(ctx context.Context, req *proto.GetFollowingFeedRequest) (*proto.GetFollowingFeedResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	cursor, err := decodeCursorParam(req.Cursor)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.timeline.Feed(ctx, req.UserId, cursor, clampFeedLimit(int(req.Limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	limits := newFeedLimits(s.timeline.Options(), page)
+	response := &proto.GetFollowingFeedResponse{
+		Posts:      make([]*proto.FeedPost, 0, len(page.Posts)),
+		NextCursor: page.NextCursor,
+		Limits: &proto.FeedLimits{
+			MaxAuthors:       int32(limits.MaxAuthors),
+			PerAuthorLimit:   int32(limits.PerAuthorLimit),
+			ScanLimit:        int32(limits.ScanLimit),
+			AuthorsTruncated: limits.AuthorsTruncated,
+			ScanTruncated:    limits.ScanTruncated,
+		},
+	}
+	for _, post := range page.Posts {
+		feedPost := &proto.FeedPost{
+			Id:         post.Id,
+			Content:    post.Content,
+			Images:     post.Images,
+			City:       post.City,
+			Visibility: post.Visibility,
+			Likes:      post.Likes,
+			Comments:   post.Comments,
+			Shares:     post.Shares,
+			IsLiked:    post.IsLiked,
+			CreatedAt:  post.CreatedAt,
+		}
+		if post.Author != nil {
+			feedPost.AuthorId = post.Author.Id
+			feedPost.AuthorUsername = post.Author.Username
+			feedPost.AuthorAvatar = post.Author.Avatar
+		}
+		response.Posts = append(response.Posts, feedPost)
+	}
+	return response, nil
+}
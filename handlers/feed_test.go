@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"followservice/timeline"
+	"net/http"
+	"testing"
+)
+
+// TestGetFeedLimits 响应中返回信息流的合并上限，关注的人超过上限时标记authorsTruncated
+func TestGetFeedLimits(t *testing.T) {
+	s := newTestStores()
+	s.follow(t, alice, bob)
+	s.follow(t, alice, carol)
+	feed := timeline.NewService(s.follows, s.mutes, fakePostService{}, timeline.Options{MaxAuthors: 1})
+	handler := NewFollowHandler(s.follows, s.requests, s.settings, s.blocks, s.mutes, s.lists, s.enricher(), nil, feed)
+
+	w := serve(handler.GetFeed, http.MethodGet, "/feed", "/feed", alice, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	limits := decode[struct {
+		Limits FeedLimits `json:"limits"`
+	}](t, w).Limits
+	want := FeedLimits{
+		MaxAuthors:       1,
+		PerAuthorLimit:   timeline.DefaultPerAuthorLimit,
+		ScanLimit:        timeline.DefaultScanLimit,
+		AuthorsTruncated: true,
+	}
+	if limits != want {
+		t.Errorf("limits = %+v, want %+v", limits, want)
+	}
+}
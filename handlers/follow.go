@@ -1,43 +1,42 @@
 package handlers
 
 import (
-	"context"
+	"errors"
+	"followservice/enrichment"
 	"followservice/models"
-	"followservice/proto"
+	"followservice/store"
+	"followservice/suggestions"
+	"followservice/timeline"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"google.golang.org/grpc"
 )
 
 type FollowHandler struct {
-	collection        *mongo.Collection
-	userServiceClient proto.UserServiceClient
-	postServiceClient proto.PostServiceClient
+	store     store.FollowStore
+	requests  store.FollowRequestStore
+	settings  store.SettingsStore
+	blocks    store.BlockStore
+	mutes     store.MuteStore
+	lists     store.ListStore
+	enricher  *enrichment.Enricher
+	suggester *suggestions.Engine
+	timeline  *timeline.Service
 }
 
-func NewFollowHandler(collection *mongo.Collection, userServiceHost, postServiceHost string) (*FollowHandler, error) {
-	// 创建用户服务客户端
-	userConn, err := grpc.Dial(userServiceHost, grpc.WithInsecure())
-	if err != nil {
-		return nil, err
-	}
-
-	// 创建帖子服务客户端
-	postConn, err := grpc.Dial(postServiceHost, grpc.WithInsecure())
-	if err != nil {
-		return nil, err
-	}
-
+func NewFollowHandler(followStore store.FollowStore, requestStore store.FollowRequestStore, settingsStore store.SettingsStore, blockStore store.BlockStore, muteStore store.MuteStore, listStore store.ListStore, enricher *enrichment.Enricher, suggester *suggestions.Engine, timeline *timeline.Service) *FollowHandler {
 	return &FollowHandler{
-		collection:        collection,
-		userServiceClient: proto.NewUserServiceClient(userConn),
-		postServiceClient: proto.NewPostServiceClient(postConn),
-	}, nil
+		store:     followStore,
+		requests:  requestStore,
+		settings:  settingsStore,
+		blocks:    blockStore,
+		mutes:     muteStore,
+		lists:     listStore,
+		enricher:  enricher,
+		suggester: suggester,
+		timeline:  timeline,
+	}
 }
 
 type FollowUserRequest struct {
@@ -64,27 +63,34 @@ func (h *FollowHandler) FollowUser(c *gin.Context) {
 		return
 	}
 
-	// 检查是否已经关注
-	exists, err := h.checkFollowExists(c.Request.Context(), userID.(string), req.TargetUserID)
+	// 任意一方拉黑了另一方时不允许关注
+	blockedByUser, blockedByTarget, err := blockedBetween(c.Request.Context(), h.blocks, userID.(string), req.TargetUserID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
 	}
+	if blockedByUser || blockedByTarget {
+		c.JSON(http.StatusForbidden, gin.H{"error": "无法关注该用户"})
+		return
+	}
 
-	if exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "已经关注该用户"})
+	// 目标用户开启了关注审批时，创建关注请求而不是直接关注
+	targetSettings, err := h.settings.GetSettings(c.Request.Context(), req.TargetUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+	if targetSettings.RequiresApproval {
+		h.requestFollow(c, userID.(string), req.TargetUserID)
 		return
 	}
 
 	// 创建关注关系
-	follow := models.Follow{
-		ID:          uuid.New().String(),
-		FollowerID:  userID.(string),
-		FollowingID: req.TargetUserID,
-		CreatedAt:   time.Now(),
+	_, err = h.store.Follow(c.Request.Context(), userID.(string), req.TargetUserID)
+	if errors.Is(err, store.ErrAlreadyFollowing) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "已经关注该用户"})
+		return
 	}
-
-	_, err = h.collection.InsertOne(c.Request.Context(), follow)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
@@ -96,17 +102,6 @@ func (h *FollowHandler) FollowUser(c *gin.Context) {
 	})
 }
 
-func (h *FollowHandler) checkFollowExists(ctx context.Context, followerID, followingID string) (bool, error) {
-	count, err := h.collection.CountDocuments(ctx, bson.M{
-		"follower_id":  followerID,
-		"following_id": followingID,
-	})
-	if err != nil {
-		return false, err
-	}
-	return count > 0, nil
-}
-
 func (h *FollowHandler) UnfollowUser(c *gin.Context) {
 	// 获取目标用户ID
 	targetUserID := c.Query("targetUserId")
@@ -128,33 +123,17 @@ func (h *FollowHandler) UnfollowUser(c *gin.Context) {
 		return
 	}
 
-	// 检查关注关系是否存在
-	exists, err := h.checkFollowExists(c.Request.Context(), userID.(string), targetUserID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
-		return
-	}
-
-	if !exists {
+	// 删除关注关系
+	err := endFollow(c.Request.Context(), h.store, h.lists, userID.(string), targetUserID, models.UnfollowReasonUser)
+	if errors.Is(err, store.ErrNotFollowing) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "未关注该用户"})
 		return
 	}
-
-	// 删除关注关系
-	result, err := h.collection.DeleteOne(c.Request.Context(), bson.M{
-		"follower_id":  userID.(string),
-		"following_id": targetUserID,
-	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
 	}
 
-	if result.DeletedCount == 0 {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "取消关注失败"})
-		return
-	}
-
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "success",
 		"message": "取消关注成功",
@@ -163,25 +142,28 @@ func (h *FollowHandler) UnfollowUser(c *gin.Context) {
 
 // GetMyFollowsRequest 定义获取关注列表的请求参数
 type GetMyFollowsRequest struct {
-	Limit  int `form:"limit,default=10"`
-	Offset int `form:"offset,default=0"`
+	Limit  int    `form:"limit,default=10"`
+	Offset int    `form:"offset,default=0"` // 旧版分页参数，传入cursor时忽略
+	Cursor string `form:"cursor"`
+	// Relation 为mutual时只返回互关的用户，为non_mutual时只返回没有回关的用户
+	Relation string `form:"relation"`
+	ListQuery
 }
 
 // FollowResponse 定义关注列表的响应结构
 type FollowResponse struct {
 	Follows    []FollowDetail `json:"follows"`
 	TotalCount int64          `json:"totalCount"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	// Truncated 按用户信息排序或筛选时关系数超过上限，更早的关系不在结果和TotalCount中
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // FollowDetail 定义每个关注对象的详细信息
 type FollowDetail struct {
-	TargetUser struct {
-		ID       string `json:"id"`
-		Avatar   string `json:"avatar"`
-		Username string `json:"username"`
-	} `json:"targetUser"`
-	LatestPostContent string    `json:"latestPostContent"`
-	Timestamp         time.Time `json:"timestamp"`
+	TargetUser        UserSummary `json:"targetUser"`
+	LatestPostContent string      `json:"latestPostContent"`
+	Timestamp         time.Time   `json:"timestamp"`
 }
 
 // GetMyFollows 获取当前用户的关注列表
@@ -199,6 +181,16 @@ func (h *FollowHandler) GetMyFollows(c *gin.Context) {
 	if req.Offset < 0 {
 		req.Offset = 0
 	}
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	mutual, ok := parseRelationFilter(req.Relation)
+	if !ok || (cursor != nil && req.needsProfiles()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
 
 	// 获取当前用户ID
 	userID, exists := c.Get("userId")
@@ -207,43 +199,35 @@ func (h *FollowHandler) GetMyFollows(c *gin.Context) {
 		return
 	}
 
-	// 查询关注列表
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"follower_id": userID.(string),
-			},
-		},
-		{
-			"$sort": bson.M{
-				"created_at": -1,
-			},
-		},
-		{
-			"$skip": req.Offset,
-		},
-		{
-			"$limit": req.Limit,
-		},
-	}
-
-	cursor, err := h.collection.Aggregate(c.Request.Context(), pipeline)
+	// 隐藏存在拉黑关系的用户
+	hiddenUserIDs, err := h.blocks.RelatedUserIDs(c.Request.Context(), userID.(string))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
 	}
-	defer cursor.Close(c.Request.Context())
 
-	var follows []models.Follow
-	if err := cursor.All(c.Request.Context(), &follows); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
-		return
+	// 查询关注列表
+	opts := store.ListOptions{
+		Limit:          req.Limit,
+		Offset:         req.Offset,
+		Cursor:         cursor,
+		ExcludeUserIDs: hiddenUserIDs,
+		Ascending:      req.Sort == sortOldest,
+		Mutual:         mutual,
+	}
+	var (
+		page      *store.FollowPage
+		profiles  map[string]*enrichment.Profile
+		truncated bool
+	)
+	if req.needsProfiles() {
+		page, profiles, truncated, err = listByProfile(c.Request.Context(), h.enricher, h.store.ListFollowing, func(f models.Follow) string {
+			return f.FollowingID
+		}, userID.(string), opts, req.ListQuery)
+	} else if page, err = h.store.ListFollowing(c.Request.Context(), userID.(string), opts); err == nil {
+		// 获取每个用户的详细信息
+		profiles = h.enricher.Enrich(c.Request.Context(), followingIDs(page.Follows), enrichment.Options{LatestPost: true})
 	}
-
-	// 获取总数
-	totalCount, err := h.collection.CountDocuments(c.Request.Context(), bson.M{
-		"follower_id": userID.(string),
-	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
@@ -251,41 +235,23 @@ func (h *FollowHandler) GetMyFollows(c *gin.Context) {
 
 	// 构建响应数据
 	response := FollowResponse{
-		Follows:    make([]FollowDetail, 0, len(follows)),
-		TotalCount: totalCount,
+		Follows:    make([]FollowDetail, 0, len(page.Follows)),
+		TotalCount: page.TotalCount,
+		NextCursor: page.NextCursor,
+		Truncated:  truncated,
 	}
 
-	// 获取每个关注用户的详细信息
-	for _, follow := range follows {
-		// 获取用户信息
-		userInfo, err := h.userServiceClient.GetUserInfo(c.Request.Context(), &proto.GetUserInfoRequest{
-			UserId: follow.FollowingID,
-		})
-		if err != nil {
+	for _, follow := range page.Follows {
+		profile, ok := profiles[follow.FollowingID]
+		if !ok {
 			continue // 跳过获取失败的用户
 		}
 
-		// 获取用户最新帖子
-		posts, err := h.postServiceClient.GetUserPosts(c.Request.Context(), &proto.GetUserPostsRequest{
-			UserId: follow.FollowingID,
-			Limit:  1,
-			Offset: 0,
-		})
-
-		var latestPostContent string
-		if err == nil && len(posts.Posts) > 0 {
-			latestPostContent = posts.Posts[0].Content
-		}
-
-		detail := FollowDetail{
-			LatestPostContent: latestPostContent,
+		response.Follows = append(response.Follows, FollowDetail{
+			TargetUser:        newUserSummary(profile.User),
+			LatestPostContent: profile.LatestPostContent,
 			Timestamp:         follow.CreatedAt,
-		}
-		detail.TargetUser.ID = userInfo.Id
-		detail.TargetUser.Avatar = userInfo.Avatar
-		detail.TargetUser.Username = userInfo.Username
-
-		response.Follows = append(response.Follows, detail)
+		})
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -293,25 +259,28 @@ func (h *FollowHandler) GetMyFollows(c *gin.Context) {
 
 // GetMyFansRequest 定义获取粉丝列表的请求参数
 type GetMyFansRequest struct {
-	Limit  int `form:"limit,default=10"`
-	Offset int `form:"offset,default=0"`
+	Limit  int    `form:"limit,default=10"`
+	Offset int    `form:"offset,default=0"` // 旧版分页参数，传入cursor时忽略
+	Cursor string `form:"cursor"`
+	// Relation 为mutual时只返回互关的粉丝，为non_mutual时只返回自己没有回关的粉丝
+	Relation string `form:"relation"`
+	ListQuery
 }
 
 // FansResponse 定义粉丝列表的响应结构
 type FansResponse struct {
 	Fans       []FanDetail `json:"fans"`
 	TotalCount int64       `json:"totalCount"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+	// Truncated 按用户信息排序或筛选时关系数超过上限，更早的关系不在结果和TotalCount中
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // FanDetail 定义每个粉丝的详细信息
 type FanDetail struct {
-	TargetUser struct {
-		ID       string `json:"id"`
-		Avatar   string `json:"avatar"`
-		Username string `json:"username"`
-	} `json:"targetUser"`
-	LatestPostContent string    `json:"latestPostContent"`
-	Timestamp         time.Time `json:"timestamp"`
+	TargetUser        UserSummary `json:"targetUser"`
+	LatestPostContent string      `json:"latestPostContent"`
+	Timestamp         time.Time   `json:"timestamp"`
 }
 
 // GetMyFans 获取当前用户的粉丝列表
@@ -329,6 +298,16 @@ func (h *FollowHandler) GetMyFans(c *gin.Context) {
 	if req.Offset < 0 {
 		req.Offset = 0
 	}
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	mutual, ok := parseRelationFilter(req.Relation)
+	if !ok || (cursor != nil && req.needsProfiles()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
 
 	// 获取当前用户ID
 	userID, exists := c.Get("userId")
@@ -337,43 +316,35 @@ func (h *FollowHandler) GetMyFans(c *gin.Context) {
 		return
 	}
 
-	// 查询粉丝列表
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"following_id": userID.(string),
-			},
-		},
-		{
-			"$sort": bson.M{
-				"created_at": -1,
-			},
-		},
-		{
-			"$skip": req.Offset,
-		},
-		{
-			"$limit": req.Limit,
-		},
-	}
-
-	cursor, err := h.collection.Aggregate(c.Request.Context(), pipeline)
+	// 隐藏存在拉黑关系的用户
+	hiddenUserIDs, err := h.blocks.RelatedUserIDs(c.Request.Context(), userID.(string))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
 	}
-	defer cursor.Close(c.Request.Context())
 
-	var follows []models.Follow
-	if err := cursor.All(c.Request.Context(), &follows); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
-		return
+	// 查询粉丝列表
+	opts := store.ListOptions{
+		Limit:          req.Limit,
+		Offset:         req.Offset,
+		Cursor:         cursor,
+		ExcludeUserIDs: hiddenUserIDs,
+		Ascending:      req.Sort == sortOldest,
+		Mutual:         mutual,
+	}
+	var (
+		page      *store.FollowPage
+		profiles  map[string]*enrichment.Profile
+		truncated bool
+	)
+	if req.needsProfiles() {
+		page, profiles, truncated, err = listByProfile(c.Request.Context(), h.enricher, h.store.ListFollowers, func(f models.Follow) string {
+			return f.FollowerID
+		}, userID.(string), opts, req.ListQuery)
+	} else if page, err = h.store.ListFollowers(c.Request.Context(), userID.(string), opts); err == nil {
+		// 获取每个用户的详细信息
+		profiles = h.enricher.Enrich(c.Request.Context(), followerIDs(page.Follows), enrichment.Options{LatestPost: true})
 	}
-
-	// 获取总数
-	totalCount, err := h.collection.CountDocuments(c.Request.Context(), bson.M{
-		"following_id": userID.(string),
-	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
@@ -381,41 +352,23 @@ func (h *FollowHandler) GetMyFans(c *gin.Context) {
 
 	// 构建响应数据
 	response := FansResponse{
-		Fans:       make([]FanDetail, 0, len(follows)),
-		TotalCount: totalCount,
+		Fans:       make([]FanDetail, 0, len(page.Follows)),
+		TotalCount: page.TotalCount,
+		NextCursor: page.NextCursor,
+		Truncated:  truncated,
 	}
 
-	// 获取每个粉丝的详细信息
-	for _, follow := range follows {
-		// 获取用户信息
-		userInfo, err := h.userServiceClient.GetUserInfo(c.Request.Context(), &proto.GetUserInfoRequest{
-			UserId: follow.FollowerID,
-		})
-		if err != nil {
+	for _, follow := range page.Follows {
+		profile, ok := profiles[follow.FollowerID]
+		if !ok {
 			continue // 跳过获取失败的用户
 		}
 
-		// 获取用户最新帖子
-		posts, err := h.postServiceClient.GetUserPosts(c.Request.Context(), &proto.GetUserPostsRequest{
-			UserId: follow.FollowerID,
-			Limit:  1,
-			Offset: 0,
-		})
-
-		var latestPostContent string
-		if err == nil && len(posts.Posts) > 0 {
-			latestPostContent = posts.Posts[0].Content
-		}
-
-		detail := FanDetail{
-			LatestPostContent: latestPostContent,
+		response.Fans = append(response.Fans, FanDetail{
+			TargetUser:        newUserSummary(profile.User),
+			LatestPostContent: profile.LatestPostContent,
 			Timestamp:         follow.CreatedAt,
-		}
-		detail.TargetUser.ID = userInfo.Id
-		detail.TargetUser.Avatar = userInfo.Avatar
-		detail.TargetUser.Username = userInfo.Username
-
-		response.Fans = append(response.Fans, detail)
+		})
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -423,25 +376,26 @@ func (h *FollowHandler) GetMyFans(c *gin.Context) {
 
 // GetMutualFollowsRequest 定义获取互相关注列表的请求参数
 type GetMutualFollowsRequest struct {
-	Limit  int `form:"limit,default=10"`
-	Offset int `form:"offset,default=0"`
+	Limit  int    `form:"limit,default=10"`
+	Offset int    `form:"offset,default=0"` // 旧版分页参数，传入cursor时忽略
+	Cursor string `form:"cursor"`
+	ListQuery
 }
 
 // MutualFollowResponse 定义互相关注列表的响应结构
 type MutualFollowResponse struct {
 	MutualFollows []MutualFollowDetail `json:"mutualFollows"`
 	TotalCount    int64                `json:"totalCount"`
+	NextCursor    string               `json:"nextCursor,omitempty"`
+	// Truncated 按用户信息排序或筛选时关系数超过上限，更早的关系不在结果和TotalCount中
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // MutualFollowDetail 定义每个互相关注用户的详细信息
 type MutualFollowDetail struct {
-	TargetUser struct {
-		ID       string `json:"id"`
-		Avatar   string `json:"avatar"`
-		Username string `json:"username"`
-	} `json:"targetUser"`
-	LatestPostContent string    `json:"latestPostContent"`
-	Timestamp         time.Time `json:"timestamp"`
+	TargetUser        UserSummary `json:"targetUser"`
+	LatestPostContent string      `json:"latestPostContent"`
+	Timestamp         time.Time   `json:"timestamp"`
 }
 
 // GetMutualFollows 获取当前用户的互相关注列表
@@ -459,6 +413,15 @@ func (h *FollowHandler) GetMutualFollows(c *gin.Context) {
 	if req.Offset < 0 {
 		req.Offset = 0
 	}
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	if cursor != nil && req.needsProfiles() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
 
 	// 获取当前用户ID
 	userID, exists := c.Get("userId")
@@ -467,143 +430,67 @@ func (h *FollowHandler) GetMutualFollows(c *gin.Context) {
 		return
 	}
 
-	// 使用聚合管道查询互相关注的用户
-	pipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"follower_id": userID.(string),
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"from":         "follows", // 假设集合名为 follows
-				"localField":   "following_id",
-				"foreignField": "follower_id",
-				"as":           "mutual",
-			},
-		},
-		{
-			"$match": bson.M{
-				"mutual": bson.M{
-					"$elemMatch": bson.M{
-						"following_id": userID.(string),
-					},
-				},
-			},
-		},
-		{
-			"$sort": bson.M{
-				"created_at": -1,
-			},
-		},
-		{
-			"$skip": req.Offset,
-		},
-		{
-			"$limit": req.Limit,
-		},
-	}
-
-	cursor, err := h.collection.Aggregate(c.Request.Context(), pipeline)
+	// 隐藏存在拉黑关系的用户
+	hiddenUserIDs, err := h.blocks.RelatedUserIDs(c.Request.Context(), userID.(string))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
 	}
-	defer cursor.Close(c.Request.Context())
 
-	var follows []models.Follow
-	if err := cursor.All(c.Request.Context(), &follows); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
-		return
+	// 查询互相关注列表
+	opts := store.ListOptions{
+		Limit:          req.Limit,
+		Offset:         req.Offset,
+		Cursor:         cursor,
+		ExcludeUserIDs: hiddenUserIDs,
+		Ascending:      req.Sort == sortOldest,
+	}
+	var (
+		page      *store.FollowPage
+		profiles  map[string]*enrichment.Profile
+		truncated bool
+	)
+	if req.needsProfiles() {
+		page, profiles, truncated, err = listByProfile(c.Request.Context(), h.enricher, h.store.ListMutual, func(f models.Follow) string {
+			return f.FollowingID
+		}, userID.(string), opts, req.ListQuery)
+	} else if page, err = h.store.ListMutual(c.Request.Context(), userID.(string), opts); err == nil {
+		// 获取每个用户的详细信息
+		profiles = h.enricher.Enrich(c.Request.Context(), followingIDs(page.Follows), enrichment.Options{LatestPost: true})
 	}
-
-	// 获取互相关注总数
-	countPipeline := []bson.M{
-		{
-			"$match": bson.M{
-				"follower_id": userID.(string),
-			},
-		},
-		{
-			"$lookup": bson.M{
-				"from":         "follows",
-				"localField":   "following_id",
-				"foreignField": "follower_id",
-				"as":           "mutual",
-			},
-		},
-		{
-			"$match": bson.M{
-				"mutual": bson.M{
-					"$elemMatch": bson.M{
-						"following_id": userID.(string),
-					},
-				},
-			},
-		},
-		{
-			"$count": "total",
-		},
-	}
-
-	var totalResults []struct {
-		Total int64 `bson:"total"`
-	}
-	cursor, err = h.collection.Aggregate(c.Request.Context(), countPipeline)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
 		return
 	}
-	defer cursor.Close(c.Request.Context())
-
-	if err := cursor.All(c.Request.Context(), &totalResults); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
-		return
-	}
-
-	var totalCount int64
-	if len(totalResults) > 0 {
-		totalCount = totalResults[0].Total
-	}
 
 	// 构建响应数据
 	response := MutualFollowResponse{
-		MutualFollows: make([]MutualFollowDetail, 0, len(follows)),
-		TotalCount:    totalCount,
+		MutualFollows: make([]MutualFollowDetail, 0, len(page.Follows)),
+		TotalCount:    page.TotalCount,
+		NextCursor:    page.NextCursor,
+		Truncated:     truncated,
 	}
 
-	// 获取每个互相关注用户的详细信息
-	for _, follow := range follows {
-		// 获取用户信息
-		userInfo, err := h.userServiceClient.GetUserInfo(c.Request.Context(), &proto.GetUserInfoRequest{
-			UserId: follow.FollowingID,
-		})
-		if err != nil {
+	for _, follow := range page.Follows {
+		profile, ok := profiles[follow.FollowingID]
+		if !ok {
 			continue // 跳过获取失败的用户
 		}
 
-		// 获取用户最新帖子
-		posts, err := h.postServiceClient.GetUserPosts(c.Request.Context(), &proto.GetUserPostsRequest{
-			UserId: follow.FollowingID,
-			Limit:  1,
-			Offset: 0,
-		})
-
-		var latestPostContent string
-		if err == nil && len(posts.Posts) > 0 {
-			latestPostContent = posts.Posts[0].Content
-		}
-
-		detail := MutualFollowDetail{
-			LatestPostContent: latestPostContent,
+		response.MutualFollows = append(response.MutualFollows, MutualFollowDetail{
+			TargetUser:        newUserSummary(profile.User),
+			LatestPostContent: profile.LatestPostContent,
 			Timestamp:         follow.CreatedAt,
-		}
-		detail.TargetUser.ID = userInfo.Id
-		detail.TargetUser.Avatar = userInfo.Avatar
-		detail.TargetUser.Username = userInfo.Username
-
-		response.MutualFollows = append(response.MutualFollows, detail)
+		})
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// decodeCursorParam 解析请求中的分页游标，未传入时返回nil
+func decodeCursorParam(token string) (*store.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	return store.DecodeCursor(token)
+}
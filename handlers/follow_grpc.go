@@ -2,76 +2,197 @@ package handlers
 
 import (
 	"context"
+	"followservice/enrichment"
 	"followservice/proto"
+	"followservice/store"
+	"followservice/suggestions"
+	"followservice/timeline"
 
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// maxRelationshipTargets GetRelationships单次请求允许的最大目标用户数
+const maxRelationshipTargets = 100
+
 type FollowGrpcServer struct {
 	proto.UnimplementedFollowServiceServer
-	collection *mongo.Collection
+	store    store.FollowStore
+	requests store.FollowRequestStore
+	settings store.SettingsStore
+	blocks   store.BlockStore
+	mutes    store.MuteStore
+	lists    store.ListStore
+	// directory 用户资料副本，由SyncUserProfiles写入
+	directory store.UserDirectory
+	// deletions 注销账号清理任务，由jobs.AccountDeletionJob执行
+	deletions store.AccountDeletionStore
+	// profileCache 为nil表示未启用用户信息缓存
+	profileCache *enrichment.ProfileCache
+	enricher     *enrichment.Enricher
+	suggester    *suggestions.Engine
+	timeline     *timeline.Service
 }
 
-func NewFollowGrpcServer(collection *mongo.Collection) *FollowGrpcServer {
+func NewFollowGrpcServer(followStore store.FollowStore, requestStore store.FollowRequestStore, settingsStore store.SettingsStore, blockStore store.BlockStore, muteStore store.MuteStore, listStore store.ListStore, directory store.UserDirectory, deletionStore store.AccountDeletionStore, profileCache *enrichment.ProfileCache, enricher *enrichment.Enricher, suggester *suggestions.Engine, timeline *timeline.Service) *FollowGrpcServer {
 	return &FollowGrpcServer{
-		collection: collection,
+		store:        followStore,
+		requests:     requestStore,
+		settings:     settingsStore,
+		blocks:       blockStore,
+		mutes:        muteStore,
+		lists:        listStore,
+		directory:    directory,
+		deletions:    deletionStore,
+		profileCache: profileCache,
+		enricher:     enricher,
+		suggester:    suggester,
+		timeline:     timeline,
 	}
 }
 
 func (s *FollowGrpcServer) GetFollowCount(ctx context.Context, req *proto.GetFollowCountRequest) (*proto.GetFollowCountResponse, error) {
-	// 获取关注数量
-	followingCount, err := s.collection.CountDocuments(ctx, bson.M{
-		"follower_id": req.UserId,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	// 获取粉丝数量
-	followersCount, err := s.collection.CountDocuments(ctx, bson.M{
-		"following_id": req.UserId,
-	})
+	// 获取关注数量和粉丝数量
+	counts, err := s.store.Counts(ctx, req.UserId)
 	if err != nil {
 		return nil, err
 	}
 
 	return &proto.GetFollowCountResponse{
-		FollowersCount: followersCount,
-		FollowingCount: followingCount,
+		FollowersCount: counts.FollowersCount,
+		FollowingCount: counts.FollowingCount,
 	}, nil
 }
 
 func (s *FollowGrpcServer) GetFollowingUserIds(ctx context.Context, req *proto.GetFollowingUserIdsRequest) (*proto.GetFollowingUserIdsResponse, error) {
-	// 查询指定用户关注的所有用户ID
-	cursor, err := s.collection.Find(ctx, bson.M{
-		"follower_id": req.UserId,
-	}, &options.FindOptions{
-		Projection: bson.M{
-			"following_id": 1,
-			"_id":          0,
-		},
-	})
+	opts, err := idPageOptions(req.PageSize, req.PageToken)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
-
-	var follows []struct {
-		FollowingID string `bson:"following_id"`
+	if req.ExcludeMuted {
+		mutedIds, err := s.mutes.MutedUserIDs(ctx, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+		opts.ExcludeUserIDs = mutedIds
 	}
-	if err := cursor.All(ctx, &follows); err != nil {
+
+	// 查询指定用户关注的用户ID，未指定page_size时返回全部
+	page, err := s.store.ListFollowing(ctx, req.UserId, opts)
+	if err != nil {
 		return nil, err
 	}
 
 	// 构建响应
-	followingIds := make([]string, 0, len(follows))
-	for _, follow := range follows {
+	followingIds := make([]string, 0, len(page.Follows))
+	for _, follow := range page.Follows {
 		followingIds = append(followingIds, follow.FollowingID)
 	}
 
 	return &proto.GetFollowingUserIdsResponse{
 		FollowingUserIds: followingIds,
+		NextPageToken:    page.NextCursor,
+	}, nil
+}
+
+func (s *FollowGrpcServer) GetFollowerUserIds(ctx context.Context, req *proto.GetFollowerUserIdsRequest) (*proto.GetFollowerUserIdsResponse, error) {
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = maxIdPageSize
+	}
+	opts, err := idPageOptions(pageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.store.ListFollowers(ctx, req.UserId, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	followerIds := make([]string, 0, len(page.Follows))
+	for _, follow := range page.Follows {
+		followerIds = append(followerIds, follow.FollowerID)
+	}
+
+	return &proto.GetFollowerUserIdsResponse{
+		FollowerUserIds: followerIds,
+		NextPageToken:   page.NextCursor,
+	}, nil
+}
+
+// maxIdPageSize 分页查询用户ID时单页的最大数量
+const maxIdPageSize = 1000
+
+// idPageOptions 将ID分页参数转换为ListOptions，pageSize为0时不分页
+func idPageOptions(pageSize int32, pageToken string) (store.ListOptions, error) {
+	opts := store.ListOptions{
+		Limit: int(pageSize),
+	}
+	if opts.Limit < 0 {
+		opts.Limit = 0
+	}
+	if opts.Limit > maxIdPageSize {
+		opts.Limit = maxIdPageSize
+	}
+
+	cursor, err := decodeCursorParam(pageToken)
+	if err != nil {
+		return opts, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	opts.Cursor = cursor
+	return opts, nil
+}
+
+func (s *FollowGrpcServer) IsFollowing(ctx context.Context, req *proto.IsFollowingRequest) (*proto.IsFollowingResponse, error) {
+	following, err := s.store.Exists(ctx, req.FollowerId, req.FollowingId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.IsFollowingResponse{
+		IsFollowing: following,
+	}, nil
+}
+
+func (s *FollowGrpcServer) GetRelationships(ctx context.Context, req *proto.GetRelationshipsRequest) (*proto.GetRelationshipsResponse, error) {
+	if req.ViewerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "viewer_id is required")
+	}
+	if len(req.TargetIds) > maxRelationshipTargets {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d target_ids are allowed", maxRelationshipTargets)
+	}
+
+	// 批量查询关注状态
+	states, err := s.store.FollowStates(ctx, req.ViewerId, req.TargetIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// 查询存在拉黑关系的用户
+	blockedIds, err := s.blocks.RelatedUserIDs(ctx, req.ViewerId)
+	if err != nil {
+		return nil, err
+	}
+	blocked := make(map[string]bool, len(blockedIds))
+	for _, id := range blockedIds {
+		blocked[id] = true
+	}
+
+	// 构建响应，顺序与请求一致
+	relationships := make([]*proto.Relationship, 0, len(req.TargetIds))
+	for _, targetId := range req.TargetIds {
+		state := states[targetId]
+		relationships = append(relationships, &proto.Relationship{
+			TargetId:   targetId,
+			Following:  state.Following,
+			FollowedBy: state.FollowedBy,
+			Mutual:     state.Following && state.FollowedBy,
+			Blocked:    blocked[targetId],
+		})
+	}
+
+	return &proto.GetRelationshipsResponse{
+		Relationships: relationships,
 	}, nil
 }
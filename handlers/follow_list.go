@@ -0,0 +1,407 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/store"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// endFollow 结束followerID对followingID的关注关系，并将followingID移出followerID的所有分组
+func endFollow(ctx context.Context, follows store.FollowStore, lists store.ListStore, followerID, followingID string, reason models.UnfollowReason) error {
+	if err := follows.Unfollow(ctx, followerID, followingID, reason); err != nil {
+		return err
+	}
+	// 关注关系已经结束，移出分组失败不影响本次操作的结果
+	if err := lists.RemoveFromOwnerLists(ctx, followerID, followingID); err != nil {
+		log.Printf("将用户 %s 移出 %s 的分组失败: %v", followingID, followerID, err)
+	}
+	return nil
+}
+
+// FollowListRequest 定义创建和重命名分组的请求参数
+type FollowListRequest struct {
+	Name string `json:"name" binding:"required,max=30"`
+}
+
+// ListMemberRequest 定义向分组添加成员的请求参数
+type ListMemberRequest struct {
+	TargetUserID string `json:"targetUserId" binding:"required,len=36"`
+}
+
+// GetListMembersRequest 定义获取分组成员的请求参数
+type GetListMembersRequest struct {
+	Limit  int    `form:"limit,default=20"`
+	Cursor string `form:"cursor"`
+}
+
+// FollowListDetail 定义分组的详细信息
+type FollowListDetail struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	MemberCount int64     `json:"memberCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// ListMemberDetail 定义每个分组成员的详细信息
+type ListMemberDetail struct {
+	TargetUser UserSummary `json:"targetUser"`
+	AddedAt    time.Time   `json:"addedAt"`
+}
+
+// CreateList 创建关注分组
+func (h *FollowHandler) CreateList(c *gin.Context) {
+	var req FollowListRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	list, err := h.lists.CreateList(c.Request.Context(), userID.(string), req.Name)
+	if errors.Is(err, store.ErrListNameTaken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "已存在同名分组"})
+		return
+	}
+	if errors.Is(err, store.ErrTooManyLists) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "分组数量已达上限"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "创建成功",
+		"list":    newFollowListDetail(*list),
+	})
+}
+
+// GetLists 获取当前用户的所有分组
+func (h *FollowHandler) GetLists(c *gin.Context) {
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	lists, err := h.lists.ListsByOwner(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+	if err := h.countFollowedMembers(c.Request.Context(), userID.(string), lists); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	details := make([]FollowListDetail, 0, len(lists))
+	for _, list := range lists {
+		details = append(details, newFollowListDetail(list))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"lists": details,
+	})
+}
+
+// RenameList 修改分组名称
+func (h *FollowHandler) RenameList(c *gin.Context) {
+	var req FollowListRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	err := h.lists.RenameList(c.Request.Context(), list.ID, req.Name)
+	if errors.Is(err, store.ErrListNameTaken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "已存在同名分组"})
+		return
+	}
+	if errors.Is(err, store.ErrListNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "分组不存在"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "修改成功",
+	})
+}
+
+// DeleteList 删除分组及其所有成员
+func (h *FollowHandler) DeleteList(c *gin.Context) {
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	err := h.lists.DeleteList(c.Request.Context(), list.ID)
+	if errors.Is(err, store.ErrListNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "分组不存在"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "删除成功",
+	})
+}
+
+// GetListMembers 按加入时间倒序获取分组成员，与gRPC接口一样只返回创建者仍在关注的成员
+func (h *FollowHandler) GetListMembers(c *gin.Context) {
+	var req GetListMembersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	if req.Limit < 1 {
+		req.Limit = 20
+	}
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	page, err := h.followedListMembers(c.Request.Context(), list, req.Limit, cursor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	memberIDs := make([]string, 0, len(page.Members))
+	for _, member := range page.Members {
+		memberIDs = append(memberIDs, member.MemberID)
+	}
+	profiles := h.enricher.Enrich(c.Request.Context(), memberIDs, enrichment.Options{})
+
+	members := make([]ListMemberDetail, 0, len(page.Members))
+	for _, member := range page.Members {
+		profile, ok := profiles[member.MemberID]
+		if !ok {
+			continue // 跳过获取失败的用户
+		}
+		members = append(members, ListMemberDetail{
+			TargetUser: newUserSummary(profile.User),
+			AddedAt:    member.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"members":    members,
+		"nextCursor": page.NextCursor,
+	})
+}
+
+// AddListMember 将当前用户关注的用户加入分组
+func (h *FollowHandler) AddListMember(c *gin.Context) {
+	var req ListMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	// 只能添加已关注的用户
+	following, err := h.store.Exists(c.Request.Context(), list.OwnerID, req.TargetUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+	if !following {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "未关注该用户"})
+		return
+	}
+
+	err = h.lists.AddMember(c.Request.Context(), list.OwnerID, list.ID, req.TargetUserID)
+	if errors.Is(err, store.ErrAlreadyInList) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "该用户已在分组中"})
+		return
+	}
+	if errors.Is(err, store.ErrListFull) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "分组成员数量已达上限"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "添加成功",
+	})
+}
+
+// RemoveListMember 将用户移出分组
+func (h *FollowHandler) RemoveListMember(c *gin.Context) {
+	targetUserID := c.Query("targetUserId")
+	if len(targetUserID) != 36 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	err := h.lists.RemoveMember(c.Request.Context(), list.ID, targetUserID)
+	if errors.Is(err, store.ErrNotInList) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "该用户不在分组中"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "移除成功",
+	})
+}
+
+// followedListMembers 按加入时间倒序返回一页创建者仍在关注的分组成员。
+// 取消关注或拉黑后未能及时移出分组的成员记录被跳过，跳过后继续读取，使每页尽量填满limit
+func (h *FollowHandler) followedListMembers(ctx context.Context, list *models.FollowList, limit int, cursor *store.Cursor) (*store.ListMemberPage, error) {
+	result := &store.ListMemberPage{Members: make([]models.FollowListMember, 0, limit)}
+	for {
+		page, err := h.lists.ListMembers(ctx, list.ID, store.ListOptions{
+			Limit:  limit - len(result.Members),
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		memberIDs := make([]string, 0, len(page.Members))
+		for _, member := range page.Members {
+			memberIDs = append(memberIDs, member.MemberID)
+		}
+		states, err := h.store.FollowStates(ctx, list.OwnerID, memberIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range page.Members {
+			if states[member.MemberID].Following {
+				result.Members = append(result.Members, member)
+			}
+		}
+
+		result.NextCursor = page.NextCursor
+		if len(result.Members) >= limit || page.NextCursor == "" {
+			return result, nil
+		}
+		if cursor, err = store.DecodeCursor(page.NextCursor); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// countFollowedMembers 将lists的成员数改为ownerID仍在关注的成员数，与followedListMembers返回的成员一致
+func (h *FollowHandler) countFollowedMembers(ctx context.Context, ownerID string, lists []models.FollowList) error {
+	memberIDs := make([][]string, len(lists))
+	all := make([]string, 0)
+	for i, list := range lists {
+		if list.MemberCount == 0 {
+			continue
+		}
+		ids, err := h.lists.MemberIDs(ctx, list.ID)
+		if err != nil {
+			return err
+		}
+		memberIDs[i] = ids
+		all = append(all, ids...)
+	}
+	if len(all) == 0 {
+		return nil
+	}
+
+	// 同一用户可能在多个分组中，所有分组的成员一次查询
+	states, err := h.store.FollowStates(ctx, ownerID, all)
+	if err != nil {
+		return err
+	}
+	for i := range lists {
+		var count int64
+		for _, memberID := range memberIDs[i] {
+			if states[memberID].Following {
+				count++
+			}
+		}
+		lists[i].MemberCount = count
+	}
+	return nil
+}
+
+// ownedList 返回路径参数id对应的、当前用户创建的分组，失败时已写入响应。
+// 分组属于其他用户时同样返回分组不存在
+func (h *FollowHandler) ownedList(c *gin.Context) (*models.FollowList, bool) {
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return nil, false
+	}
+
+	list, err := h.lists.GetList(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, store.ErrListNotFound) || (err == nil && list.OwnerID != userID.(string)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "分组不存在"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return nil, false
+	}
+	return list, true
+}
+
+func newFollowListDetail(list models.FollowList) FollowListDetail {
+	return FollowListDetail{
+		ID:          list.ID,
+		Name:        list.Name,
+		MemberCount: list.MemberCount,
+		CreatedAt:   list.CreatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/proto"
+	"followservice/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsInList 判断用户是否在分组中，供帖子服务判断"密友可见"等按分组设置可见范围的帖子。
+// 移出分组在结束关注之后执行且失败时只记录日志，与添加成员并发的取消关注或拉黑也可能留下成员记录，
+// 因此只有创建者仍在关注的成员才视为在分组中
+func (s *FollowGrpcServer) IsInList(ctx context.Context, req *proto.IsInListRequest) (*proto.IsInListResponse, error) {
+	if req.ListId == "" || req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "list_id and user_id are required")
+	}
+
+	list, err := s.lists.GetList(ctx, req.ListId)
+	if errors.Is(err, store.ErrListNotFound) {
+		return nil, status.Error(codes.NotFound, "list not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	isMember, err := s.lists.IsMember(ctx, list.ID, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		if isMember, err = s.store.Exists(ctx, list.OwnerID, req.UserId); err != nil {
+			return nil, err
+		}
+	}
+
+	return &proto.IsInListResponse{
+		IsMember: isMember,
+		OwnerId:  list.OwnerID,
+	}, nil
+}
+
+// GetListMemberIds 返回分组创建者和仍被创建者关注的所有成员ID，原因同IsInList
+func (s *FollowGrpcServer) GetListMemberIds(ctx context.Context, req *proto.GetListMemberIdsRequest) (*proto.GetListMemberIdsResponse, error) {
+	if req.ListId == "" {
+		return nil, status.Error(codes.InvalidArgument, "list_id is required")
+	}
+
+	list, err := s.lists.GetList(ctx, req.ListId)
+	if errors.Is(err, store.ErrListNotFound) {
+		return nil, status.Error(codes.NotFound, "list not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := s.lists.MemberIDs(ctx, list.ID)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs, err = followedMembers(ctx, s.store, list.OwnerID, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetListMemberIdsResponse{
+		OwnerId:   list.OwnerID,
+		MemberIds: memberIDs,
+	}, nil
+}
+
+// followedMembers 返回memberIDs中ownerID仍在关注的用户，保持原有顺序
+func followedMembers(ctx context.Context, follows store.FollowStore, ownerID string, memberIDs []string) ([]string, error) {
+	states, err := follows.FollowStates(ctx, ownerID, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	followed := make([]string, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if states[memberID].Following {
+			followed = append(followed, memberID)
+		}
+	}
+	return followed, nil
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// membersResponse 与GetListMembers返回的JSON对应
+type membersResponse struct {
+	Members    []ListMemberDetail `json:"members"`
+	NextCursor string             `json:"nextCursor"`
+}
+
+func TestCreateList(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantName   string
+	}{
+		{name: "创建成功", body: `{"name":" 同事 "}`, wantStatus: http.StatusOK, wantName: "同事"},
+		{name: "名称为空", body: `{"name":"  "}`, wantStatus: http.StatusBadRequest},
+		{name: "同名分组", body: `{"name":"朋友"}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			if _, err := s.lists.CreateList(context.Background(), alice, "朋友"); err != nil {
+				t.Fatalf("CreateList() error = %v", err)
+			}
+
+			w := serve(s.handler().CreateList, http.MethodPost, "/lists", "/lists", alice, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			response := decode[struct {
+				List FollowListDetail `json:"list"`
+			}](t, w)
+			if response.List.Name != tt.wantName || response.List.ID == "" {
+				t.Errorf("list = %+v, want name %q", response.List, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestAddListMember(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		target     string
+		wantStatus int
+	}{
+		{name: "添加成功", userID: alice, target: carol, wantStatus: http.StatusOK},
+		{name: "未关注该用户", userID: alice, target: dave, wantStatus: http.StatusBadRequest},
+		{name: "已在分组中", userID: alice, target: bob, wantStatus: http.StatusBadRequest},
+		{name: "请求参数错误", userID: alice, target: "carol", wantStatus: http.StatusBadRequest},
+		{name: "其他用户的分组", userID: bob, target: carol, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			ctx := context.Background()
+			s.follow(t, alice, bob)
+			s.follow(t, alice, carol)
+			s.follow(t, bob, carol)
+			list, err := s.lists.CreateList(ctx, alice, "朋友")
+			if err != nil {
+				t.Fatalf("CreateList() error = %v", err)
+			}
+			if err := s.lists.AddMember(ctx, alice, list.ID, bob); err != nil {
+				t.Fatalf("AddMember() error = %v", err)
+			}
+
+			w := serve(s.handler().AddListMember, http.MethodPost, "/lists/:id/members", "/lists/"+list.ID+"/members", tt.userID, targetBody(tt.target))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			memberIDs, err := s.lists.MemberIDs(ctx, list.ID)
+			if err != nil {
+				t.Fatalf("MemberIDs() error = %v", err)
+			}
+			wantMembers := 1
+			if tt.wantStatus == http.StatusOK {
+				wantMembers = 2
+			}
+			if len(memberIDs) != wantMembers {
+				t.Errorf("members = %v, want %d members", memberIDs, wantMembers)
+			}
+		})
+	}
+}
+
+func TestRemoveListMember(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{name: "移除成功", query: "?targetUserId=" + bob, wantStatus: http.StatusOK},
+		{name: "不在分组中", query: "?targetUserId=" + carol, wantStatus: http.StatusBadRequest},
+		{name: "参数格式错误", query: "?targetUserId=bob", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			ctx := context.Background()
+			s.follow(t, alice, bob)
+			list, err := s.lists.CreateList(ctx, alice, "朋友")
+			if err != nil {
+				t.Fatalf("CreateList() error = %v", err)
+			}
+			if err := s.lists.AddMember(ctx, alice, list.ID, bob); err != nil {
+				t.Fatalf("AddMember() error = %v", err)
+			}
+
+			w := serve(s.handler().RemoveListMember, http.MethodDelete, "/lists/:id/members", "/lists/"+list.ID+"/members"+tt.query, alice, "")
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			member, err := s.lists.IsMember(ctx, list.ID, bob)
+			if err != nil {
+				t.Fatalf("IsMember() error = %v", err)
+			}
+			if member == (tt.wantStatus == http.StatusOK) {
+				t.Errorf("bob member = %v after status %d", member, w.Code)
+			}
+		})
+	}
+}
+
+// listMemberIDs 以limit为页大小读取alice的分组listID的所有成员，每页都必须填满，最后一页除外
+func listMemberIDs(t *testing.T, s *testStores, listID string, limit int) []string {
+	t.Helper()
+	handler := s.handler()
+	got := make([]string, 0)
+	target := "/lists/" + listID + "/members?limit=" + strconv.Itoa(limit)
+	for pages := 0; pages < 5; pages++ {
+		w := serve(handler.GetListMembers, http.MethodGet, "/lists/:id/members", target, alice, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+		}
+		response := decode[membersResponse](t, w)
+		for _, member := range response.Members {
+			got = append(got, member.TargetUser.ID)
+		}
+		if response.NextCursor == "" {
+			return got
+		}
+		if len(response.Members) < limit {
+			t.Fatalf("page %v is short", response.Members)
+		}
+		target += "&cursor=" + url.QueryEscape(response.NextCursor)
+	}
+	t.Fatalf("members did not end after 5 pages: %v", got)
+	return nil
+}
+
+func TestGetListMembers(t *testing.T) {
+	s := newTestStores()
+	ctx := context.Background()
+	list, err := s.lists.CreateList(ctx, alice, "朋友")
+	if err != nil {
+		t.Fatalf("CreateList() error = %v", err)
+	}
+	for _, userID := range []string{bob, carol, dave} {
+		s.follow(t, alice, userID)
+		w := serve(s.handler().AddListMember, http.MethodPost, "/lists/:id/members", "/lists/"+list.ID+"/members", alice, targetBody(userID))
+		if w.Code != http.StatusOK {
+			t.Fatalf("add member status = %d, body %s", w.Code, w.Body.String())
+		}
+	}
+
+	if got, want := listMemberIDs(t, s, list.ID, 2), []string{dave, carol, bob}; !reflect.DeepEqual(got, want) {
+		t.Errorf("members = %v, want %v", got, want)
+	}
+
+	// 其他用户无法查看该分组
+	w := serve(s.handler().GetListMembers, http.MethodGet, "/lists/:id/members", "/lists/"+list.ID+"/members", bob, "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("other user status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// TestListMembersSkipUnfollowed 取消关注后未能移出分组的成员不出现在HTTP成员列表和成员数中，与gRPC接口一致
+func TestListMembersSkipUnfollowed(t *testing.T) {
+	s := newTestStores()
+	ctx := context.Background()
+	list, err := s.lists.CreateList(ctx, alice, "朋友")
+	if err != nil {
+		t.Fatalf("CreateList() error = %v", err)
+	}
+	for _, userID := range []string{bob, carol, dave} {
+		s.follow(t, alice, userID)
+		if err := s.lists.AddMember(ctx, alice, list.ID, userID); err != nil {
+			t.Fatalf("AddMember() error = %v", err)
+		}
+	}
+	// 直接结束关注关系，模拟移出分组失败
+	s.unfollow(t, alice, carol)
+
+	want := []string{dave, bob}
+	if got := listMemberIDs(t, s, list.ID, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("members = %v, want %v", got, want)
+	}
+
+	w := serve(s.handler().GetLists, http.MethodGet, "/lists", "/lists", alice, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	lists := decode[struct {
+		Lists []FollowListDetail `json:"lists"`
+	}](t, w).Lists
+	if len(lists) != 1 || lists[0].MemberCount != int64(len(want)) {
+		t.Errorf("lists = %+v, want memberCount %d", lists, len(want))
+	}
+
+	response, err := s.grpcServer().GetListMemberIds(ctx, &proto.GetListMemberIdsRequest{ListId: list.ID})
+	if err != nil {
+		t.Fatalf("GetListMemberIds() error = %v", err)
+	}
+	got := append([]string(nil), response.MemberIds...)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, []string{bob, dave}) {
+		t.Errorf("gRPC members = %v, want [bob dave]", got)
+	}
+}
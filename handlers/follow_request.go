@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/store"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestFollow 为需要审批的目标用户创建关注请求
+func (h *FollowHandler) requestFollow(c *gin.Context, userID, targetUserID string) {
+	// 检查是否已经关注
+	exists, err := h.store.Exists(c.Request.Context(), userID, targetUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+	if exists {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "已经关注该用户"})
+		return
+	}
+
+	request, err := h.requests.CreateRequest(c.Request.Context(), userID, targetUserID)
+	if errors.Is(err, store.ErrRequestPending) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "已发送过关注请求，请等待对方处理"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "success",
+		"message":   "已发送关注请求",
+		"pending":   true,
+		"requestId": request.ID,
+	})
+}
+
+// GetFollowRequestsRequest 定义获取关注请求列表的请求参数
+type GetFollowRequestsRequest struct {
+	Limit  int `form:"limit,default=10"`
+	Offset int `form:"offset,default=0"`
+}
+
+// FollowRequestsResponse 定义关注请求列表的响应结构
+type FollowRequestsResponse struct {
+	Requests   []FollowRequestDetail `json:"requests"`
+	TotalCount int64                 `json:"totalCount"`
+}
+
+// FollowRequestDetail 定义每个关注请求的详细信息，TargetUser为请求的另一方
+type FollowRequestDetail struct {
+	ID         string      `json:"id"`
+	TargetUser UserSummary `json:"targetUser"`
+	Timestamp  time.Time   `json:"timestamp"`
+}
+
+// GetIncomingFollowRequests 获取当前用户收到的待处理关注请求
+func (h *FollowHandler) GetIncomingFollowRequests(c *gin.Context) {
+	h.listFollowRequests(c, h.requests.ListIncoming, func(r models.FollowRequest) string {
+		return r.RequesterID
+	})
+}
+
+// GetOutgoingFollowRequests 获取当前用户发出的待处理关注请求
+func (h *FollowHandler) GetOutgoingFollowRequests(c *gin.Context) {
+	h.listFollowRequests(c, h.requests.ListOutgoing, func(r models.FollowRequest) string {
+		return r.TargetID
+	})
+}
+
+func (h *FollowHandler) listFollowRequests(
+	c *gin.Context,
+	list func(ctx context.Context, userID string, opts store.ListOptions) (*store.FollowRequestPage, error),
+	otherParty func(models.FollowRequest) string,
+) {
+	var req GetFollowRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 验证参数
+	if req.Limit < 1 {
+		req.Limit = 10
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	page, err := list(c.Request.Context(), userID.(string), store.ListOptions{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	// 构建响应数据
+	response := FollowRequestsResponse{
+		Requests:   make([]FollowRequestDetail, 0, len(page.Requests)),
+		TotalCount: page.TotalCount,
+	}
+
+	// 获取请求另一方的用户信息
+	userIDs := make([]string, 0, len(page.Requests))
+	for _, request := range page.Requests {
+		userIDs = append(userIDs, otherParty(request))
+	}
+	profiles := h.enricher.Enrich(c.Request.Context(), userIDs, enrichment.Options{})
+
+	for _, request := range page.Requests {
+		profile, ok := profiles[otherParty(request)]
+		if !ok {
+			continue // 跳过获取失败的用户
+		}
+
+		response.Requests = append(response.Requests, FollowRequestDetail{
+			ID:         request.ID,
+			TargetUser: newUserSummary(profile.User),
+			Timestamp:  request.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApproveFollowRequest 同意收到的关注请求，并建立关注关系。
+// 先建立关注关系再将请求标记为已同意，建立失败时请求保持待处理状态，可以重试。
+func (h *FollowHandler) ApproveFollowRequest(c *gin.Context) {
+	_, ok := h.resolveFollowRequest(c, models.FollowRequestApproved, func(r *models.FollowRequest, userID string) bool {
+		return r.TargetID == userID
+	}, func(ctx context.Context, r *models.FollowRequest) error {
+		_, err := h.store.Follow(ctx, r.RequesterID, r.TargetID)
+		if errors.Is(err, store.ErrAlreadyFollowing) {
+			return nil
+		}
+		return err
+	})
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "已同意关注请求",
+	})
+}
+
+// RejectFollowRequest 拒绝收到的关注请求
+func (h *FollowHandler) RejectFollowRequest(c *gin.Context) {
+	_, ok := h.resolveFollowRequest(c, models.FollowRequestRejected, func(r *models.FollowRequest, userID string) bool {
+		return r.TargetID == userID
+	}, nil)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "已拒绝关注请求",
+	})
+}
+
+// CancelFollowRequest 撤回自己发出的关注请求
+func (h *FollowHandler) CancelFollowRequest(c *gin.Context) {
+	_, ok := h.resolveFollowRequest(c, models.FollowRequestCancelled, func(r *models.FollowRequest, userID string) bool {
+		return r.RequesterID == userID
+	}, nil)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "已撤回关注请求",
+	})
+}
+
+// resolveFollowRequest 校验当前用户有权处理路径中的关注请求，并将其更新为指定状态。
+// beforeResolve不为nil时在更新状态前调用，返回错误时请求保持待处理状态。
+// 返回false时已写入错误响应。
+func (h *FollowHandler) resolveFollowRequest(
+	c *gin.Context,
+	status models.FollowRequestStatus,
+	canResolve func(r *models.FollowRequest, userID string) bool,
+	beforeResolve func(ctx context.Context, r *models.FollowRequest) error,
+) (*models.FollowRequest, bool) {
+	requestID := c.Param("id")
+	if len(requestID) != 36 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return nil, false
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return nil, false
+	}
+
+	request, err := h.requests.GetRequest(c.Request.Context(), requestID)
+	if err != nil && !errors.Is(err, store.ErrRequestNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return nil, false
+	}
+	// 无权处理的请求与不存在的请求返回相同结果，避免泄露请求信息
+	if err != nil || request.Status != models.FollowRequestPending || !canResolve(request, userID.(string)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "关注请求不存在或已处理"})
+		return nil, false
+	}
+
+	if beforeResolve != nil {
+		if err := beforeResolve(c.Request.Context(), request); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+			return nil, false
+		}
+	}
+
+	request, err = h.requests.ResolveRequest(c.Request.Context(), requestID, status)
+	if errors.Is(err, store.ErrRequestNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "关注请求不存在或已处理"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return nil, false
+	}
+
+	return request, true
+}
+
+// FollowSettingsResponse 定义关注设置的响应结构
+type FollowSettingsResponse struct {
+	RequiresApproval  bool                  `json:"requiresApproval"`
+	FollowsVisibility models.ListVisibility `json:"followsVisibility"`
+	FansVisibility    models.ListVisibility `json:"fansVisibility"`
+}
+
+// UpdateFollowSettingsRequest 定义更新关注设置的请求参数，未传入的字段保持不变
+type UpdateFollowSettingsRequest struct {
+	RequiresApproval  *bool                  `json:"requiresApproval"`
+	FollowsVisibility *models.ListVisibility `json:"followsVisibility" binding:"omitempty,oneof=public mutuals hidden"`
+	FansVisibility    *models.ListVisibility `json:"fansVisibility" binding:"omitempty,oneof=public mutuals hidden"`
+}
+
+func newFollowSettingsResponse(settings *models.UserSettings) FollowSettingsResponse {
+	return FollowSettingsResponse{
+		RequiresApproval:  settings.RequiresApproval,
+		FollowsVisibility: settings.FollowsVisibilityOrDefault(),
+		FansVisibility:    settings.FansVisibilityOrDefault(),
+	}
+}
+
+// GetFollowSettings 获取当前用户的关注设置
+func (h *FollowHandler) GetFollowSettings(c *gin.Context) {
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	settings, err := h.settings.GetSettings(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newFollowSettingsResponse(settings))
+}
+
+// UpdateFollowSettings 更新当前用户的关注设置
+func (h *FollowHandler) UpdateFollowSettings(c *gin.Context) {
+	var req UpdateFollowSettingsRequest
+	err := c.ShouldBindJSON(&req)
+	if err != nil || (req.RequiresApproval == nil && req.FollowsVisibility == nil && req.FansVisibility == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	settings, err := h.settings.GetSettings(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	if req.RequiresApproval != nil {
+		settings.RequiresApproval = *req.RequiresApproval
+	}
+	if req.FollowsVisibility != nil {
+		settings.FollowsVisibility = *req.FollowsVisibility
+	}
+	if req.FansVisibility != nil {
+		settings.FansVisibility = *req.FansVisibility
+	}
+	if err := h.settings.SaveSettings(c.Request.Context(), settings); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newFollowSettingsResponse(settings))
+}
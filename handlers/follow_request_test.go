@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"followservice/models"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func requireApproval(t *testing.T, s *testStores, userID string) {
+	t.Helper()
+	if err := s.settings.SaveSettings(context.Background(), &models.UserSettings{UserID: userID, RequiresApproval: true}); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+}
+
+func TestFollowUserCreatesRequestWhenApprovalRequired(t *testing.T) {
+	s := newTestStores()
+	requireApproval(t, s, bob)
+	h := s.handler()
+
+	w := serve(h.FollowUser, http.MethodPost, "/user", "/user", alice, targetBody(bob))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	response := decode[struct {
+		Pending   bool   `json:"pending"`
+		RequestID string `json:"requestId"`
+	}](t, w)
+	if !response.Pending || response.RequestID == "" {
+		t.Errorf("response = %+v, want a pending request", response)
+	}
+	if s.isFollowing(t, alice, bob) {
+		t.Error("follow was created before approval")
+	}
+
+	// 请求待处理时再次关注不会创建第二个请求
+	if w := serve(h.FollowUser, http.MethodPost, "/user", "/user", alice, targetBody(bob)); w.Code != http.StatusBadRequest {
+		t.Errorf("second follow status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	w = serve(h.GetIncomingFollowRequests, http.MethodGet, "/requests/incoming", "/requests/incoming", bob, "")
+	incoming := decode[FollowRequestsResponse](t, w)
+	if incoming.TotalCount != 1 || len(incoming.Requests) != 1 || incoming.Requests[0].TargetUser.ID != alice {
+		t.Errorf("incoming = %+v, want one request from alice", incoming)
+	}
+	if incoming.Requests[0].ID != response.RequestID {
+		t.Errorf("incoming request id = %s, want %s", incoming.Requests[0].ID, response.RequestID)
+	}
+}
+
+func TestResolveFollowRequest(t *testing.T) {
+	type action struct {
+		handler func(*FollowHandler) gin.HandlerFunc
+		method  string
+		route   string
+	}
+	approve := action{func(h *FollowHandler) gin.HandlerFunc { return h.ApproveFollowRequest }, http.MethodPost, "/requests/:id/approve"}
+	reject := action{func(h *FollowHandler) gin.HandlerFunc { return h.RejectFollowRequest }, http.MethodPost, "/requests/:id/reject"}
+	cancel := action{func(h *FollowHandler) gin.HandlerFunc { return h.CancelFollowRequest }, http.MethodDelete, "/requests/:id"}
+
+	tests := []struct {
+		name          string
+		action        action
+		userID        string
+		wantStatus    int
+		wantFollowing bool
+		wantState     models.FollowRequestStatus
+	}{
+		{name: "对方同意后建立关注", action: approve, userID: bob, wantStatus: http.StatusOK, wantFollowing: true, wantState: models.FollowRequestApproved},
+		{name: "对方拒绝", action: reject, userID: bob, wantStatus: http.StatusOK, wantState: models.FollowRequestRejected},
+		{name: "请求者撤回", action: cancel, userID: alice, wantStatus: http.StatusOK, wantState: models.FollowRequestCancelled},
+		{name: "请求者不能同意自己的请求", action: approve, userID: alice, wantStatus: http.StatusNotFound, wantState: models.FollowRequestPending},
+		{name: "第三方不能拒绝", action: reject, userID: carol, wantStatus: http.StatusNotFound, wantState: models.FollowRequestPending},
+		{name: "目标用户不能撤回", action: cancel, userID: bob, wantStatus: http.StatusNotFound, wantState: models.FollowRequestPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			request, err := s.requests.CreateRequest(context.Background(), alice, bob)
+			if err != nil {
+				t.Fatalf("CreateRequest() error = %v", err)
+			}
+
+			path := strings.Replace(tt.action.route, ":id", request.ID, 1)
+			w := serve(tt.action.handler(s.handler()), tt.action.method, tt.action.route, path, tt.userID, "")
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if got := s.isFollowing(t, alice, bob); got != tt.wantFollowing {
+				t.Errorf("following = %v, want %v", got, tt.wantFollowing)
+			}
+			stored, err := s.requests.GetRequest(context.Background(), request.ID)
+			if err != nil {
+				t.Fatalf("GetRequest() error = %v", err)
+			}
+			if stored.Status != tt.wantState {
+				t.Errorf("request status = %s, want %s", stored.Status, tt.wantState)
+			}
+		})
+	}
+}
+
+// TestApproveFollowRequestOnce 已处理的请求不能再次同意
+func TestApproveFollowRequestOnce(t *testing.T) {
+	s := newTestStores()
+	h := s.handler()
+	request, err := s.requests.CreateRequest(context.Background(), alice, bob)
+	if err != nil {
+		t.Fatalf("CreateRequest() error = %v", err)
+	}
+	path := "/requests/" + request.ID + "/approve"
+
+	if w := serve(h.ApproveFollowRequest, http.MethodPost, "/requests/:id/approve", path, bob, ""); w.Code != http.StatusOK {
+		t.Fatalf("first approve status = %d, body %s", w.Code, w.Body.String())
+	}
+	if w := serve(h.ApproveFollowRequest, http.MethodPost, "/requests/:id/approve", path, bob, ""); w.Code != http.StatusNotFound {
+		t.Errorf("second approve status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if w := serve(h.ApproveFollowRequest, http.MethodPost, "/requests/:id/approve", "/requests/abc/approve", bob, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("malformed id status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
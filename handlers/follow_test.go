@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestFollowUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		following  bool // alice已关注bob
+		userID     string
+		body       string
+		wantStatus int
+	}{
+		{name: "关注成功", userID: alice, body: targetBody(bob), wantStatus: http.StatusOK},
+		{name: "目标用户ID格式错误", userID: alice, body: targetBody("bob"), wantStatus: http.StatusBadRequest},
+		{name: "缺少目标用户", userID: alice, body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "未获取到当前用户", body: targetBody(bob), wantStatus: http.StatusInternalServerError},
+		{name: "不能关注自己", userID: alice, body: targetBody(alice), wantStatus: http.StatusBadRequest},
+		{name: "已经关注", following: true, userID: alice, body: targetBody(bob), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			if tt.following {
+				s.follow(t, alice, bob)
+			}
+
+			w := serve(s.handler().FollowUser, http.MethodPost, "/user", "/user", tt.userID, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			wantFollowing := tt.following || tt.wantStatus == http.StatusOK
+			if got := s.isFollowing(t, alice, bob); got != wantFollowing {
+				t.Errorf("following = %v, want %v", got, wantFollowing)
+			}
+		})
+	}
+}
+
+func TestUnfollowUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		following  bool
+		target     string
+		wantStatus int
+	}{
+		{name: "取消关注成功", following: true, target: "/user?targetUserId=" + bob, wantStatus: http.StatusOK},
+		{name: "未关注", target: "/user?targetUserId=" + bob, wantStatus: http.StatusBadRequest},
+		{name: "缺少参数", following: true, target: "/user", wantStatus: http.StatusBadRequest},
+		{name: "不能取消关注自己", target: "/user?targetUserId=" + alice, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			if tt.following {
+				s.follow(t, alice, bob)
+			}
+
+			w := serve(s.handler().UnfollowUser, http.MethodDelete, "/user", tt.target, alice, "")
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			wantFollowing := tt.following && tt.wantStatus != http.StatusOK
+			if got := s.isFollowing(t, alice, bob); got != wantFollowing {
+				t.Errorf("following = %v, want %v", got, wantFollowing)
+			}
+		})
+	}
+}
+
+// TestGetFollowCountAfterFollowAndUnfollow HTTP关注和取消关注后，gRPC返回的计数与之一致
+func TestGetFollowCountAfterFollowAndUnfollow(t *testing.T) {
+	s := newTestStores()
+	h := s.handler()
+	for _, target := range []string{bob, carol} {
+		if w := serve(h.FollowUser, http.MethodPost, "/user", "/user", alice, targetBody(target)); w.Code != http.StatusOK {
+			t.Fatalf("follow %s status = %d", target, w.Code)
+		}
+	}
+	s.follow(t, dave, alice)
+	if w := serve(h.UnfollowUser, http.MethodDelete, "/user", "/user?targetUserId="+carol, alice, ""); w.Code != http.StatusOK {
+		t.Fatalf("unfollow status = %d", w.Code)
+	}
+
+	counts, err := s.grpcServer().GetFollowCount(context.Background(), &proto.GetFollowCountRequest{UserId: alice})
+	if err != nil {
+		t.Fatalf("GetFollowCount() error = %v", err)
+	}
+	if counts.FollowingCount != 1 || counts.FollowersCount != 1 {
+		t.Errorf("counts = %d following, %d followers, want 1 and 1", counts.FollowingCount, counts.FollowersCount)
+	}
+}
+
+// TestFollowUserConcurrent 并发的重复关注请求只有一个成功，计数不会重复增加
+func TestFollowUserConcurrent(t *testing.T) {
+	s := newTestStores()
+	h := s.handler()
+
+	const requests = 10
+	codes := make(chan int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serve(h.FollowUser, http.MethodPost, "/user", "/user", alice, targetBody(bob)).Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	succeeded := 0
+	for code := range codes {
+		switch code {
+		case http.StatusOK:
+			succeeded++
+		case http.StatusBadRequest:
+		default:
+			t.Errorf("unexpected status %d", code)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d requests succeeded, want 1", succeeded)
+	}
+	counts, err := s.follows.Counts(context.Background(), bob)
+	if err != nil {
+		t.Fatalf("Counts() error = %v", err)
+	}
+	if counts.FollowersCount != 1 {
+		t.Errorf("FollowersCount = %d, want 1", counts.FollowersCount)
+	}
+}
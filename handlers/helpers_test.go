@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const (
+	alice   = "00000000-0000-0000-0000-00000000000a"
+	bob     = "00000000-0000-0000-0000-00000000000b"
+	carol   = "00000000-0000-0000-0000-00000000000c"
+	dave    = "00000000-0000-0000-0000-00000000000d"
+	missing = "00000000-0000-0000-0000-0000000000ff" // 用户服务中不存在的用户
+)
+
+// fakeUserService 返回users中的用户信息，未设置的用户以ID末位命名，missing返回NotFound
+type fakeUserService struct {
+	proto.UserServiceClient
+
+	mu    sync.Mutex
+	users map[string]*proto.UserInfo
+}
+
+func (f *fakeUserService) set(user *proto.UserInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.users == nil {
+		f.users = make(map[string]*proto.UserInfo)
+	}
+	f.users[user.Id] = user
+}
+
+func (f *fakeUserService) GetUserInfo(ctx context.Context, in *proto.GetUserInfoRequest, opts ...grpc.CallOption) (*proto.UserInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if user, ok := f.users[in.UserId]; ok {
+		return user, nil
+	}
+	if in.UserId == missing {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &proto.UserInfo{Id: in.UserId, Username: "user-" + in.UserId[len(in.UserId)-1:]}, nil
+}
+
+// fakePostService 所有用户都没有帖子
+type fakePostService struct {
+	proto.PostServiceClient
+}
+
+func (fakePostService) GetUserPosts(ctx context.Context, in *proto.GetUserPostsRequest, opts ...grpc.CallOption) (*proto.GetUserPostsResponse, error) {
+	return &proto.GetUserPostsResponse{}, nil
+}
+
+// testStores 处理器使用的内存存储，拉黑和关注请求与关注关系共用发件箱
+type testStores struct {
+	follows   *store.MemoryFollowStore
+	requests  *store.MemoryFollowRequestStore
+	settings  *store.MemorySettingsStore
+	blocks    *store.MemoryBlockStore
+	mutes     *store.MemoryMuteStore
+	lists     *store.MemoryListStore
+	users     *fakeUserService
+	directory *store.MemoryUserDirectory
+	deletions *store.MemoryAccountDeletionStore
+}
+
+func newTestStores() *testStores {
+	follows := store.NewMemoryFollowStore()
+	return &testStores{
+		follows:   follows,
+		requests:  store.NewMemoryFollowRequestStore(follows.Outbox()),
+		settings:  store.NewMemorySettingsStore(),
+		blocks:    store.NewMemoryBlockStore(follows.Outbox()),
+		mutes:     store.NewMemoryMuteStore(),
+		lists:     store.NewMemoryListStore(),
+		users:     &fakeUserService{},
+		directory: store.NewMemoryUserDirectory(),
+		deletions: store.NewMemoryAccountDeletionStore(),
+	}
+}
+
+func (s *testStores) enricher() *enrichment.Enricher {
+	return enrichment.NewEnricher(s.users, fakePostService{}, 0, nil)
+}
+
+// handler 创建不带推荐引擎和信息流的FollowHandler
+func (s *testStores) handler() *FollowHandler {
+	return NewFollowHandler(s.follows, s.requests, s.settings, s.blocks, s.mutes, s.lists, s.enricher(), nil, nil)
+}
+
+// grpcServer 创建不带缓存、推荐引擎和信息流的FollowGrpcServer
+func (s *testStores) grpcServer() *FollowGrpcServer {
+	return NewFollowGrpcServer(s.follows, s.requests, s.settings, s.blocks, s.mutes, s.lists, s.directory, s.deletions, nil, s.enricher(), nil, nil)
+}
+
+func (s *testStores) follow(t *testing.T, followerID, followingID string) {
+	t.Helper()
+	if _, err := s.follows.Follow(context.Background(), followerID, followingID); err != nil {
+		t.Fatalf("Follow(%s, %s) error = %v", followerID, followingID, err)
+	}
+}
+
+func (s *testStores) unfollow(t *testing.T, followerID, followingID string) {
+	t.Helper()
+	if err := s.follows.Unfollow(context.Background(), followerID, followingID, models.UnfollowReasonUser); err != nil {
+		t.Fatalf("Unfollow(%s, %s) error = %v", followerID, followingID, err)
+	}
+}
+
+func (s *testStores) isFollowing(t *testing.T, followerID, followingID string) bool {
+	t.Helper()
+	exists, err := s.follows.Exists(context.Background(), followerID, followingID)
+	if err != nil {
+		t.Fatalf("Exists() error = %v", err)
+	}
+	return exists
+}
+
+// serve 以userID的身份请求注册在route上的handler，userID为空时不设置当前用户
+func serve(handler gin.HandlerFunc, method, route, target, userID, body string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		if userID != "" {
+			c.Set("userId", userID)
+		}
+	}, handler)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+	return v
+}
+
+func targetBody(targetUserID string) string {
+	return `{"targetUserId":"` + targetUserID + `"}`
+}
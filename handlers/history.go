@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/store"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRecentUnfollowersRequest 定义获取最近取消关注的粉丝的请求参数
+type GetRecentUnfollowersRequest struct {
+	Limit  int    `form:"limit,default=10"`
+	Cursor string `form:"cursor"`
+}
+
+// UnfollowerDetail 定义每个取消关注的粉丝的详细信息
+type UnfollowerDetail struct {
+	TargetUser   UserSummary           `json:"targetUser"`
+	FollowedAt   time.Time             `json:"followedAt"`
+	UnfollowedAt time.Time             `json:"unfollowedAt"`
+	Reason       models.UnfollowReason `json:"reason"`
+}
+
+// GetRecentUnfollowers 按取消关注时间倒序获取曾经关注当前用户、后来取消关注的用户。
+// 存在拉黑关系的用户以及之后重新关注的用户不在结果中
+func (h *FollowHandler) GetRecentUnfollowers(c *gin.Context) {
+	var req GetRecentUnfollowersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 验证参数
+	if req.Limit < 1 {
+		req.Limit = 10
+	}
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	// 隐藏存在拉黑关系的用户
+	hiddenUserIDs, err := h.blocks.RelatedUserIDs(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	page, err := h.store.ListUnfollowers(c.Request.Context(), userID.(string), store.ListOptions{
+		Limit:             req.Limit,
+		Cursor:            cursor,
+		ExcludeUserIDs:    hiddenUserIDs,
+		ExcludeRefollowed: true,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unfollowers": h.unfollowerDetails(c.Request.Context(), page.Entries),
+		"nextCursor":  page.NextCursor,
+	})
+}
+
+// unfollowerDetails 补充取消关注的用户的展示信息，跳过获取信息失败的用户
+func (h *FollowHandler) unfollowerDetails(ctx context.Context, entries []models.FollowHistory) []UnfollowerDetail {
+	followerIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		followerIDs = append(followerIDs, entry.FollowerID)
+	}
+	profiles := h.enricher.Enrich(ctx, followerIDs, enrichment.Options{})
+
+	details := make([]UnfollowerDetail, 0, len(entries))
+	for _, entry := range entries {
+		profile, ok := profiles[entry.FollowerID]
+		if !ok {
+			continue
+		}
+		details = append(details, UnfollowerDetail{
+			TargetUser:   newUserSummary(profile.User),
+			FollowedAt:   entry.FollowedAt,
+			UnfollowedAt: *entry.UnfollowedAt,
+			Reason:       entry.Reason,
+		})
+	}
+	return details
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListRecentUnfollowers 按取消关注时间倒序返回曾经关注user_id、现已结束的关注关系，
+// 与HTTP接口不同，不过滤存在拉黑关系或已重新关注的用户
+func (s *FollowGrpcServer) ListRecentUnfollowers(ctx context.Context, req *proto.ListFollowsRequest) (*proto.ListFollowHistoryResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	opts, err := grpcListOptions(req.PageSize, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.store.ListUnfollowers(ctx, req.UserId, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ListFollowHistoryResponse{
+		Entries:    protoHistoryEntries(page.Entries),
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// GetRelationshipHistory 返回follower_id对following_id的所有关注关系历史，用于审计
+func (s *FollowGrpcServer) GetRelationshipHistory(ctx context.Context, req *proto.GetRelationshipHistoryRequest) (*proto.GetRelationshipHistoryResponse, error) {
+	if req.FollowerId == "" || req.FollowingId == "" {
+		return nil, status.Error(codes.InvalidArgument, "follower_id and following_id are required")
+	}
+
+	entries, err := s.store.RelationshipHistory(ctx, req.FollowerId, req.FollowingId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetRelationshipHistoryResponse{
+		Entries: protoHistoryEntries(entries),
+	}, nil
+}
+
+// DeleteUserFollows 用户注销账号时由用户服务调用，创建清理任务后立即返回。
+// 关注关系、拉黑、静音、关注请求、设置、分组和推荐反馈由jobs.AccountDeletionJob在后台分批清理
+func (s *FollowGrpcServer) DeleteUserFollows(ctx context.Context, req *proto.DeleteUserFollowsRequest) (*proto.DeleteUserFollowsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	if err := s.deletions.RequestDeletion(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return &proto.DeleteUserFollowsResponse{
+		Accepted: true,
+	}, nil
+}
+
+func protoHistoryEntries(entries []models.FollowHistory) []*proto.FollowHistoryEntry {
+	result := make([]*proto.FollowHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		protoEntry := &proto.FollowHistoryEntry{
+			FollowerId:  entry.FollowerID,
+			FollowingId: entry.FollowingID,
+			FollowedAt:  timestamppb.New(entry.FollowedAt),
+			Reason:      string(entry.Reason),
+		}
+		if entry.UnfollowedAt != nil {
+			protoEntry.UnfollowedAt = timestamppb.New(*entry.UnfollowedAt)
+		}
+		result = append(result, protoEntry)
+	}
+	return result
+}
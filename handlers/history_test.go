@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/proto"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unfollowersResponse 与GetRecentUnfollowers返回的JSON对应
+type unfollowersResponse struct {
+	Unfollowers []UnfollowerDetail `json:"unfollowers"`
+	NextCursor  string             `json:"nextCursor"`
+}
+
+// TestGetRecentUnfollowersFullPages 重新关注和拉黑的用户在存储层跳过，每页都能填满limit
+func TestGetRecentUnfollowersFullPages(t *testing.T) {
+	s := newTestStores()
+	const erin = "00000000-0000-0000-0000-00000000000e"
+	for _, follower := range []string{bob, carol, dave, erin} {
+		s.follow(t, follower, alice)
+		s.unfollow(t, follower, alice)
+	}
+	// dave重新关注，erin被拉黑，bob取消关注了两次
+	s.follow(t, dave, alice)
+	if _, err := s.blocks.Block(context.Background(), alice, erin); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	s.follow(t, bob, alice)
+	s.unfollow(t, bob, alice)
+
+	h := s.handler()
+	var got []string
+	target := "/recent-unfollowers?limit=1"
+	for {
+		w := serve(h.GetRecentUnfollowers, http.MethodGet, "/recent-unfollowers", target, alice, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+		}
+		response := decode[unfollowersResponse](t, w)
+		if response.NextCursor != "" && len(response.Unfollowers) != 1 {
+			t.Fatalf("page %v is short", response.Unfollowers)
+		}
+		for _, unfollower := range response.Unfollowers {
+			got = append(got, unfollower.TargetUser.ID)
+		}
+		if response.NextCursor == "" {
+			break
+		}
+		target = "/recent-unfollowers?limit=1&cursor=" + url.QueryEscape(response.NextCursor)
+	}
+	if !reflect.DeepEqual(got, []string{bob, carol}) {
+		t.Errorf("unfollowers = %v, want [bob carol]", got)
+	}
+}
+
+// TestListRecentUnfollowersKeepsRefollowed gRPC接口不过滤重新关注的用户
+func TestListRecentUnfollowersKeepsRefollowed(t *testing.T) {
+	s := newTestStores()
+	s.follow(t, bob, alice)
+	s.unfollow(t, bob, alice)
+	s.follow(t, bob, alice)
+
+	response, err := s.grpcServer().ListRecentUnfollowers(context.Background(), &proto.ListFollowsRequest{UserId: alice})
+	if err != nil {
+		t.Fatalf("ListRecentUnfollowers() error = %v", err)
+	}
+	if len(response.Entries) != 1 || response.Entries[0].FollowerId != bob || response.Entries[0].Reason != string(models.UnfollowReasonUser) {
+		t.Errorf("entries = %v, want bob's unfollow", response.Entries)
+	}
+}
+
+// TestDeleteUserFollowsQueuesDeletion 接口只创建清理任务，关注关系由后台任务结束
+func TestDeleteUserFollowsQueuesDeletion(t *testing.T) {
+	s := newTestStores()
+	s.follow(t, alice, bob)
+	server := s.grpcServer()
+
+	response, err := server.DeleteUserFollows(context.Background(), &proto.DeleteUserFollowsRequest{UserId: alice})
+	if err != nil {
+		t.Fatalf("DeleteUserFollows() error = %v", err)
+	}
+	if !response.Accepted {
+		t.Error("Accepted = false, want true")
+	}
+	if !s.isFollowing(t, alice, bob) {
+		t.Error("DeleteUserFollows() ended the follow synchronously")
+	}
+
+	deletion, err := s.deletions.ClaimDeletion(context.Background(), time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimDeletion() error = %v", err)
+	}
+	if deletion == nil || deletion.UserID != alice {
+		t.Errorf("claimed deletion = %+v, want alice", deletion)
+	}
+
+	_, err = server.DeleteUserFollows(context.Background(), &proto.DeleteUserFollowsRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error = %v, want InvalidArgument", err)
+	}
+}
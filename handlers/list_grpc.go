@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultGrpcPageSize = 20
+	maxGrpcPageSize     = 100
+)
+
+func (s *FollowGrpcServer) ListFollowing(ctx context.Context, req *proto.ListFollowsRequest) (*proto.ListFollowsResponse, error) {
+	return s.listFollows(ctx, req, s.store.ListFollowing, func(f models.Follow) string {
+		return f.FollowingID
+	})
+}
+
+func (s *FollowGrpcServer) ListFollowers(ctx context.Context, req *proto.ListFollowsRequest) (*proto.ListFollowsResponse, error) {
+	return s.listFollows(ctx, req, s.store.ListFollowers, func(f models.Follow) string {
+		return f.FollowerID
+	})
+}
+
+func (s *FollowGrpcServer) ListMutualFollows(ctx context.Context, req *proto.ListFollowsRequest) (*proto.ListFollowsResponse, error) {
+	return s.listFollows(ctx, req, s.store.ListMutual, func(f models.Follow) string {
+		return f.FollowingID
+	})
+}
+
+// listFollows 使用游标分页查询关注关系，otherParty返回关系另一方的用户ID。
+// 与HTTP接口相同，不返回与user_id存在拉黑关系的用户
+func (s *FollowGrpcServer) listFollows(
+	ctx context.Context,
+	req *proto.ListFollowsRequest,
+	list func(ctx context.Context, userID string, opts store.ListOptions) (*store.FollowPage, error),
+	otherParty func(models.Follow) string,
+) (*proto.ListFollowsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	opts, err := grpcListOptions(req.PageSize, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ExcludeUserIDs, err = s.blocks.RelatedUserIDs(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	page, err := list(ctx, req.UserId, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*proto.FollowEntry, 0, len(page.Follows))
+	for _, follow := range page.Follows {
+		entries = append(entries, &proto.FollowEntry{
+			UserId:     otherParty(follow),
+			FollowedAt: timestamppb.New(follow.CreatedAt),
+		})
+	}
+
+	return &proto.ListFollowsResponse{
+		Entries:    entries,
+		NextCursor: page.NextCursor,
+		TotalCount: page.TotalCount,
+	}, nil
+}
+
+// grpcListOptions 将gRPC请求中的分页参数转换为ListOptions
+func grpcListOptions(pageSize int32, token string) (store.ListOptions, error) {
+	opts := store.ListOptions{
+		Limit: int(pageSize),
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultGrpcPageSize
+	}
+	if opts.Limit > maxGrpcPageSize {
+		opts.Limit = maxGrpcPageSize
+	}
+
+	cursor, err := decodeCursorParam(token)
+	if errors.Is(err, store.ErrInvalidCursor) {
+		return opts, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if err != nil {
+		return opts, err
+	}
+	opts.Cursor = cursor
+	return opts, nil
+}
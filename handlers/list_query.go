@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+	"sort"
+	"strings"
+)
+
+// 列表的排序方式
+const (
+	sortNewest   = "newest"   // 按关注时间倒序
+	sortOldest   = "oldest"   // 按关注时间正序
+	sortActive   = "active"   // 在线用户优先，其余按最近在线时间倒序
+	sortUsername = "username" // 按用户名字母顺序
+)
+
+// maxProfileListCandidates 需要按用户信息排序或筛选时，最多获取最近建立的多少条关系。
+// 每条关系都要向用户服务请求一次用户信息（缓存未命中时），且每次翻页都会重新获取，因此上限保持较小
+const maxProfileListCandidates = 200
+
+// ListQuery 定义关注、粉丝和互关列表的排序和筛选参数
+type ListQuery struct {
+	Sort string `form:"sort,default=newest" binding:"oneof=newest oldest active username"`
+	// City 和 Gender 不为空时只返回用户信息中城市或性别与之相同的用户
+	City   string `form:"city"`
+	Gender string `form:"gender"`
+}
+
+// needsProfiles 判断是否需要先获取用户信息再排序和筛选，此时不支持cursor分页
+func (q ListQuery) needsProfiles() bool {
+	return q.Sort == sortActive || q.Sort == sortUsername || q.City != "" || q.Gender != ""
+}
+
+// matches 判断用户是否符合城市和性别筛选条件
+func (q ListQuery) matches(user *proto.UserInfo) bool {
+	return (q.City == "" || user.City == q.City) && (q.Gender == "" || user.Gender == q.Gender)
+}
+
+// parseRelationFilter 解析粉丝和关注列表的relation参数
+func parseRelationFilter(relation string) (store.MutualFilter, bool) {
+	switch relation {
+	case "", "all":
+		return store.MutualAny, true
+	case "mutual":
+		return store.MutualOnly, true
+	case "non_mutual":
+		return store.MutualExclude, true
+	default:
+		return store.MutualAny, false
+	}
+}
+
+// listByProfile 获取最近（opts.Ascending为true时为最早）建立的最多maxProfileListCandidates条关系及其用户信息，
+// 按query筛选和排序后，按opts中的Offset和Limit分页。返回的TotalCount为候选关系中筛选后的数量，
+// profiles只包含当前页的用户并带有最新帖子，truncated为true表示关系数超过maxProfileListCandidates，更早的关系没有参与筛选和排序
+func listByProfile(
+	ctx context.Context,
+	enricher *enrichment.Enricher,
+	list func(ctx context.Context, userID string, opts store.ListOptions) (*store.FollowPage, error),
+	otherParty func(models.Follow) string,
+	userID string,
+	opts store.ListOptions,
+	query ListQuery,
+) (page *store.FollowPage, profiles map[string]*enrichment.Profile, truncated bool, err error) {
+	candidates, err := list(ctx, userID, store.ListOptions{
+		Limit:          maxProfileListCandidates,
+		ExcludeUserIDs: opts.ExcludeUserIDs,
+		Ascending:      opts.Ascending,
+		Mutual:         opts.Mutual,
+	})
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	otherIDs := make([]string, 0, len(candidates.Follows))
+	for _, follow := range candidates.Follows {
+		otherIDs = append(otherIDs, otherParty(follow))
+	}
+	users := enricher.Enrich(ctx, otherIDs, enrichment.Options{})
+
+	// 获取用户信息失败的用户无法判断是否符合条件，不出现在结果中
+	matched := make([]models.Follow, 0, len(candidates.Follows))
+	for _, follow := range candidates.Follows {
+		if profile, ok := users[otherParty(follow)]; ok && query.matches(profile.User) {
+			matched = append(matched, follow)
+		}
+	}
+	sortByProfile(matched, users, otherParty, query.Sort)
+
+	follows := paginateFollows(matched, opts.Offset, opts.Limit)
+	pageIDs := make([]string, 0, len(follows))
+	for _, follow := range follows {
+		pageIDs = append(pageIDs, otherParty(follow))
+	}
+	profiles = enricher.Enrich(ctx, pageIDs, enrichment.Options{LatestPost: true})
+
+	return &store.FollowPage{
+		Follows:    follows,
+		TotalCount: int64(len(matched)),
+	}, profiles, candidates.TotalCount > int64(len(candidates.Follows)), nil
+}
+
+// sortByProfile 按sortBy对关注关系排序，newest和oldest已由查询时的排序方向保证，保持原顺序
+func sortByProfile(follows []models.Follow, profiles map[string]*enrichment.Profile, otherParty func(models.Follow) string, sortBy string) {
+	user := func(i int) *proto.UserInfo {
+		return profiles[otherParty(follows[i])].User
+	}
+
+	switch sortBy {
+	case sortActive:
+		sort.SliceStable(follows, func(i, j int) bool {
+			a, b := user(i), user(j)
+			if a.IsOnline != b.IsOnline {
+				return a.IsOnline
+			}
+			return a.LastOnlineTime.AsTime().After(b.LastOnlineTime.AsTime())
+		})
+	case sortUsername:
+		sort.SliceStable(follows, func(i, j int) bool {
+			return strings.ToLower(user(i).Username) < strings.ToLower(user(j).Username)
+		})
+	}
+}
+
+func paginateFollows(follows []models.Follow, offset, limit int) []models.Follow {
+	if offset >= len(follows) {
+		return []models.Follow{}
+	}
+	follows = follows[offset:]
+	if limit > 0 && limit < len(follows) {
+		follows = follows[:limit]
+	}
+	return follows
+}
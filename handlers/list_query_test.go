@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"fmt"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/proto"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSortByProfile(t *testing.T) {
+	now := time.Now()
+	profiles := map[string]*enrichment.Profile{
+		"a": {User: &proto.UserInfo{Username: "bob", LastOnlineTime: timestamppb.New(now.Add(-time.Hour))}},
+		"b": {User: &proto.UserInfo{Username: "Alice", IsOnline: true, LastOnlineTime: timestamppb.New(now.Add(-2 * time.Hour))}},
+		"c": {User: &proto.UserInfo{Username: "carol", LastOnlineTime: timestamppb.New(now.Add(-time.Minute))}},
+	}
+	tests := []struct {
+		sortBy string
+		want   []string
+	}{
+		{sortBy: sortNewest, want: []string{"a", "b", "c"}},
+		{sortBy: sortActive, want: []string{"b", "c", "a"}},
+		{sortBy: sortUsername, want: []string{"b", "a", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			follows := []models.Follow{{FollowingID: "a"}, {FollowingID: "b"}, {FollowingID: "c"}}
+			sortByProfile(follows, profiles, func(f models.Follow) string { return f.FollowingID }, tt.sortBy)
+			got := make([]string, 0, len(follows))
+			for _, follow := range follows {
+				got = append(got, follow.FollowingID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMyFollowsFilter(t *testing.T) {
+	s := newTestStores()
+	for _, userID := range []string{bob, carol, dave} {
+		s.follow(t, alice, userID)
+	}
+	s.users.set(&proto.UserInfo{Id: bob, Username: "Zed", City: "上海", Gender: "female"})
+	s.users.set(&proto.UserInfo{Id: carol, Username: "carol", City: "北京", Gender: "female"})
+	s.users.set(&proto.UserInfo{Id: dave, Username: "amy", City: "上海", Gender: "male"})
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "按城市筛选并按用户名排序", query: "?city=上海&sort=username", want: []string{dave, bob}},
+		{name: "按性别筛选", query: "?gender=female&sort=username", want: []string{carol, bob}},
+		{name: "同时按城市和性别筛选", query: "?city=上海&gender=male", want: []string{dave}},
+		{name: "筛选后分页", query: "?city=上海&sort=username&offset=1&limit=1", want: []string{bob}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(s.handler().GetMyFollows, http.MethodGet, "/follows", "/follows"+tt.query, alice, "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+			}
+			response := decode[FollowResponse](t, w)
+			got := make([]string, 0, len(response.Follows))
+			for _, follow := range response.Follows {
+				got = append(got, follow.TargetUser.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("follows = %v, want %v", got, tt.want)
+			}
+			if response.Truncated {
+				t.Error("truncated = true, want false")
+			}
+		})
+	}
+
+	// 按用户信息排序时不支持游标分页
+	w := serve(s.handler().GetMyFollows, http.MethodGet, "/follows", "/follows?sort=username&cursor=abc", alice, "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("cursor with sort=username status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+// TestGetMyFollowsProfileTruncated 关系数超过maxProfileListCandidates时只对最近的关系排序，并在响应中标记truncated
+func TestGetMyFollowsProfileTruncated(t *testing.T) {
+	s := newTestStores()
+	total := maxProfileListCandidates + 5
+	for i := 0; i < total; i++ {
+		s.follow(t, alice, fmt.Sprintf("00000000-0000-0000-0001-%012d", i))
+	}
+
+	tests := []struct {
+		query         string
+		wantTotal     int64
+		wantTruncated bool
+	}{
+		{query: "?sort=username", wantTotal: int64(maxProfileListCandidates), wantTruncated: true},
+		{query: "?sort=newest", wantTotal: int64(total), wantTruncated: false},
+	}
+	for _, tt := range tests {
+		w := serve(s.handler().GetMyFollows, http.MethodGet, "/follows", "/follows"+tt.query, alice, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: status = %d, body %s", tt.query, w.Code, w.Body.String())
+		}
+		response := decode[FollowResponse](t, w)
+		if response.TotalCount != tt.wantTotal || response.Truncated != tt.wantTruncated {
+			t.Errorf("%q: totalCount = %d, truncated = %v, want %d, %v", tt.query, response.TotalCount, response.Truncated, tt.wantTotal, tt.wantTruncated)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"followservice/store"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MuteUserRequest 定义静音用户的请求参数
+type MuteUserRequest struct {
+	TargetUserID string `json:"targetUserId" binding:"required,len=36"`
+}
+
+// MuteUser 静音已关注的用户，其帖子不再出现在信息流中，关注关系保持不变
+func (h *FollowHandler) MuteUser(c *gin.Context) {
+	var req MuteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	// 只能静音已关注的用户
+	following, err := h.store.Exists(c.Request.Context(), userID.(string), req.TargetUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+	if !following {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "未关注该用户"})
+		return
+	}
+
+	_, err = h.mutes.Mute(c.Request.Context(), userID.(string), req.TargetUserID)
+	if errors.Is(err, store.ErrAlreadyMuted) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "已经静音该用户"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "静音成功",
+	})
+}
+
+// UnmuteUser 解除对用户的静音
+func (h *FollowHandler) UnmuteUser(c *gin.Context) {
+	// 获取目标用户ID
+	targetUserID := c.Query("targetUserId")
+	if len(targetUserID) != 36 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	err := h.mutes.Unmute(c.Request.Context(), userID.(string), targetUserID)
+	if errors.Is(err, store.ErrNotMuted) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "未静音该用户"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "解除静音成功",
+	})
+}
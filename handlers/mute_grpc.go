@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+)
+
+func (s *FollowGrpcServer) GetMutedUserIds(ctx context.Context, req *proto.GetMutedUserIdsRequest) (*proto.GetMutedUserIdsResponse, error) {
+	mutedIds, err := s.mutes.MutedUserIDs(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.GetMutedUserIdsResponse{
+		MutedUserIds: mutedIds,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMuteUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		following  bool
+		muted      bool
+		body       string
+		wantStatus int
+		wantMuted  bool
+	}{
+		{name: "静音已关注的用户", following: true, body: targetBody(bob), wantStatus: http.StatusOK, wantMuted: true},
+		{name: "未关注不能静音", body: targetBody(bob), wantStatus: http.StatusBadRequest},
+		{name: "已经静音", following: true, muted: true, body: targetBody(bob), wantStatus: http.StatusBadRequest, wantMuted: true},
+		{name: "目标用户ID格式错误", following: true, body: targetBody("bob"), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			if tt.following {
+				s.follow(t, alice, bob)
+			}
+			if tt.muted {
+				if _, err := s.mutes.Mute(context.Background(), alice, bob); err != nil {
+					t.Fatalf("Mute() error = %v", err)
+				}
+			}
+
+			w := serve(s.handler().MuteUser, http.MethodPost, "/mute", "/mute", alice, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			muted, err := s.mutes.MutedUserIDs(context.Background(), alice)
+			if err != nil {
+				t.Fatalf("MutedUserIDs() error = %v", err)
+			}
+			if got := len(muted) == 1; got != tt.wantMuted {
+				t.Errorf("muted = %v, want %v", muted, tt.wantMuted)
+			}
+		})
+	}
+}
+
+// TestMuteKeepsFollow 静音和解除静音都不影响关注关系和粉丝数
+func TestMuteKeepsFollow(t *testing.T) {
+	s := newTestStores()
+	h := s.handler()
+	s.follow(t, alice, bob)
+
+	if w := serve(h.MuteUser, http.MethodPost, "/mute", "/mute", alice, targetBody(bob)); w.Code != http.StatusOK {
+		t.Fatalf("mute status = %d, body %s", w.Code, w.Body.String())
+	}
+	if !s.isFollowing(t, alice, bob) {
+		t.Error("mute removed the follow")
+	}
+	counts, err := s.follows.Counts(context.Background(), bob)
+	if err != nil {
+		t.Fatalf("Counts() error = %v", err)
+	}
+	if counts.FollowersCount != 1 {
+		t.Errorf("FollowersCount = %d after mute, want 1", counts.FollowersCount)
+	}
+
+	if w := serve(h.UnmuteUser, http.MethodDelete, "/mute", "/mute?targetUserId="+bob, alice, ""); w.Code != http.StatusOK {
+		t.Fatalf("unmute status = %d, body %s", w.Code, w.Body.String())
+	}
+	if w := serve(h.UnmuteUser, http.MethodDelete, "/mute", "/mute?targetUserId="+bob, alice, ""); w.Code != http.StatusBadRequest {
+		t.Errorf("second unmute status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !s.isFollowing(t, alice, bob) {
+		t.Error("unmute removed the follow")
+	}
+}
+
+func TestGetFollowingUserIdsExcludeMuted(t *testing.T) {
+	s := newTestStores()
+	server := s.grpcServer()
+	for _, target := range []string{bob, carol, dave} {
+		s.follow(t, alice, target)
+	}
+	if _, err := s.mutes.Mute(context.Background(), alice, carol); err != nil {
+		t.Fatalf("Mute() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		excludeMuted bool
+		want         []string
+	}{
+		{name: "包含静音用户", want: []string{dave, carol, bob}},
+		{name: "排除静音用户", excludeMuted: true, want: []string{dave, bob}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := server.GetFollowingUserIds(context.Background(), &proto.GetFollowingUserIdsRequest{UserId: alice, ExcludeMuted: tt.excludeMuted})
+			if err != nil {
+				t.Fatalf("GetFollowingUserIds() error = %v", err)
+			}
+			if !reflect.DeepEqual(response.FollowingUserIds, tt.want) {
+				t.Errorf("ids = %v, want %v", response.FollowingUserIds, tt.want)
+			}
+		})
+	}
+
+	muted, err := server.GetMutedUserIds(context.Background(), &proto.GetMutedUserIdsRequest{UserId: alice})
+	if err != nil {
+		t.Fatalf("GetMutedUserIds() error = %v", err)
+	}
+	if !reflect.DeepEqual(muted.MutedUserIds, []string{carol}) {
+		t.Errorf("muted ids = %v, want [carol]", muted.MutedUserIds)
+	}
+}
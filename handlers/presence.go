@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"followservice/enrichment"
+	"followservice/store"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultOnlineLimit = 50
+	maxOnlineLimit     = 200
+	// maxPresenceCandidates 只检查最近关注的多少个用户的在线状态，避免关注数很大时请求过多的用户信息
+	maxPresenceCandidates = 1000
+)
+
+// GetOnlineFollowsRequest 定义获取在线关注用户的请求参数
+type GetOnlineFollowsRequest struct {
+	// Scope 为following时返回关注的人，为mutual时只返回互关的人
+	Scope string `form:"scope,default=following"`
+	Limit int    `form:"limit,default=50"`
+}
+
+// OnlineUser 定义在线的关注用户
+type OnlineUser struct {
+	TargetUser   UserSummary `json:"targetUser"`
+	IsMutual     bool        `json:"isMutual"`
+	LastOnlineAt time.Time   `json:"lastOnlineAt"`
+}
+
+// GetOnlineFollows 获取当前用户关注的人中正在在线的用户，按最近在线时间倒序
+func (h *FollowHandler) GetOnlineFollows(c *gin.Context) {
+	var req GetOnlineFollowsRequest
+	if err := c.ShouldBindQuery(&req); err != nil || (req.Scope != "following" && req.Scope != "mutual") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	online, err := onlineFollows(c.Request.Context(), h.store, h.blocks, h.enricher, userID.(string), req.Scope == "mutual")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	// onlineCount只统计最近关注的maxPresenceCandidates个用户中在线的人数
+	c.JSON(http.StatusOK, gin.H{
+		"online":      online[:min(len(online), clampOnlineLimit(req.Limit))],
+		"onlineCount": len(online),
+	})
+}
+
+func clampOnlineLimit(limit int) int {
+	if limit <= 0 {
+		return defaultOnlineLimit
+	}
+	if limit > maxOnlineLimit {
+		return maxOnlineLimit
+	}
+	return limit
+}
+
+// onlineFollows 返回userID最近关注的maxPresenceCandidates个用户中正在在线的用户，按最近在线时间倒序。
+// mutualOnly为true时只检查互关的用户，存在拉黑关系的用户和获取用户信息失败的用户不在结果中。
+// 在线状态变化很快，用户信息不读取缓存，直接请求用户服务
+func onlineFollows(ctx context.Context, follows store.FollowStore, blocks store.BlockStore, enricher *enrichment.Enricher, userID string, mutualOnly bool) ([]OnlineUser, error) {
+	hiddenUserIDs, err := blocks.RelatedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := store.ListOptions{
+		Limit:          maxPresenceCandidates,
+		ExcludeUserIDs: hiddenUserIDs,
+	}
+	var page *store.FollowPage
+	if mutualOnly {
+		page, err = follows.ListMutual(ctx, userID, opts)
+	} else {
+		page, err = follows.ListFollowing(ctx, userID, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := enricher.Enrich(ctx, followingIDs(page.Follows), enrichment.Options{SkipUserCache: true})
+	online := make([]OnlineUser, 0)
+	onlineIDs := make([]string, 0)
+	for _, follow := range page.Follows {
+		profile, ok := profiles[follow.FollowingID]
+		if !ok || !profile.User.IsOnline {
+			continue
+		}
+		user := OnlineUser{
+			TargetUser: newUserSummary(profile.User),
+			IsMutual:   mutualOnly,
+		}
+		if profile.User.LastOnlineTime != nil {
+			user.LastOnlineAt = profile.User.LastOnlineTime.AsTime()
+		}
+		online = append(online, user)
+		onlineIDs = append(onlineIDs, follow.FollowingID)
+	}
+
+	if !mutualOnly && len(onlineIDs) > 0 {
+		states, err := follows.FollowStates(ctx, userID, onlineIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range online {
+			online[i].IsMutual = states[online[i].TargetUser.ID].FollowedBy
+		}
+	}
+
+	sort.SliceStable(online, func(i, j int) bool {
+		return online[i].LastOnlineAt.After(online[j].LastOnlineAt)
+	})
+	return online, nil
+}
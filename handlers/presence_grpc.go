@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *FollowGrpcServer) GetOnlineFollowing(ctx context.Context, req *proto.GetOnlineFollowingRequest) (*proto.GetOnlineFollowingResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	online, err := onlineFollows(ctx, s.store, s.blocks, s.enricher, req.UserId, req.MutualOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &proto.GetOnlineFollowingResponse{
+		Users:       make([]*proto.OnlineUser, 0, len(online)),
+		OnlineCount: int32(len(online)),
+	}
+	for _, user := range online[:min(len(online), clampOnlineLimit(int(req.Limit)))] {
+		onlineUser := &proto.OnlineUser{
+			UserId:   user.TargetUser.ID,
+			Username: user.TargetUser.Username,
+			Avatar:   user.TargetUser.Avatar,
+			IsMutual: user.IsMutual,
+		}
+		if !user.LastOnlineAt.IsZero() {
+			onlineUser.LastOnlineTime = timestamppb.New(user.LastOnlineAt)
+		}
+		response.Users = append(response.Users, onlineUser)
+	}
+	return response, nil
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// onlineResponse 与GetOnlineFollows返回的JSON对应
+type onlineResponse struct {
+	Online      []OnlineUser `json:"online"`
+	OnlineCount int          `json:"onlineCount"`
+}
+
+// presenceStores alice关注bob、carol、dave、erin和missing：bob和carol在线（carol更近且与alice互关），
+// dave离线，erin在线但拉黑了alice，missing在用户服务中不存在
+func presenceStores(t *testing.T) *testStores {
+	t.Helper()
+	const erin = "00000000-0000-0000-0000-00000000000e"
+	s := newTestStores()
+	for _, userID := range []string{bob, carol, dave, erin, missing} {
+		s.follow(t, alice, userID)
+	}
+	s.follow(t, carol, alice)
+	if _, err := s.blocks.Block(context.Background(), erin, alice); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+
+	now := time.Now()
+	s.users.set(&proto.UserInfo{Id: bob, Username: "bob", IsOnline: true, LastOnlineTime: timestamppb.New(now.Add(-time.Hour))})
+	s.users.set(&proto.UserInfo{Id: carol, Username: "carol", IsOnline: true, LastOnlineTime: timestamppb.New(now.Add(-time.Minute))})
+	s.users.set(&proto.UserInfo{Id: dave, Username: "dave", LastOnlineTime: timestamppb.New(now)})
+	s.users.set(&proto.UserInfo{Id: erin, Username: "erin", IsOnline: true, LastOnlineTime: timestamppb.New(now)})
+	return s
+}
+
+func TestGetOnlineFollows(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantIDs    []string
+		wantMutual []bool
+		wantCount  int
+	}{
+		{name: "关注的人", query: "", wantIDs: []string{carol, bob}, wantMutual: []bool{true, false}, wantCount: 2},
+		{name: "只看互关", query: "?scope=mutual", wantIDs: []string{carol}, wantMutual: []bool{true}, wantCount: 1},
+		{name: "limit不影响在线人数", query: "?limit=1", wantIDs: []string{carol}, wantMutual: []bool{true}, wantCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := presenceStores(t)
+			w := serve(s.handler().GetOnlineFollows, http.MethodGet, "/online", "/online"+tt.query, alice, "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+			}
+			response := decode[onlineResponse](t, w)
+			ids := make([]string, 0, len(response.Online))
+			mutual := make([]bool, 0, len(response.Online))
+			for _, user := range response.Online {
+				ids = append(ids, user.TargetUser.ID)
+				mutual = append(mutual, user.IsMutual)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) || !reflect.DeepEqual(mutual, tt.wantMutual) {
+				t.Errorf("online = %v (mutual %v), want %v (mutual %v)", ids, mutual, tt.wantIDs, tt.wantMutual)
+			}
+			if response.OnlineCount != tt.wantCount {
+				t.Errorf("onlineCount = %d, want %d", response.OnlineCount, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestGetOnlineFollowsInvalidScope(t *testing.T) {
+	w := serve(newTestStores().handler().GetOnlineFollows, http.MethodGet, "/online", "/online?scope=fans", alice, "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGetOnlineFollowing(t *testing.T) {
+	server := presenceStores(t).grpcServer()
+
+	response, err := server.GetOnlineFollowing(context.Background(), &proto.GetOnlineFollowingRequest{UserId: alice, Limit: 1})
+	if err != nil {
+		t.Fatalf("GetOnlineFollowing() error = %v", err)
+	}
+	if response.OnlineCount != 2 || len(response.Users) != 1 {
+		t.Fatalf("got %d users of %d online, want 1 of 2", len(response.Users), response.OnlineCount)
+	}
+	if user := response.Users[0]; user.UserId != carol || !user.IsMutual || user.LastOnlineTime == nil {
+		t.Errorf("user = %+v, want mutual carol with last online time", user)
+	}
+
+	_, err = server.GetOnlineFollowing(context.Background(), &proto.GetOnlineFollowingRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("error = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsFollowing(t *testing.T) {
+	s := newTestStores()
+	server := s.grpcServer()
+	s.follow(t, alice, bob)
+
+	tests := []struct {
+		follower, following string
+		want                bool
+	}{
+		{follower: alice, following: bob, want: true},
+		{follower: bob, following: alice, want: false},
+		{follower: alice, following: carol, want: false},
+	}
+	for _, tt := range tests {
+		response, err := server.IsFollowing(context.Background(), &proto.IsFollowingRequest{FollowerId: tt.follower, FollowingId: tt.following})
+		if err != nil {
+			t.Fatalf("IsFollowing() error = %v", err)
+		}
+		if response.IsFollowing != tt.want {
+			t.Errorf("IsFollowing(%s, %s) = %v, want %v", tt.follower, tt.following, response.IsFollowing, tt.want)
+		}
+	}
+}
+
+func TestGetRelationships(t *testing.T) {
+	s := newTestStores()
+	s.follow(t, alice, bob)
+	s.follow(t, bob, alice)
+	s.follow(t, alice, carol)
+	s.follow(t, dave, alice)
+	if _, err := s.blocks.Block(context.Background(), missing, alice); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+
+	// 目标顺序与请求一致，重复的目标各返回一次
+	targets := []string{dave, bob, missing, carol, dave}
+	response, err := s.grpcServer().GetRelationships(context.Background(), &proto.GetRelationshipsRequest{ViewerId: alice, TargetIds: targets})
+	if err != nil {
+		t.Fatalf("GetRelationships() error = %v", err)
+	}
+	want := []*proto.Relationship{
+		{TargetId: dave, FollowedBy: true},
+		{TargetId: bob, Following: true, FollowedBy: true, Mutual: true},
+		{TargetId: missing, Blocked: true},
+		{TargetId: carol, Following: true},
+		{TargetId: dave, FollowedBy: true},
+	}
+	if len(response.Relationships) != len(want) {
+		t.Fatalf("got %d relationships, want %d", len(response.Relationships), len(want))
+	}
+	for i, got := range response.Relationships {
+		w := want[i]
+		if got.TargetId != w.TargetId || got.Following != w.Following || got.FollowedBy != w.FollowedBy || got.Mutual != w.Mutual || got.Blocked != w.Blocked {
+			t.Errorf("relationship %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestGetRelationshipsInvalidArgument(t *testing.T) {
+	tooMany := make([]string, maxRelationshipTargets+1)
+	for i := range tooMany {
+		tooMany[i] = bob
+	}
+	tests := []struct {
+		name string
+		req  *proto.GetRelationshipsRequest
+	}{
+		{name: "缺少查看者", req: &proto.GetRelationshipsRequest{TargetIds: []string{bob}}},
+		{name: "目标用户过多", req: &proto.GetRelationshipsRequest{ViewerId: alice, TargetIds: tooMany}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestStores().grpcServer().GetRelationships(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
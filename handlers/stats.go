@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"followservice/analytics"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statsDateLayout 统计接口中日期的格式，日期均为UTC
+const statsDateLayout = "2006-01-02"
+
+// defaultStatsDays 未指定起始日期时统计的天数（含结束日期当天）
+const defaultStatsDays = 7
+
+// GetFollowStatsRequest 定义获取粉丝增长统计的请求参数
+type GetFollowStatsRequest struct {
+	From        string `form:"from"` // YYYY-MM-DD，默认为结束日期前6天
+	To          string `form:"to"`   // YYYY-MM-DD，默认为今天
+	Granularity string `form:"granularity"`
+}
+
+// FollowStatsPoint 定义一个时间段内的粉丝变化
+type FollowStatsPoint struct {
+	Date          string `json:"date"` // 时间段的第一天
+	NewFollowers  int64  `json:"newFollowers"`
+	LostFollowers int64  `json:"lostFollowers"`
+	NetGrowth     int64  `json:"netGrowth"`
+}
+
+// GetFollowStats 获取当前用户按天、周或月汇总的粉丝增长
+func (h *FollowHandler) GetFollowStats(c *gin.Context) {
+	var req GetFollowStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	from, to, granularity, err := parseStatsRange(req.From, req.To, req.Granularity)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	growth, err := analytics.FollowerGrowth(c.Request.Context(), h.store, userID.(string), from, to, granularity)
+	if errors.Is(err, analytics.ErrInvalidRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "时间范围无效，最长为366天"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	points := make([]FollowStatsPoint, 0, len(growth.Points))
+	for _, point := range growth.Points {
+		points = append(points, newFollowStatsPoint(point))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"from":        growth.From.Format(statsDateLayout),
+		"to":          growth.To.Format(statsDateLayout),
+		"granularity": growth.Granularity,
+		"points":      points,
+		"total":       newFollowStatsPoint(growth.Total),
+	})
+}
+
+// parseStatsRange 解析统计的起止日期和粒度，未指定时统计截至今天的最近7天
+func parseStatsRange(fromValue, toValue, granularityValue string) (time.Time, time.Time, analytics.Granularity, error) {
+	granularity, err := analytics.ParseGranularity(granularityValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, "", err
+	}
+
+	to := time.Now().UTC()
+	if toValue != "" {
+		if to, err = time.Parse(statsDateLayout, toValue); err != nil {
+			return time.Time{}, time.Time{}, "", err
+		}
+	}
+	from := to.AddDate(0, 0, -(defaultStatsDays - 1))
+	if fromValue != "" {
+		if from, err = time.Parse(statsDateLayout, fromValue); err != nil {
+			return time.Time{}, time.Time{}, "", err
+		}
+	}
+	return from, to, granularity, nil
+}
+
+func newFollowStatsPoint(point analytics.Point) FollowStatsPoint {
+	return FollowStatsPoint{
+		Date:          point.Start.Format(statsDateLayout),
+		NewFollowers:  point.Gained,
+		LostFollowers: point.Lost,
+		NetGrowth:     point.Net,
+	}
+}
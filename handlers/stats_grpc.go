@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/analytics"
+	"followservice/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *FollowGrpcServer) GetFollowStats(ctx context.Context, req *proto.GetFollowStatsRequest) (*proto.GetFollowStatsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	from, to, granularity, err := parseStatsRange(req.From, req.To, req.Granularity)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from, to or granularity")
+	}
+
+	growth, err := analytics.FollowerGrowth(ctx, s.store, req.UserId, from, to, granularity)
+	if errors.Is(err, analytics.ErrInvalidRange) {
+		return nil, status.Error(codes.InvalidArgument, "invalid range")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &proto.GetFollowStatsResponse{
+		From:        growth.From.Format(statsDateLayout),
+		To:          growth.To.Format(statsDateLayout),
+		Granularity: string(growth.Granularity),
+		Points:      make([]*proto.FollowStatsPoint, 0, len(growth.Points)),
+		Total:       newProtoStatsPoint(growth.Total),
+	}
+	for _, point := range growth.Points {
+		response.Points = append(response.Points, newProtoStatsPoint(point))
+	}
+	return response, nil
+}
+
+func newProtoStatsPoint(point analytics.Point) *proto.FollowStatsPoint {
+	return &proto.FollowStatsPoint{
+		Date:          point.Start.Format(statsDateLayout),
+		NewFollowers:  point.Gained,
+		LostFollowers: point.Lost,
+		NetGrowth:     point.Net,
+	}
+}
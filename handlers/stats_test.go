@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"followservice/models"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// followStatsResponse 与GetFollowStats返回的JSON对应
+type followStatsResponse struct {
+	From        string             `json:"from"`
+	To          string             `json:"to"`
+	Granularity string             `json:"granularity"`
+	Points      []FollowStatsPoint `json:"points"`
+	Total       FollowStatsPoint   `json:"total"`
+}
+
+func TestGetFollowStatsDefaultRange(t *testing.T) {
+	s := newTestStores()
+	s.follow(t, bob, alice)
+	s.follow(t, carol, alice)
+	if err := s.follows.Unfollow(context.Background(), carol, alice, models.UnfollowReasonUser); err != nil {
+		t.Fatalf("Unfollow() error = %v", err)
+	}
+
+	w := serve(s.handler().GetFollowStats, http.MethodGet, "/stats", "/stats", alice, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	response := decode[followStatsResponse](t, w)
+
+	today := time.Now().UTC().Format(statsDateLayout)
+	if response.To != today || response.Granularity != "day" || len(response.Points) != defaultStatsDays {
+		t.Fatalf("response = %+v, want %d daily points ending %s", response, defaultStatsDays, today)
+	}
+	last := response.Points[len(response.Points)-1]
+	want := FollowStatsPoint{Date: today, NewFollowers: 2, LostFollowers: 1, NetGrowth: 1}
+	if last != want {
+		t.Errorf("today = %+v, want %+v", last, want)
+	}
+	if response.Total.NetGrowth != 1 {
+		t.Errorf("total = %+v, want net growth 1", response.Total)
+	}
+}
+
+func TestGetFollowStatsInvalidRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "未知的统计粒度", query: "?granularity=year"},
+		{name: "日期格式错误", query: "?from=2024/03/01"},
+		{name: "起始日期晚于结束日期", query: "?from=2024-03-02&to=2024-03-01"},
+		{name: "超过366天", query: "?from=2023-01-01&to=2024-01-03"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(newTestStores().handler().GetFollowStats, http.MethodGet, "/stats", "/stats"+tt.query, alice, "")
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultStreamChunkSize = 500
+	maxStreamChunkSize     = 1000
+)
+
+func (s *FollowGrpcServer) StreamFollowingUserIds(req *proto.StreamUserIdsRequest, stream grpc.ServerStreamingServer[proto.UserIdsChunk]) error {
+	ctx := stream.Context()
+
+	var excludeIds []string
+	if req.ExcludeMuted {
+		mutedIds, err := s.mutes.MutedUserIDs(ctx, req.UserId)
+		if err != nil {
+			return err
+		}
+		excludeIds = mutedIds
+	}
+
+	return streamUserIds(req, stream, excludeIds, s.store.ForEachFollowing, func(f models.Follow) string {
+		return f.FollowingID
+	})
+}
+
+func (s *FollowGrpcServer) StreamFollowerUserIds(req *proto.StreamUserIdsRequest, stream grpc.ServerStreamingServer[proto.UserIdsChunk]) error {
+	return streamUserIds(req, stream, nil, s.store.ForEachFollower, func(f models.Follow) string {
+		return f.FollowerID
+	})
+}
+
+// streamUserIds 逐条遍历关注关系并按chunk_size分批发送，服务端只缓存一批ID
+func streamUserIds(
+	req *proto.StreamUserIdsRequest,
+	stream grpc.ServerStreamingServer[proto.UserIdsChunk],
+	excludeIds []string,
+	forEach func(ctx context.Context, userID string, opts store.ListOptions, fn func(models.Follow) error) error,
+	otherParty func(models.Follow) string,
+) error {
+	if req.UserId == "" {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	chunkSize := int(req.ChunkSize)
+	if chunkSize <= 0 {
+		chunkSize = defaultStreamChunkSize
+	}
+	if chunkSize > maxStreamChunkSize {
+		chunkSize = maxStreamChunkSize
+	}
+
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+
+	chunk := make([]string, 0, chunkSize)
+	var last models.Follow
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		err := stream.Send(&proto.UserIdsChunk{
+			UserIds: chunk,
+			Cursor:  store.EncodeCursor(last.CreatedAt, last.ID),
+		})
+		chunk = make([]string, 0, chunkSize)
+		return err
+	}
+
+	err = forEach(stream.Context(), req.UserId, store.ListOptions{
+		Cursor:         cursor,
+		ExcludeUserIDs: excludeIds,
+	}, func(follow models.Follow) error {
+		chunk = append(chunk, otherParty(follow))
+		last = follow
+		if len(chunk) >= chunkSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chunkRecorder 记录服务端流发送的每一批ID
+type chunkRecorder struct {
+	grpc.ServerStream
+	chunks []*proto.UserIdsChunk
+}
+
+func (r *chunkRecorder) Context() context.Context {
+	return context.Background()
+}
+
+func (r *chunkRecorder) Send(chunk *proto.UserIdsChunk) error {
+	r.chunks = append(r.chunks, chunk)
+	return nil
+}
+
+// chunkSizes 返回每一批的ID数量
+func (r *chunkRecorder) chunkSizes() []int {
+	sizes := make([]int, 0, len(r.chunks))
+	for _, chunk := range r.chunks {
+		sizes = append(sizes, len(chunk.UserIds))
+	}
+	return sizes
+}
+
+func TestStreamFollowingUserIds(t *testing.T) {
+	s := newTestStores()
+	server := s.grpcServer()
+	for _, target := range []string{bob, carol, dave, missing} {
+		s.follow(t, alice, target)
+	}
+	if _, err := s.mutes.Mute(context.Background(), alice, carol); err != nil {
+		t.Fatalf("Mute() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *proto.StreamUserIdsRequest
+		want []int
+	}{
+		{name: "按chunk_size分批", req: &proto.StreamUserIdsRequest{UserId: alice, ChunkSize: 3}, want: []int{3, 1}},
+		{name: "默认一批发送", req: &proto.StreamUserIdsRequest{UserId: alice}, want: []int{4}},
+		{name: "排除静音用户", req: &proto.StreamUserIdsRequest{UserId: alice, ChunkSize: 3, ExcludeMuted: true}, want: []int{3}},
+		{name: "没有关注时不发送", req: &proto.StreamUserIdsRequest{UserId: bob}, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &chunkRecorder{}
+			if err := server.StreamFollowingUserIds(tt.req, recorder); err != nil {
+				t.Fatalf("StreamFollowingUserIds() error = %v", err)
+			}
+			if got := recorder.chunkSizes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunk sizes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestStreamFollowerUserIdsResume 中断后从最后一批的游标继续，不重复也不遗漏
+func TestStreamFollowerUserIdsResume(t *testing.T) {
+	s := newTestStores()
+	server := s.grpcServer()
+	for _, follower := range []string{bob, carol, dave} {
+		s.follow(t, follower, alice)
+	}
+
+	first := &chunkRecorder{}
+	if err := server.StreamFollowerUserIds(&proto.StreamUserIdsRequest{UserId: alice, ChunkSize: 2}, first); err != nil {
+		t.Fatalf("StreamFollowerUserIds() error = %v", err)
+	}
+	if len(first.chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(first.chunks))
+	}
+
+	resumed := &chunkRecorder{}
+	req := &proto.StreamUserIdsRequest{UserId: alice, ChunkSize: 2, Cursor: first.chunks[0].Cursor}
+	if err := server.StreamFollowerUserIds(req, resumed); err != nil {
+		t.Fatalf("StreamFollowerUserIds() error = %v", err)
+	}
+	if len(resumed.chunks) != 1 || !reflect.DeepEqual(resumed.chunks[0].UserIds, first.chunks[1].UserIds) {
+		t.Errorf("resumed chunks = %v, want %v", resumed.chunks, first.chunks[1:])
+	}
+}
+
+func TestStreamUserIdsInvalidArgument(t *testing.T) {
+	server := newTestStores().grpcServer()
+	tests := []struct {
+		name string
+		req  *proto.StreamUserIdsRequest
+	}{
+		{name: "缺少user_id", req: &proto.StreamUserIdsRequest{}},
+		{name: "游标无效", req: &proto.StreamUserIdsRequest{UserId: alice, Cursor: "!!!"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := server.StreamFollowerUserIds(tt.req, &chunkRecorder{})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestGetFollowingUserIdsPageToken(t *testing.T) {
+	s := newTestStores()
+	server := s.grpcServer()
+	for _, target := range []string{bob, carol, dave} {
+		s.follow(t, alice, target)
+	}
+
+	all, err := server.GetFollowingUserIds(context.Background(), &proto.GetFollowingUserIdsRequest{UserId: alice})
+	if err != nil {
+		t.Fatalf("GetFollowingUserIds() error = %v", err)
+	}
+	if len(all.FollowingUserIds) != 3 || all.NextPageToken != "" {
+		t.Fatalf("without page_size = %v (token %q), want all 3 ids and no token", all.FollowingUserIds, all.NextPageToken)
+	}
+
+	paged := make([]string, 0, 3)
+	req := &proto.GetFollowingUserIdsRequest{UserId: alice, PageSize: 2}
+	for pages := 0; ; pages++ {
+		if pages > 2 {
+			t.Fatal("paging did not terminate")
+		}
+		response, err := server.GetFollowingUserIds(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GetFollowingUserIds() error = %v", err)
+		}
+		paged = append(paged, response.FollowingUserIds...)
+		if response.NextPageToken == "" {
+			break
+		}
+		req.PageToken = response.NextPageToken
+	}
+	if !reflect.DeepEqual(paged, all.FollowingUserIds) {
+		t.Errorf("paged ids = %v, want %v", paged, all.FollowingUserIds)
+	}
+
+	_, err = server.GetFollowerUserIds(context.Background(), &proto.GetFollowerUserIdsRequest{UserId: alice, PageToken: "!!!"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid page_token error = %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"followservice/enrichment"
+	"followservice/suggestions"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSuggestionLimit = 20
+	maxSuggestionLimit     = 50
+)
+
+// GetSuggestionsRequest 定义获取推荐关注的请求参数
+type GetSuggestionsRequest struct {
+	Limit int `form:"limit,default=20"`
+}
+
+// SuggestionDetail 定义每个推荐关注用户的详细信息
+type SuggestionDetail struct {
+	TargetUser  UserSummary        `json:"targetUser"`
+	Reason      suggestions.Reason `json:"reason"`
+	Explanation string             `json:"explanation"`
+	MutualCount int64              `json:"mutualCount,omitempty"`
+	FollowedBy  []UserSummary      `json:"followedBy,omitempty"` // 关注了该用户的、当前用户关注的人
+	City        string             `json:"city,omitempty"`       // 同城推荐时的城市
+}
+
+// DismissSuggestionRequest 定义对推荐标记不感兴趣的请求参数
+type DismissSuggestionRequest struct {
+	TargetUserID string `json:"targetUserId" binding:"required,len=36"`
+}
+
+// GetSuggestions 获取推荐关注的用户
+func (h *FollowHandler) GetSuggestions(c *gin.Context) {
+	var req GetSuggestionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	limit := clampSuggestionLimit(req.Limit)
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+
+	found, err := h.suggester.Suggest(c.Request.Context(), userID.(string), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suggestions": suggestionDetails(c.Request.Context(), h.enricher, found),
+	})
+}
+
+// DismissSuggestion 对推荐的用户标记不感兴趣，该用户不会再出现在推荐中
+func (h *FollowHandler) DismissSuggestion(c *gin.Context) {
+	var req DismissSuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+	if req.TargetUserID == userID.(string) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	if err := h.suggester.Dismiss(c.Request.Context(), userID.(string), req.TargetUserID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "将不再推荐该用户",
+	})
+}
+
+func clampSuggestionLimit(limit int) int {
+	if limit <= 0 {
+		return defaultSuggestionLimit
+	}
+	if limit > maxSuggestionLimit {
+		return maxSuggestionLimit
+	}
+	return limit
+}
+
+// suggestionDetails 补充推荐用户及中间人的展示信息，获取用户信息失败的推荐会被跳过
+func suggestionDetails(ctx context.Context, enricher *enrichment.Enricher, found []suggestions.Suggestion) []SuggestionDetail {
+	userIDs := make([]string, 0, len(found))
+	for _, suggestion := range found {
+		userIDs = append(userIDs, suggestion.UserID)
+		userIDs = append(userIDs, suggestion.Via...)
+	}
+	profiles := enricher.Enrich(ctx, userIDs, enrichment.Options{})
+
+	details := make([]SuggestionDetail, 0, len(found))
+	for _, suggestion := range found {
+		profile, ok := profiles[suggestion.UserID]
+		if !ok {
+			continue
+		}
+
+		detail := SuggestionDetail{
+			TargetUser:  newUserSummary(profile.User),
+			Reason:      suggestion.Reason,
+			MutualCount: suggestion.MutualCount,
+			City:        suggestion.City,
+		}
+		for _, viaID := range suggestion.Via {
+			if via, ok := profiles[viaID]; ok {
+				detail.FollowedBy = append(detail.FollowedBy, newUserSummary(via.User))
+			}
+		}
+		detail.Explanation = explainSuggestion(detail)
+		details = append(details, detail)
+	}
+	return details
+}
+
+// explainSuggestion 生成推荐理由，例如"张三 和其他 3 人关注了TA"、"同城 · 上海"
+func explainSuggestion(detail SuggestionDetail) string {
+	switch detail.Reason {
+	case suggestions.ReasonFollowedByFollowing:
+		if len(detail.FollowedBy) == 0 {
+			return fmt.Sprintf("%d 位你关注的人关注了TA", detail.MutualCount)
+		}
+		if detail.MutualCount <= 1 {
+			return fmt.Sprintf("%s 关注了TA", detail.FollowedBy[0].Username)
+		}
+		return fmt.Sprintf("%s 和其他 %d 人关注了TA", detail.FollowedBy[0].Username, detail.MutualCount-1)
+	case suggestions.ReasonSameCity:
+		return fmt.Sprintf("同城 · %s", detail.City)
+	case suggestions.ReasonNewUser:
+		return "新加入的用户"
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/proto"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxSyncProfiles SyncUserProfiles单次请求允许的最大用户数
+const maxSyncProfiles = 500
+
+func (s *FollowGrpcServer) GetSuggestions(ctx context.Context, req *proto.GetSuggestionsRequest) (*proto.GetSuggestionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	found, err := s.suggester.Suggest(ctx, req.UserId, clampSuggestionLimit(int(req.Limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	details := suggestionDetails(ctx, s.enricher, found)
+	response := &proto.GetSuggestionsResponse{
+		Suggestions: make([]*proto.SuggestedUser, 0, len(details)),
+	}
+	for _, detail := range details {
+		viaIDs := make([]string, 0, len(detail.FollowedBy))
+		for _, via := range detail.FollowedBy {
+			viaIDs = append(viaIDs, via.ID)
+		}
+		response.Suggestions = append(response.Suggestions, &proto.SuggestedUser{
+			UserId:        detail.TargetUser.ID,
+			Username:      detail.TargetUser.Username,
+			Avatar:        detail.TargetUser.Avatar,
+			Reason:        string(detail.Reason),
+			Explanation:   detail.Explanation,
+			MutualCount:   detail.MutualCount,
+			FollowedByIds: viaIDs,
+			City:          detail.City,
+		})
+	}
+	return response, nil
+}
+
+func (s *FollowGrpcServer) DismissSuggestion(ctx context.Context, req *proto.DismissSuggestionRequest) (*proto.DismissSuggestionResponse, error) {
+	if req.UserId == "" || req.TargetId == "" || req.UserId == req.TargetId {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id or target_id")
+	}
+
+	if err := s.suggester.Dismiss(ctx, req.UserId, req.TargetId); err != nil {
+		return nil, err
+	}
+
+	return &proto.DismissSuggestionResponse{
+		Success: true,
+	}, nil
+}
+
+// SyncUserProfiles 同步写入用户资料副本，写入失败时返回错误，由用户服务重试
+func (s *FollowGrpcServer) SyncUserProfiles(ctx context.Context, req *proto.SyncUserProfilesRequest) (*proto.SyncUserProfilesResponse, error) {
+	if len(req.Profiles) > maxSyncProfiles {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d profiles per request", maxSyncProfiles)
+	}
+
+	now := time.Now()
+	profiles := make([]models.UserProfile, 0, len(req.Profiles))
+	for _, snapshot := range req.Profiles {
+		if snapshot.UserId == "" {
+			return nil, status.Error(codes.InvalidArgument, "user_id is required")
+		}
+		profile := models.UserProfile{
+			UserID:    snapshot.UserId,
+			City:      snapshot.City,
+			Gender:    snapshot.Gender,
+			IsOnline:  snapshot.IsOnline,
+			UpdatedAt: now,
+		}
+		if snapshot.LastOnlineTime != nil {
+			profile.LastOnlineAt = snapshot.LastOnlineTime.AsTime()
+		}
+		if snapshot.CreatedAt != nil {
+			profile.JoinedAt = snapshot.CreatedAt.AsTime()
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err := s.directory.UpsertProfiles(ctx, profiles); err != nil {
+		return nil, err
+	}
+
+	return &proto.SyncUserProfilesResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"followservice/proto"
+	"followservice/store"
+	"followservice/suggestions"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestExplainSuggestion(t *testing.T) {
+	tests := []struct {
+		name   string
+		detail SuggestionDetail
+		want   string
+	}{
+		{
+			name:   "一位中间人",
+			detail: SuggestionDetail{Reason: suggestions.ReasonFollowedByFollowing, MutualCount: 1, FollowedBy: []UserSummary{{Username: "张三"}}},
+			want:   "张三 关注了TA",
+		},
+		{
+			name:   "多位中间人",
+			detail: SuggestionDetail{Reason: suggestions.ReasonFollowedByFollowing, MutualCount: 4, FollowedBy: []UserSummary{{Username: "张三"}}},
+			want:   "张三 和其他 3 人关注了TA",
+		},
+		{
+			name:   "中间人信息获取失败",
+			detail: SuggestionDetail{Reason: suggestions.ReasonFollowedByFollowing, MutualCount: 2},
+			want:   "2 位你关注的人关注了TA",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := explainSuggestion(tt.detail); got != tt.want {
+				t.Errorf("explainSuggestion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSuggestions(t *testing.T) {
+	s := newTestStores()
+	for _, via := range []string{bob, carol} {
+		s.follow(t, alice, via)
+		s.follow(t, via, dave)
+	}
+	engine := suggestions.NewEngine(s.blocks, s.requests, store.NewMemoryDismissalStore(), suggestions.NewGraphSource(s.follows))
+	h := NewFollowHandler(s.follows, s.requests, s.settings, s.blocks, s.mutes, s.lists, s.enricher(), engine, nil)
+
+	w := serve(h.GetSuggestions, http.MethodGet, "/suggestions", "/suggestions", alice, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	response := decode[struct {
+		Suggestions []SuggestionDetail `json:"suggestions"`
+	}](t, w)
+	if len(response.Suggestions) != 1 {
+		t.Fatalf("suggestions = %+v, want dave only", response.Suggestions)
+	}
+	got := response.Suggestions[0]
+	if got.TargetUser.ID != dave || got.MutualCount != 2 || len(got.FollowedBy) != 2 || got.Explanation != got.FollowedBy[0].Username+" 和其他 1 人关注了TA" {
+		t.Errorf("suggestion = %+v", got)
+	}
+
+	// 标记不感兴趣后不再推荐
+	if w := serve(h.DismissSuggestion, http.MethodPost, "/suggestions/dismiss", "/suggestions/dismiss", alice, targetBody(dave)); w.Code != http.StatusOK {
+		t.Fatalf("dismiss status = %d, body %s", w.Code, w.Body.String())
+	}
+	w = serve(h.GetSuggestions, http.MethodGet, "/suggestions", "/suggestions", alice, "")
+	if response := decode[struct {
+		Suggestions []SuggestionDetail `json:"suggestions"`
+	}](t, w); len(response.Suggestions) != 0 {
+		t.Errorf("suggestions after dismiss = %+v, want none", response.Suggestions)
+	}
+}
+
+// TestSyncUserProfiles 用户服务推送的新用户不需要被获取过信息就能出现在新用户推荐中
+func TestSyncUserProfiles(t *testing.T) {
+	s := newTestStores()
+	joined := time.Now().Add(-time.Hour)
+	_, err := s.grpcServer().SyncUserProfiles(context.Background(), &proto.SyncUserProfilesRequest{Profiles: []*proto.UserProfileSnapshot{
+		{UserId: bob, City: "上海", IsOnline: true, CreatedAt: timestamppb.New(joined)},
+	}})
+	if err != nil {
+		t.Fatalf("SyncUserProfiles() error = %v", err)
+	}
+
+	found, err := suggestions.NewNewUserSource(s.directory, s.follows, 0).Suggest(context.Background(), alice, nil, 10)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(found) != 1 || found[0].UserID != bob {
+		t.Errorf("suggestions = %+v, want bob", found)
+	}
+}
+
+func TestSyncUserProfilesInvalidArgument(t *testing.T) {
+	tooMany := make([]*proto.UserProfileSnapshot, maxSyncProfiles+1)
+	for i := range tooMany {
+		tooMany[i] = &proto.UserProfileSnapshot{UserId: bob}
+	}
+	tests := []struct {
+		name string
+		req  *proto.SyncUserProfilesRequest
+	}{
+		{name: "缺少用户ID", req: &proto.SyncUserProfilesRequest{Profiles: []*proto.UserProfileSnapshot{{UserId: bob}, {City: "上海"}}}},
+		{name: "用户过多", req: &proto.SyncUserProfilesRequest{Profiles: tooMany}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			if _, err := s.grpcServer().SyncUserProfiles(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("error = %v, want InvalidArgument", err)
+			}
+			// 请求无效时不写入任何用户
+			found, err := s.directory.NewUsers(context.Background(), time.Time{}, store.DirectoryQuery{})
+			if err != nil {
+				t.Fatalf("NewUsers() error = %v", err)
+			}
+			if len(found) != 0 {
+				t.Errorf("directory = %+v, want empty", found)
+			}
+		})
+	}
+}
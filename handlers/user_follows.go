@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/store"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultUserFollowsLimit = 10
+	// maxUserFollowsLimit 单页的最大数量，每个用户都需要请求用户服务补充信息
+	maxUserFollowsLimit = 50
+)
+
+// GetUserFollowsRequest 定义查看其他用户关注列表或粉丝列表的请求参数
+type GetUserFollowsRequest struct {
+	Limit  int    `form:"limit,default=10"`
+	Cursor string `form:"cursor"`
+}
+
+// ViewerRelationship 定义查看者与列表中用户的关系
+type ViewerRelationship struct {
+	IsSelf     bool `json:"isSelf"`     // 该用户就是查看者本人
+	Following  bool `json:"following"`  // 查看者关注了该用户
+	FollowedBy bool `json:"followedBy"` // 该用户关注了查看者
+	Mutual     bool `json:"mutual"`
+}
+
+// UserFollowDetail 定义其他用户关注列表或粉丝列表中的一项
+type UserFollowDetail struct {
+	TargetUser   UserSummary        `json:"targetUser"`
+	Relationship ViewerRelationship `json:"relationship"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+// UserFollowsResponse 定义其他用户关注列表或粉丝列表的响应结构
+type UserFollowsResponse struct {
+	Users      []UserFollowDetail `json:"users"`
+	TotalCount int64              `json:"totalCount"`
+	NextCursor string             `json:"nextCursor"`
+}
+
+// GetUserFollows 获取路径参数中用户的关注列表，受该用户的关注列表可见范围限制
+func (h *FollowHandler) GetUserFollows(c *gin.Context) {
+	h.listUserFollows(c, (*models.UserSettings).FollowsVisibilityOrDefault, h.store.ListFollowing, func(f models.Follow) string {
+		return f.FollowingID
+	})
+}
+
+// GetUserFans 获取路径参数中用户的粉丝列表，受该用户的粉丝列表可见范围限制
+func (h *FollowHandler) GetUserFans(c *gin.Context) {
+	h.listUserFollows(c, (*models.UserSettings).FansVisibilityOrDefault, h.store.ListFollowers, func(f models.Follow) string {
+		return f.FollowerID
+	})
+}
+
+// listUserFollows 检查查看者能否看到列表后使用list查询，并为每个用户标注与查看者的关系。
+// visibility返回列表的可见范围，otherParty返回关系中列表所有者之外的用户ID
+func (h *FollowHandler) listUserFollows(
+	c *gin.Context,
+	visibility func(*models.UserSettings) models.ListVisibility,
+	list func(ctx context.Context, userID string, opts store.ListOptions) (*store.FollowPage, error),
+	otherParty func(models.Follow) string,
+) {
+	var req GetUserFollowsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+	req.Limit = clampUserFollowsLimit(req.Limit)
+	cursor, err := decodeCursorParam(req.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	// 获取当前用户ID
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "无法获取用户信息"})
+		return
+	}
+	viewerID := userID.(string)
+	ownerID := c.Param("id")
+	if len(ownerID) != 36 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "参数缺失或格式错误"})
+		return
+	}
+
+	if ownerID != viewerID {
+		allowed, err := canViewUserFollows(c.Request.Context(), h.store, h.blocks, h.settings, viewerID, ownerID, visibility)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+			return
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "该用户未公开此列表"})
+			return
+		}
+	}
+
+	// 隐藏与查看者存在拉黑关系的用户
+	hiddenUserIDs, err := h.blocks.RelatedUserIDs(c.Request.Context(), viewerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	page, err := list(c.Request.Context(), ownerID, store.ListOptions{
+		Limit:          req.Limit,
+		Cursor:         cursor,
+		ExcludeUserIDs: hiddenUserIDs,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	otherIDs := make([]string, 0, len(page.Follows))
+	for _, follow := range page.Follows {
+		otherIDs = append(otherIDs, otherParty(follow))
+	}
+	states, err := h.store.FollowStates(c.Request.Context(), viewerID, otherIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	response := UserFollowsResponse{
+		Users:      make([]UserFollowDetail, 0, len(page.Follows)),
+		TotalCount: page.TotalCount,
+		NextCursor: page.NextCursor,
+	}
+	profiles := h.enricher.Enrich(c.Request.Context(), otherIDs, enrichment.Options{})
+	for _, follow := range page.Follows {
+		otherID := otherParty(follow)
+		profile, ok := profiles[otherID]
+		if !ok {
+			continue // 跳过获取失败的用户
+		}
+
+		state := states[otherID]
+		response.Users = append(response.Users, UserFollowDetail{
+			TargetUser: newUserSummary(profile.User),
+			Relationship: ViewerRelationship{
+				IsSelf:     otherID == viewerID,
+				Following:  state.Following,
+				FollowedBy: state.FollowedBy,
+				Mutual:     state.Following && state.FollowedBy,
+			},
+			Timestamp: follow.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func clampUserFollowsLimit(limit int) int {
+	if limit <= 0 {
+		return defaultUserFollowsLimit
+	}
+	if limit > maxUserFollowsLimit {
+		return maxUserFollowsLimit
+	}
+	return limit
+}
+
+// canViewUserFollows 判断viewerID能否查看ownerID的列表：存在拉黑关系时不能查看，
+// 其余情况由列表的可见范围决定
+func canViewUserFollows(
+	ctx context.Context,
+	follows store.FollowStore,
+	blocks store.BlockStore,
+	settingsStore store.SettingsStore,
+	viewerID, ownerID string,
+	visibility func(*models.UserSettings) models.ListVisibility,
+) (bool, error) {
+	blockedByViewer, blockedByOwner, err := blockedBetween(ctx, blocks, viewerID, ownerID)
+	if err != nil {
+		return false, err
+	}
+	if blockedByViewer || blockedByOwner {
+		return false, nil
+	}
+
+	settings, err := settingsStore.GetSettings(ctx, ownerID)
+	if err != nil {
+		return false, err
+	}
+	switch visibility(settings) {
+	case models.ListVisibilityPublic:
+		return true, nil
+	case models.ListVisibilityMutuals:
+		states, err := follows.FollowStates(ctx, viewerID, []string{ownerID})
+		if err != nil {
+			return false, err
+		}
+		state := states[ownerID]
+		return state.Following && state.FollowedBy, nil
+	default:
+		return false, nil
+	}
+}
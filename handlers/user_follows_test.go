@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"followservice/models"
+	"net/http"
+	"testing"
+)
+
+// TestGetUserFollowsVisibility bob的关注和粉丝列表分别按可见范围对carol（互关）、dave（单向关注bob）和bob本人开放
+func TestGetUserFollowsVisibility(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   models.UserSettings
+		fans       bool
+		viewerID   string
+		blocked    bool
+		wantStatus int
+	}{
+		{name: "公开", settings: models.UserSettings{FollowsVisibility: models.ListVisibilityPublic}, viewerID: dave, wantStatus: http.StatusOK},
+		{name: "未设置时公开", viewerID: dave, wantStatus: http.StatusOK},
+		{name: "仅互关可见-互关", settings: models.UserSettings{FollowsVisibility: models.ListVisibilityMutuals}, viewerID: carol, wantStatus: http.StatusOK},
+		{name: "仅互关可见-单向关注", settings: models.UserSettings{FollowsVisibility: models.ListVisibilityMutuals}, viewerID: dave, wantStatus: http.StatusForbidden},
+		{name: "仅自己可见-互关", settings: models.UserSettings{FollowsVisibility: models.ListVisibilityHidden}, viewerID: carol, wantStatus: http.StatusForbidden},
+		{name: "仅自己可见-本人", settings: models.UserSettings{FollowsVisibility: models.ListVisibilityHidden}, viewerID: bob, wantStatus: http.StatusOK},
+		{name: "公开但存在拉黑关系", viewerID: dave, blocked: true, wantStatus: http.StatusForbidden},
+		{name: "粉丝列表使用粉丝列表的可见范围", settings: models.UserSettings{FollowsVisibility: models.ListVisibilityHidden}, fans: true, viewerID: dave, wantStatus: http.StatusOK},
+		{name: "粉丝列表仅自己可见", settings: models.UserSettings{FansVisibility: models.ListVisibilityHidden}, fans: true, viewerID: carol, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStores()
+			ctx := context.Background()
+			s.follow(t, bob, carol)
+			s.follow(t, carol, bob)
+			s.follow(t, dave, bob)
+			settings := tt.settings
+			settings.UserID = bob
+			if err := s.settings.SaveSettings(ctx, &settings); err != nil {
+				t.Fatalf("SaveSettings() error = %v", err)
+			}
+			if tt.blocked {
+				if _, err := s.blocks.Block(ctx, bob, dave); err != nil {
+					t.Fatalf("Block() error = %v", err)
+				}
+			}
+
+			handler, route := s.handler().GetUserFollows, "/users/:id/follows"
+			target := "/users/" + bob + "/follows"
+			if tt.fans {
+				handler, route = s.handler().GetUserFans, "/users/:id/fans"
+				target = "/users/" + bob + "/fans"
+			}
+			w := serve(handler, http.MethodGet, route, target, tt.viewerID, "")
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetUserFollowsRelationship(t *testing.T) {
+	s := newTestStores()
+	for _, userID := range []string{alice, carol, dave} {
+		s.follow(t, bob, userID)
+	}
+	s.follow(t, alice, carol)
+	s.follow(t, alice, dave)
+	s.follow(t, dave, alice)
+
+	w := serve(s.handler().GetUserFollows, http.MethodGet, "/users/:id/follows", "/users/"+bob+"/follows", alice, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body.String())
+	}
+	response := decode[UserFollowsResponse](t, w)
+	want := map[string]ViewerRelationship{
+		alice: {IsSelf: true},
+		carol: {Following: true},
+		dave:  {Following: true, FollowedBy: true, Mutual: true},
+	}
+	if len(response.Users) != len(want) || response.TotalCount != 3 {
+		t.Fatalf("got %d users (total %d), want 3", len(response.Users), response.TotalCount)
+	}
+	for _, user := range response.Users {
+		if user.Relationship != want[user.TargetUser.ID] {
+			t.Errorf("relationship with %s = %+v, want %+v", user.TargetUser.ID, user.Relationship, want[user.TargetUser.ID])
+		}
+	}
+}
+
+// TestGetUserFollowsLimit limit超过上限时按maxUserFollowsLimit返回
+func TestGetUserFollowsLimit(t *testing.T) {
+	s := newTestStores()
+	for i := 0; i < maxUserFollowsLimit+5; i++ {
+		s.follow(t, fmt.Sprintf("00000000-0000-0000-0001-%012d", i), bob)
+	}
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{query: "", want: defaultUserFollowsLimit},
+		{query: "?limit=-1", want: defaultUserFollowsLimit},
+		{query: "?limit=7", want: 7},
+		{query: "?limit=1000", want: maxUserFollowsLimit},
+	}
+	for _, tt := range tests {
+		w := serve(s.handler().GetUserFans, http.MethodGet, "/users/:id/fans", "/users/"+bob+"/fans"+tt.query, alice, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: status = %d, body %s", tt.query, w.Code, w.Body.String())
+		}
+		response := decode[UserFollowsResponse](t, w)
+		if len(response.Users) != tt.want || response.NextCursor == "" {
+			t.Errorf("%q: got %d users (cursor %q), want %d and a next page", tt.query, len(response.Users), response.NextCursor, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WatchFollowEvents 实时推送关注关系变更，直到客户端断开。
+// 客户端断线后可携带最后收到的resume_token重连，不会丢失期间发生的变更
+func (s *FollowGrpcServer) WatchFollowEvents(req *proto.WatchFollowEventsRequest, stream grpc.ServerStreamingServer[proto.FollowEvent]) error {
+	watcher, ok := s.store.(store.FollowWatcher)
+	if !ok {
+		return status.Error(codes.Unimplemented, "follow store does not support watching")
+	}
+
+	opts := store.WatchOptions{
+		UserID:      req.UserId,
+		ResumeToken: req.ResumeToken,
+	}
+	for _, eventType := range req.EventTypes {
+		switch t := models.EventType(eventType); t {
+		case models.EventFollowCreated, models.EventFollowDeleted:
+			opts.Types = append(opts.Types, t)
+		default:
+			return status.Errorf(codes.InvalidArgument, "unknown event type: %s", eventType)
+		}
+	}
+
+	err := watcher.WatchFollows(stream.Context(), opts, func(change store.FollowChange) error {
+		if change.Checkpoint {
+			return stream.Send(&proto.FollowEvent{
+				ResumeToken: change.ResumeToken,
+				Checkpoint:  true,
+			})
+		}
+		return stream.Send(&proto.FollowEvent{
+			EventType:   string(change.Type),
+			FollowerId:  change.FollowerID,
+			FollowingId: change.FollowingID,
+			OccurredAt:  timestamppb.New(change.OccurredAt),
+			ResumeToken: change.ResumeToken,
+		})
+	})
+	switch {
+	case errors.Is(err, store.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, "invalid resume_token")
+	case errors.Is(err, store.ErrResumeTokenExpired):
+		return status.Error(codes.OutOfRange, "resume_token is too old, restart without it")
+	case stream.Context().Err() != nil:
+		return status.FromContextError(stream.Context().Err()).Err()
+	}
+	return err
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"followservice/proto"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errEnoughEvents = errors.New("enough events")
+
+// eventRecorder 记录推送的事件，收到limit条后让推送返回错误以结束监听
+type eventRecorder struct {
+	grpc.ServerStream
+	ctx    context.Context
+	limit  int
+	events []*proto.FollowEvent
+}
+
+func (r *eventRecorder) Context() context.Context {
+	return r.ctx
+}
+
+func (r *eventRecorder) Send(event *proto.FollowEvent) error {
+	r.events = append(r.events, event)
+	if len(r.events) >= r.limit {
+		return errEnoughEvents
+	}
+	return nil
+}
+
+func TestWatchFollowEvents(t *testing.T) {
+	s := newTestStores()
+	s.follow(t, alice, bob)
+	s.follow(t, carol, dave)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	recorder := &eventRecorder{ctx: ctx, limit: 2}
+	req := &proto.WatchFollowEventsRequest{UserId: alice, EventTypes: []string{string(models.EventFollowCreated)}, ResumeToken: "0"}
+	if err := s.grpcServer().WatchFollowEvents(req, recorder); !errors.Is(err, errEnoughEvents) {
+		t.Fatalf("WatchFollowEvents() error = %v", err)
+	}
+
+	first, second := recorder.events[0], recorder.events[1]
+	if first.Checkpoint || first.EventType != string(models.EventFollowCreated) || first.FollowerId != alice || first.FollowingId != bob {
+		t.Errorf("first event = %+v, want alice -> bob", first)
+	}
+	if !second.Checkpoint || second.EventType != "" || second.ResumeToken == "" {
+		t.Errorf("second event = %+v, want a checkpoint carrying a resume token", second)
+	}
+}
+
+func TestWatchFollowEventsInvalidArgument(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *proto.WatchFollowEventsRequest
+	}{
+		{name: "未知的事件类型", req: &proto.WatchFollowEventsRequest{EventTypes: []string{"FollowUpdated"}}},
+		{name: "恢复令牌无效", req: &proto.WatchFollowEventsRequest{ResumeToken: "abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &eventRecorder{ctx: context.Background(), limit: 1}
+			err := newTestStores().grpcServer().WatchFollowEvents(tt.req, recorder)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
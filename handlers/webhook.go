@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"followservice/models"
+	"followservice/store"
+	"followservice/webhooks"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler 回调地址和死信的管理接口，仅管理员可用
+type WebhookHandler struct {
+	store store.WebhookStore
+}
+
+func NewWebhookHandler(webhookStore store.WebhookStore) *WebhookHandler {
+	return &WebhookHandler{
+		store: webhookStore,
+	}
+}
+
+// RegisterWebhookRequest 定义注册回调地址的请求参数，Secret为空时自动生成
+type RegisterWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url"`
+	Events []string `json:"events" binding:"required,min=1"`
+	Secret string   `json:"secret"`
+}
+
+// WebhookDetail 定义回调地址的详细信息，Secret仅在注册时返回
+type WebhookDetail struct {
+	ID        string                `json:"id"`
+	URL       string                `json:"url"`
+	Events    []models.WebhookEvent `json:"events"`
+	Secret    string                `json:"secret,omitempty"`
+	CreatedAt time.Time             `json:"createdAt"`
+}
+
+// RegisterWebhook 注册回调地址
+func (h *WebhookHandler) RegisterWebhook(c *gin.Context) {
+	var req RegisterWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	events := make([]models.WebhookEvent, 0, len(req.Events))
+	for _, e := range req.Events {
+		switch event := models.WebhookEvent(e); event {
+		case models.WebhookEventFollow, models.WebhookEventUnfollow, models.WebhookEventBlock, models.WebhookEventFollowRequest:
+			events = append(events, event)
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "不支持的事件类型: " + e})
+			return
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = webhooks.GenerateSecret(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+			return
+		}
+	}
+
+	webhook, err := h.store.CreateWebhook(c.Request.Context(), req.URL, secret, events)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	detail := newWebhookDetail(*webhook)
+	detail.Secret = webhook.Secret
+	c.JSON(http.StatusOK, detail)
+}
+
+// ListWebhooks 获取所有回调地址
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	webhookList, err := h.store.ListWebhooks(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	details := make([]WebhookDetail, 0, len(webhookList))
+	for _, webhook := range webhookList {
+		details = append(details, newWebhookDetail(webhook))
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": details})
+}
+
+// DeleteWebhook 删除回调地址
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	err := h.store.DeleteWebhook(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, store.ErrWebhookNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "回调地址不存在"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "已删除回调地址",
+	})
+}
+
+// GetDeadLettersRequest 定义获取死信列表的请求参数
+type GetDeadLettersRequest struct {
+	Limit  int `form:"limit,default=20"`
+	Offset int `form:"offset,default=0"`
+}
+
+// DeadLetterDetail 定义每条死信的详细信息
+type DeadLetterDetail struct {
+	ID        string              `json:"id"`
+	WebhookID string              `json:"webhookId"`
+	Event     models.WebhookEvent `json:"event"`
+	Payload   string              `json:"payload"`
+	Attempts  int                 `json:"attempts"`
+	LastError string              `json:"lastError"`
+	CreatedAt time.Time           `json:"createdAt"`
+	FailedAt  *time.Time          `json:"failedAt"`
+}
+
+// ListDeadLetters 按失败时间倒序获取投递失败的回调
+func (h *WebhookHandler) ListDeadLetters(c *gin.Context) {
+	var req GetDeadLettersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	// 验证参数
+	if req.Limit < 1 {
+		req.Limit = 20
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	deliveries, totalCount, err := h.store.ListDeadLetters(c.Request.Context(), store.ListOptions{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	details := make([]DeadLetterDetail, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		details = append(details, DeadLetterDetail{
+			ID:        delivery.ID,
+			WebhookID: delivery.WebhookID,
+			Event:     delivery.Event,
+			Payload:   delivery.Payload,
+			Attempts:  delivery.Attempts,
+			LastError: delivery.LastError,
+			CreatedAt: delivery.CreatedAt,
+			FailedAt:  delivery.FailedAt,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"deadLetters": details,
+		"totalCount":  totalCount,
+	})
+}
+
+// ReplayDeadLetter 将投递失败的回调重新加入投递队列
+func (h *WebhookHandler) ReplayDeadLetter(c *gin.Context) {
+	err := h.store.ReplayDeadLetter(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, store.ErrDeliveryNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "死信不存在"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "已重新加入投递队列",
+	})
+}
+
+func newWebhookDetail(webhook models.Webhook) WebhookDetail {
+	return WebhookDetail{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"followservice/store"
+	"log"
+	"time"
+)
+
+// AccountDeletionOptions 注销账号清理任务的参数
+type AccountDeletionOptions struct {
+	PollInterval time.Duration // 没有待执行的任务时两次轮询之间的间隔
+	BatchSize    int           // 每批结束的最大关注关系数，每批之后记录进度并续期租约
+	Lease        time.Duration // 领取任务后的租约，执行任务的实例崩溃后其他实例在租约结束后接管
+}
+
+// AccountDeletionStores 清理任务需要修改的存储
+type AccountDeletionStores struct {
+	Follows    store.FollowStore
+	Requests   store.FollowRequestStore
+	Settings   store.SettingsStore
+	Blocks     store.BlockStore
+	Mutes      store.MuteStore
+	Lists      store.ListStore
+	Directory  store.UserDirectory
+	Dismissals store.DismissalStore
+}
+
+// AccountDeletionJob 执行用户注销账号后的清理任务：分批结束该用户的关注和被关注关系（原因记为account_deleted），
+// 然后删除与该用户有关的拉黑、静音、待处理关注请求、设置、分组、用户资料副本和推荐反馈。
+// 每一步都可以重复执行，任务中断后由任意实例从剩余的数据继续
+type AccountDeletionJob struct {
+	deletions store.AccountDeletionStore
+	stores    AccountDeletionStores
+	opts      AccountDeletionOptions
+}
+
+func NewAccountDeletionJob(deletions store.AccountDeletionStore, stores AccountDeletionStores, opts AccountDeletionOptions) *AccountDeletionJob {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = 2 * time.Minute
+	}
+	return &AccountDeletionJob{
+		deletions: deletions,
+		stores:    stores,
+		opts:      opts,
+	}
+}
+
+// Run 持续执行清理任务，直到ctx结束
+func (j *AccountDeletionJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			claimed, err := j.RunOnce(ctx)
+			if err != nil {
+				log.Printf("注销账号清理失败: %v", err)
+				break
+			}
+			if !claimed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 领取并执行一个清理任务，没有待执行的任务时返回false。
+// 执行失败时任务保持未完成，租约结束后重试
+func (j *AccountDeletionJob) RunOnce(ctx context.Context) (bool, error) {
+	deletion, err := j.deletions.ClaimDeletion(ctx, j.opts.Lease)
+	if err != nil || deletion == nil {
+		return false, err
+	}
+
+	userID := deletion.UserID
+	if err := j.endFollows(ctx, userID, j.stores.Follows.ForEachFollowing, func(follow models.Follow) (string, string) {
+		return userID, follow.FollowingID
+	}); err != nil {
+		return true, err
+	}
+	if err := j.endFollows(ctx, userID, j.stores.Follows.ForEachFollower, func(follow models.Follow) (string, string) {
+		return follow.FollowerID, userID
+	}); err != nil {
+		return true, err
+	}
+
+	// 关注关系结束后再删除其他数据，关注关系对应的分组成员在这里一并删除
+	cleanups := []func(context.Context, string) error{
+		j.stores.Requests.CancelUserRequests,
+		j.stores.Blocks.DeleteUserBlocks,
+		j.stores.Mutes.DeleteUserMutes,
+		j.stores.Settings.DeleteSettings,
+		j.stores.Lists.DeleteOwnerLists,
+		j.stores.Lists.RemoveFromAllLists,
+		j.stores.Directory.DeleteProfile,
+		j.stores.Dismissals.DeleteUserDismissals,
+	}
+	for _, cleanup := range cleanups {
+		if err := cleanup(ctx, userID); err != nil {
+			return true, err
+		}
+	}
+
+	if err := j.deletions.CompleteDeletion(ctx, userID); err != nil {
+		return true, err
+	}
+	log.Printf("用户 %s 的注销账号清理完成", userID)
+	return true, nil
+}
+
+// endFollows 每次最多读取BatchSize个关注关系并逐个结束，直到没有剩余的关注关系。
+// 先读取再结束，避免在遍历游标的同时修改关注关系
+func (j *AccountDeletionJob) endFollows(ctx context.Context, userID string, forEach func(context.Context, string, store.ListOptions, func(models.Follow) error) error, pair func(models.Follow) (string, string)) error {
+	for {
+		follows := make([]models.Follow, 0, j.opts.BatchSize)
+		err := forEach(ctx, userID, store.ListOptions{Limit: j.opts.BatchSize}, func(follow models.Follow) error {
+			follows = append(follows, follow)
+			return nil
+		})
+		if err != nil || len(follows) == 0 {
+			return err
+		}
+
+		var removed int64
+		for _, follow := range follows {
+			followerID, followingID := pair(follow)
+			err := j.stores.Follows.Unfollow(ctx, followerID, followingID, models.UnfollowReasonAccountDeleted)
+			if errors.Is(err, store.ErrNotFollowing) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			removed++
+		}
+		if err := j.deletions.RecordProgress(ctx, userID, removed, j.opts.Lease); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"followservice/store"
+	"testing"
+	"time"
+)
+
+// deletionFixture 清理任务使用的内存存储
+type deletionFixture struct {
+	deletions  *store.MemoryAccountDeletionStore
+	follows    *store.MemoryFollowStore
+	requests   *store.MemoryFollowRequestStore
+	settings   *store.MemorySettingsStore
+	blocks     *store.MemoryBlockStore
+	mutes      *store.MemoryMuteStore
+	lists      *store.MemoryListStore
+	directory  *store.MemoryUserDirectory
+	dismissals *store.MemoryDismissalStore
+}
+
+func newDeletionFixture() *deletionFixture {
+	follows := store.NewMemoryFollowStore()
+	return &deletionFixture{
+		deletions:  store.NewMemoryAccountDeletionStore(),
+		follows:    follows,
+		requests:   store.NewMemoryFollowRequestStore(follows.Outbox()),
+		settings:   store.NewMemorySettingsStore(),
+		blocks:     store.NewMemoryBlockStore(follows.Outbox()),
+		mutes:      store.NewMemoryMuteStore(),
+		lists:      store.NewMemoryListStore(),
+		directory:  store.NewMemoryUserDirectory(),
+		dismissals: store.NewMemoryDismissalStore(),
+	}
+}
+
+func (f *deletionFixture) job(follows store.FollowStore, batchSize int, lease time.Duration) *AccountDeletionJob {
+	return NewAccountDeletionJob(f.deletions, AccountDeletionStores{
+		Follows:    follows,
+		Requests:   f.requests,
+		Settings:   f.settings,
+		Blocks:     f.blocks,
+		Mutes:      f.mutes,
+		Lists:      f.lists,
+		Directory:  f.directory,
+		Dismissals: f.dismissals,
+	}, AccountDeletionOptions{BatchSize: batchSize, Lease: lease})
+}
+
+func (f *deletionFixture) follow(t *testing.T, followerID, followingID string) {
+	t.Helper()
+	if _, err := f.follows.Follow(context.Background(), followerID, followingID); err != nil {
+		t.Fatalf("Follow(%s, %s) error = %v", followerID, followingID, err)
+	}
+}
+
+func (f *deletionFixture) counts(t *testing.T, userID string) store.FollowCounts {
+	t.Helper()
+	counts, err := f.follows.Counts(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("Counts() error = %v", err)
+	}
+	return *counts
+}
+
+// TestAccountDeletionJobCleansUp u关注a、b、c，d、e关注u，另有u参与的拉黑、静音、关注请求、设置、分组、资料副本和推荐反馈
+func TestAccountDeletionJobCleansUp(t *testing.T) {
+	ctx := context.Background()
+	f := newDeletionFixture()
+	for _, following := range []string{"a", "b", "c"} {
+		f.follow(t, "u", following)
+	}
+	f.follow(t, "d", "u")
+	f.follow(t, "e", "u")
+	f.follow(t, "d", "a")
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := f.blocks.Block(ctx, "u", "x")
+	must(err)
+	_, err = f.blocks.Block(ctx, "y", "u")
+	must(err)
+	_, err = f.mutes.Mute(ctx, "d", "u")
+	must(err)
+	_, err = f.requests.CreateRequest(ctx, "u", "p")
+	must(err)
+	_, err = f.requests.CreateRequest(ctx, "q", "u")
+	must(err)
+	must(f.settings.SaveSettings(ctx, &models.UserSettings{UserID: "u", RequiresApproval: true}))
+	ownList, err := f.lists.CreateList(ctx, "u", "friends")
+	must(err)
+	must(f.lists.AddMember(ctx, "u", ownList.ID, "a"))
+	otherList, err := f.lists.CreateList(ctx, "d", "close")
+	must(err)
+	must(f.lists.AddMember(ctx, "d", otherList.ID, "u"))
+	must(f.lists.AddMember(ctx, "d", otherList.ID, "a"))
+	must(f.directory.UpsertProfiles(ctx, []models.UserProfile{{UserID: "u", JoinedAt: time.Now()}}))
+	must(f.dismissals.Dismiss(ctx, "u", "z"))
+	must(f.dismissals.Dismiss(ctx, "z", "u"))
+	must(f.deletions.RequestDeletion(ctx, "u"))
+
+	// 每批2个，关注和粉丝都需要多批才能结束
+	job := f.job(f.follows, 2, time.Minute)
+	claimed, err := job.RunOnce(ctx)
+	if err != nil || !claimed {
+		t.Fatalf("RunOnce() = %v, %v, want claimed", claimed, err)
+	}
+
+	if counts := f.counts(t, "u"); counts != (store.FollowCounts{}) {
+		t.Errorf("counts of u = %+v, want zero", counts)
+	}
+	if counts := f.counts(t, "d"); counts.FollowingCount != 1 {
+		t.Errorf("d follows %d users, want only a", counts.FollowingCount)
+	}
+	history, err := f.follows.RelationshipHistory(ctx, "d", "u")
+	must(err)
+	if len(history) != 1 || history[0].Reason != models.UnfollowReasonAccountDeleted {
+		t.Errorf("history of d -> u = %+v, want one account_deleted entry", history)
+	}
+	if related, _ := f.blocks.RelatedUserIDs(ctx, "u"); len(related) != 0 {
+		t.Errorf("blocks of u = %v, want none", related)
+	}
+	if muted, _ := f.mutes.MutedUserIDs(ctx, "d"); len(muted) != 0 {
+		t.Errorf("d still mutes %v", muted)
+	}
+	if outgoing, _ := f.requests.ListOutgoing(ctx, "u", store.ListOptions{}); outgoing.TotalCount != 0 {
+		t.Errorf("u has %d outgoing requests, want 0", outgoing.TotalCount)
+	}
+	if incoming, _ := f.requests.ListIncoming(ctx, "u", store.ListOptions{}); incoming.TotalCount != 0 {
+		t.Errorf("u has %d incoming requests, want 0", incoming.TotalCount)
+	}
+	if settings, _ := f.settings.GetSettings(ctx, "u"); settings.RequiresApproval {
+		t.Error("settings of u were not deleted")
+	}
+	if lists, _ := f.lists.ListsByOwner(ctx, "u"); len(lists) != 0 {
+		t.Errorf("u still owns %d lists", len(lists))
+	}
+	if member, _ := f.lists.IsMember(ctx, otherList.ID, "u"); member {
+		t.Error("u is still in d's list")
+	}
+	if member, _ := f.lists.IsMember(ctx, otherList.ID, "a"); !member {
+		t.Error("cleanup removed another member from d's list")
+	}
+	if profiles, _ := f.directory.NewUsers(ctx, time.Time{}, store.DirectoryQuery{}); len(profiles) != 0 {
+		t.Errorf("directory = %+v, want empty", profiles)
+	}
+	if dismissed, _ := f.dismissals.DismissedUserIDs(ctx, "z"); len(dismissed) != 0 {
+		t.Errorf("z still dismissed %v", dismissed)
+	}
+
+	// 任务已完成，不会被再次领取
+	if claimed, err := job.RunOnce(ctx); claimed || err != nil {
+		t.Errorf("second RunOnce() = %v, %v, want nothing to claim", claimed, err)
+	}
+}
+
+// failingFollows 在Unfollow成功limit次后返回错误，模拟清理过程中实例崩溃或数据库故障
+type failingFollows struct {
+	store.FollowStore
+	limit int
+	calls int
+}
+
+func (f *failingFollows) Unfollow(ctx context.Context, followerID, followingID string, reason models.UnfollowReason) error {
+	f.calls++
+	if f.calls > f.limit {
+		return errors.New("connection reset")
+	}
+	return f.FollowStore.Unfollow(ctx, followerID, followingID, reason)
+}
+
+// TestAccountDeletionJobResumes 清理中断后任务保持未完成，租约结束后从剩余的关注关系继续
+func TestAccountDeletionJobResumes(t *testing.T) {
+	ctx := context.Background()
+	f := newDeletionFixture()
+	for _, following := range []string{"a", "b", "c", "d", "e"} {
+		f.follow(t, "u", following)
+	}
+	if err := f.deletions.RequestDeletion(ctx, "u"); err != nil {
+		t.Fatalf("RequestDeletion() error = %v", err)
+	}
+
+	lease := 10 * time.Millisecond
+	failing := &failingFollows{FollowStore: f.follows, limit: 3}
+	if _, err := f.job(failing, 2, lease).RunOnce(ctx); err == nil {
+		t.Fatal("RunOnce() error = nil, want the unfollow failure")
+	}
+	if counts := f.counts(t, "u"); counts.FollowingCount != 2 {
+		t.Fatalf("u follows %d users after the failure, want 2", counts.FollowingCount)
+	}
+
+	job := f.job(f.follows, 2, lease)
+	if claimed, _ := job.RunOnce(ctx); claimed {
+		t.Fatal("task was claimed again before its lease expired")
+	}
+	time.Sleep(lease)
+	if claimed, err := job.RunOnce(ctx); !claimed || err != nil {
+		t.Fatalf("RunOnce() after lease = %v, %v, want claimed", claimed, err)
+	}
+	if counts := f.counts(t, "u"); counts.FollowingCount != 0 {
+		t.Errorf("u still follows %d users", counts.FollowingCount)
+	}
+}
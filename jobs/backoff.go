@@ -0,0 +1,17 @@
+package jobs
+
+import (
+	"time"
+)
+
+// exponentialBackoff 返回第attempts次失败后的重试等待时间，从min开始每次翻倍，不超过max
+func exponentialBackoff(attempts int, min, max time.Duration) time.Duration {
+	backoff := min
+	for i := 0; i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return backoff
+}
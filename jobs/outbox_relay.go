@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"followservice/events"
+	"followservice/store"
+	"log"
+	"time"
+)
+
+// OutboxRelayOptions 发件箱中继任务的参数
+type OutboxRelayOptions struct {
+	PollInterval time.Duration // 两次轮询之间的间隔
+	BatchSize    int           // 每次领取的最大事件数
+	Lease        time.Duration // 领取后其他实例不可再领取的时长，应大于单批发布耗时
+	MinBackoff   time.Duration // 首次发布失败后的重试间隔，之后每次翻倍
+	MaxBackoff   time.Duration // 重试间隔上限
+}
+
+// OutboxRelay 轮询发件箱，将未发布的事件交给EventPublisher发布。
+// 事件在发布成功后才会被标记，因此进程崩溃或标记失败时同一事件可能被重复发布
+type OutboxRelay struct {
+	outbox    store.OutboxStore
+	publisher events.EventPublisher
+	opts      OutboxRelayOptions
+}
+
+func NewOutboxRelay(outbox store.OutboxStore, publisher events.EventPublisher, opts OutboxRelayOptions) *OutboxRelay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = 30 * time.Second
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = time.Second
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = 5 * time.Minute
+	}
+	return &OutboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		opts:      opts,
+	}
+}
+
+// Run 持续发布发件箱中的事件，直到ctx结束
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// 一批领满时说明还有积压，不等待直接领取下一批
+		for {
+			published, err := r.RunOnce(ctx)
+			if err != nil {
+				log.Printf("发件箱事件发布失败: %v", err)
+				break
+			}
+			if published < r.opts.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 领取并发布一批事件，返回领取的事件数
+func (r *OutboxRelay) RunOnce(ctx context.Context) (int, error) {
+	pending, err := r.outbox.ClaimPending(ctx, r.opts.BatchSize, r.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range pending {
+		if err := r.publisher.Publish(ctx, events.NewMessage(event)); err != nil {
+			nextAttemptAt := time.Now().Add(exponentialBackoff(event.Attempts, r.opts.MinBackoff, r.opts.MaxBackoff))
+			log.Printf("发布事件 %s 失败（第 %d 次），将于 %s 重试: %v", event.ID, event.Attempts+1, nextAttemptAt.Format(time.RFC3339), err)
+			if err := r.outbox.MarkFailed(ctx, event.ID, err.Error(), nextAttemptAt); err != nil {
+				return len(pending), err
+			}
+			continue
+		}
+		if err := r.outbox.MarkPublished(ctx, event.ID); err != nil {
+			return len(pending), err
+		}
+	}
+	return len(pending), nil
+}
@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"followservice/events"
+	"followservice/models"
+	"followservice/store"
+	"testing"
+	"time"
+)
+
+// followAndUnfollow 让a关注b后取消关注，在发件箱中写入两条事件
+func followAndUnfollow(t *testing.T) *store.MemoryFollowStore {
+	t.Helper()
+	ctx := context.Background()
+	follows := store.NewMemoryFollowStore()
+	if _, err := follows.Follow(ctx, "a", "b"); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+	if err := follows.Unfollow(ctx, "a", "b", models.UnfollowReasonUser); err != nil {
+		t.Fatalf("Unfollow() error = %v", err)
+	}
+	return follows
+}
+
+func TestOutboxRelayPublishesInOrder(t *testing.T) {
+	follows := followAndUnfollow(t)
+	publisher := events.NewMemoryPublisher()
+	relay := NewOutboxRelay(follows.Outbox(), publisher, OutboxRelayOptions{})
+
+	claimed, err := relay.RunOnce(context.Background())
+	if err != nil || claimed != 2 {
+		t.Fatalf("RunOnce() = %d, %v, want 2 events", claimed, err)
+	}
+	messages := publisher.Messages()
+	if len(messages) != 2 || messages[0].Type != models.EventFollowCreated || messages[1].Type != models.EventFollowDeleted {
+		t.Fatalf("messages = %+v, want FollowCreated then FollowDeleted", messages)
+	}
+	if messages[0].FollowerID != "a" || messages[0].FollowingID != "b" {
+		t.Errorf("message = %+v, want a -> b", messages[0])
+	}
+
+	// 已发布的事件不会再次发布
+	if claimed, err := relay.RunOnce(context.Background()); err != nil || claimed != 0 {
+		t.Errorf("second RunOnce() = %d, %v, want nothing left", claimed, err)
+	}
+}
+
+// TestOutboxRelayRetriesFailedEvents 发布失败的事件在退避时间之后重试，不影响同一批的其他事件
+func TestOutboxRelayRetriesFailedEvents(t *testing.T) {
+	follows := followAndUnfollow(t)
+	publisher := events.NewMemoryPublisher()
+	failing := true
+	publisher.Fail = func(message events.Message) error {
+		if failing && message.Type == models.EventFollowCreated {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}
+	relay := NewOutboxRelay(follows.Outbox(), publisher, OutboxRelayOptions{MinBackoff: 20 * time.Millisecond, MaxBackoff: time.Second})
+	ctx := context.Background()
+
+	if _, err := relay.RunOnce(ctx); err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if messages := publisher.Messages(); len(messages) != 1 || messages[0].Type != models.EventFollowDeleted {
+		t.Fatalf("messages = %+v, want only FollowDeleted", messages)
+	}
+
+	failing = false
+	if claimed, _ := relay.RunOnce(ctx); claimed != 0 {
+		t.Errorf("claimed %d events before the backoff elapsed", claimed)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if claimed, err := relay.RunOnce(ctx); err != nil || claimed != 1 {
+		t.Fatalf("RunOnce() after backoff = %d, %v, want the failed event", claimed, err)
+	}
+	if messages := publisher.Messages(); len(messages) != 2 || messages[1].Type != models.EventFollowCreated {
+		t.Errorf("messages = %+v, want FollowCreated retried", messages)
+	}
+}
+
+// TestOutboxRelayLease 已领取的事件在租约期内不会被其他中继实例领取
+func TestOutboxRelayLease(t *testing.T) {
+	follows := followAndUnfollow(t)
+	outbox := follows.Outbox()
+	ctx := context.Background()
+
+	claimed, err := outbox.ClaimPending(ctx, 1, time.Minute)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("ClaimPending() = %v, %v, want one event", claimed, err)
+	}
+	other, err := outbox.ClaimPending(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+	if len(other) != 1 || other[0].ID == claimed[0].ID {
+		t.Errorf("second claim = %v, want only the unclaimed event", other)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: time.Second},
+		{attempts: 1, want: 2 * time.Second},
+		{attempts: 3, want: 8 * time.Second},
+		{attempts: 10, want: time.Minute},
+		{attempts: 1000, want: time.Minute},
+	}
+	for _, tt := range tests {
+		if got := exponentialBackoff(tt.attempts, time.Second, time.Minute); got != tt.want {
+			t.Errorf("exponentialBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
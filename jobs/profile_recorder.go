@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+	"log"
+	"time"
+)
+
+const (
+	defaultRecorderBatchSize     = 100
+	defaultRecorderFlushInterval = 5 * time.Second
+)
+
+// ProfileRecorder 将从用户服务获取到的用户信息异步批量写入用户资料副本，供同城推荐和新用户推荐使用。
+// 写入队列已满时直接丢弃，不阻塞调用方，丢弃的用户会在下次被获取时重新写入
+type ProfileRecorder struct {
+	directory     store.UserDirectory
+	queue         chan models.UserProfile
+	batchSize     int
+	flushInterval time.Duration
+}
+
+func NewProfileRecorder(directory store.UserDirectory, batchSize int, flushInterval time.Duration) *ProfileRecorder {
+	if batchSize <= 0 {
+		batchSize = defaultRecorderBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultRecorderFlushInterval
+	}
+	return &ProfileRecorder{
+		directory:     directory,
+		queue:         make(chan models.UserProfile, batchSize*10),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+	}
+}
+
+// Observe 将用户信息加入写入队列，可作为Enricher的用户信息回调
+func (r *ProfileRecorder) Observe(user *proto.UserInfo) {
+	profile := models.UserProfile{
+		UserID:    user.Id,
+		City:      user.City,
+		Gender:    user.Gender,
+		IsOnline:  user.IsOnline,
+		UpdatedAt: time.Now(),
+	}
+	if user.LastOnlineTime != nil {
+		profile.LastOnlineAt = user.LastOnlineTime.AsTime()
+	}
+	if user.CreatedAt != nil {
+		profile.JoinedAt = user.CreatedAt.AsTime()
+	}
+
+	select {
+	case r.queue <- profile:
+	default:
+	}
+}
+
+// Run 每积累batchSize条或每隔flushInterval写入一次，直到ctx结束
+func (r *ProfileRecorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.UserProfile, 0, r.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.directory.UpsertProfiles(ctx, batch); err != nil {
+			log.Printf("写入用户资料失败: %v", err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case profile := <-r.queue:
+			batch = append(batch, profile)
+			if len(batch) >= r.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package jobs
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recordingDirectory 记录每次批量写入的用户数
+type recordingDirectory struct {
+	store.UserDirectory
+
+	mu      sync.Mutex
+	batches []int
+	written map[string]models.UserProfile
+}
+
+func (d *recordingDirectory) UpsertProfiles(ctx context.Context, profiles []models.UserProfile) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.written == nil {
+		d.written = make(map[string]models.UserProfile)
+	}
+	d.batches = append(d.batches, len(profiles))
+	for _, profile := range profiles {
+		d.written[profile.UserID] = profile
+	}
+	return nil
+}
+
+func (d *recordingDirectory) snapshot() ([]int, map[string]models.UserProfile) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	written := make(map[string]models.UserProfile, len(d.written))
+	for id, profile := range d.written {
+		written[id] = profile
+	}
+	return append([]int(nil), d.batches...), written
+}
+
+func TestProfileRecorderFlush(t *testing.T) {
+	tests := []struct {
+		name          string
+		batchSize     int
+		flushInterval time.Duration
+		users         int
+		wantBatches   []int
+	}{
+		{name: "攒满一批后写入", batchSize: 2, flushInterval: time.Hour, users: 4, wantBatches: []int{2, 2}},
+		{name: "不足一批时定时写入", batchSize: 100, flushInterval: 5 * time.Millisecond, users: 3, wantBatches: []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			directory := &recordingDirectory{}
+			recorder := NewProfileRecorder(directory, tt.batchSize, tt.flushInterval)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// 先入队再启动，保证同一批次内的用户一起写入
+			for i := 0; i < tt.users; i++ {
+				recorder.Observe(&proto.UserInfo{Id: string(rune('a' + i))})
+			}
+			go recorder.Run(ctx)
+
+			deadline := time.After(time.Second)
+			for {
+				batches, written := directory.snapshot()
+				if len(written) == tt.users {
+					if !reflect.DeepEqual(batches, tt.wantBatches) {
+						t.Errorf("batches = %v, want %v", batches, tt.wantBatches)
+					}
+					return
+				}
+				select {
+				case <-deadline:
+					t.Fatalf("wrote %d users in %v, want %d", len(written), batches, tt.users)
+				case <-time.After(time.Millisecond):
+				}
+			}
+		})
+	}
+}
+
+func TestProfileRecorderObserve(t *testing.T) {
+	directory := &recordingDirectory{}
+	recorder := NewProfileRecorder(directory, 1, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go recorder.Run(ctx)
+
+	lastOnline := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	joined := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder.Observe(&proto.UserInfo{
+		Id:             "u",
+		City:           "上海",
+		Gender:         "female",
+		IsOnline:       true,
+		LastOnlineTime: timestamppb.New(lastOnline),
+		CreatedAt:      timestamppb.New(joined),
+	})
+
+	deadline := time.After(time.Second)
+	for {
+		if _, written := directory.snapshot(); len(written) == 1 {
+			profile := written["u"]
+			if profile.City != "上海" || profile.Gender != "female" || !profile.IsOnline || !profile.LastOnlineAt.Equal(lastOnline) || !profile.JoinedAt.Equal(joined) || profile.UpdatedAt.IsZero() {
+				t.Errorf("profile = %+v", profile)
+			}
+			return
+		}
+		select {
+		case <-deadline:
+			t.Fatal("profile was not written")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+// TestProfileRecorderObserveFull 写入队列已满时丢弃，不阻塞调用方
+func TestProfileRecorderObserveFull(t *testing.T) {
+	recorder := NewProfileRecorder(&recordingDirectory{}, 1, time.Hour)
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < cap(recorder.queue)+5; i++ {
+			recorder.Observe(&proto.UserInfo{Id: "u"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Observe() blocked on a full queue")
+	}
+	if len(recorder.queue) != cap(recorder.queue) {
+		t.Errorf("queue length = %d, want %d", len(recorder.queue), cap(recorder.queue))
+	}
+}
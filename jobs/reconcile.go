@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"followservice/store"
+	"log"
+	"time"
+)
+
+// CounterReconcileJob 定期根据关注关系修正冗余的关注数和粉丝数
+type CounterReconcileJob struct {
+	reconciler store.CounterReconciler
+	interval   time.Duration
+}
+
+func NewCounterReconcileJob(reconciler store.CounterReconciler, interval time.Duration) *CounterReconcileJob {
+	return &CounterReconcileJob{
+		reconciler: reconciler,
+		interval:   interval,
+	}
+}
+
+// Run 启动后立即执行一次对账，之后每隔interval执行一次，直到ctx结束
+func (j *CounterReconcileJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 执行一次对账
+func (j *CounterReconcileJob) RunOnce(ctx context.Context) {
+	start := time.Now()
+	fixed, err := j.reconciler.ReconcileCounters(ctx)
+	if err != nil {
+		log.Printf("关注计数对账失败: %v", err)
+		return
+	}
+	log.Printf("关注计数对账完成，修正 %d 条计数，耗时 %v", fixed, time.Since(start))
+}
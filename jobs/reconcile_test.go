@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingReconciler 记录对账次数，err不为nil时每次返回该错误
+type countingReconciler struct {
+	runs atomic.Int64
+	err  error
+}
+
+func (r *countingReconciler) ReconcileCounters(ctx context.Context) (int64, error) {
+	r.runs.Add(1)
+	return 0, r.err
+}
+
+func TestCounterReconcileJobRun(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "对账成功"},
+		{name: "对账失败后继续执行", err: errors.New("transaction aborted")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := &countingReconciler{err: tt.err}
+			job := NewCounterReconcileJob(reconciler, 5*time.Millisecond)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				job.Run(ctx)
+				close(done)
+			}()
+
+			deadline := time.After(time.Second)
+			for reconciler.runs.Load() < 3 {
+				select {
+				case <-deadline:
+					t.Fatalf("reconciled %d times, want at least 3", reconciler.runs.Load())
+				case <-time.After(time.Millisecond):
+				}
+			}
+
+			cancel()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("Run did not return after ctx was cancelled")
+			}
+		})
+	}
+}
+
+// TestCounterReconcileJobRunsImmediately 启动后不等第一个间隔就执行对账
+func TestCounterReconcileJobRunsImmediately(t *testing.T) {
+	reconciler := &countingReconciler{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	NewCounterReconcileJob(reconciler, time.Hour).Run(ctx)
+	if runs := reconciler.runs.Load(); runs != 1 {
+		t.Errorf("runs = %d, want 1", runs)
+	}
+}
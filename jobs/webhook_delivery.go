@@ -0,0 +1,178 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"followservice/models"
+	"followservice/store"
+	"followservice/webhooks"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WebhookDeliveryOptions 回调投递任务的参数
+type WebhookDeliveryOptions struct {
+	PollInterval time.Duration // 两次轮询之间的间隔
+	BatchSize    int           // 每次领取的最大回调数
+	Concurrency  int           // 同时进行的最大HTTP请求数
+	Timeout      time.Duration // 单次HTTP请求的超时时间
+	MaxAttempts  int           // 最大投递次数，达到后移入死信
+	MinBackoff   time.Duration // 首次投递失败后的重试间隔，之后每次翻倍
+	MaxBackoff   time.Duration // 重试间隔上限
+}
+
+// WebhookDeliveryJob 投递待发送的回调，同一批回调在Concurrency的并发上限内并行发送，
+// 失败时按指数退避重试，重试次数耗尽后移入死信
+type WebhookDeliveryJob struct {
+	store  store.WebhookStore
+	client *http.Client
+	opts   WebhookDeliveryOptions
+}
+
+func NewWebhookDeliveryJob(webhookStore store.WebhookStore, opts WebhookDeliveryOptions) *WebhookDeliveryJob {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 50
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 8
+	}
+	if opts.Concurrency > opts.BatchSize {
+		opts.Concurrency = opts.BatchSize
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 8
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = 5 * time.Second
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = time.Hour
+	}
+	return &WebhookDeliveryJob{
+		store:  webhookStore,
+		client: &http.Client{Timeout: opts.Timeout},
+		opts:   opts,
+	}
+}
+
+// Run 持续投递回调，直到ctx结束
+func (j *WebhookDeliveryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			claimed, err := j.RunOnce(ctx)
+			if err != nil {
+				log.Printf("回调投递失败: %v", err)
+				break
+			}
+			if claimed < j.opts.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 领取并投递一批回调，返回领取的回调数。
+// 更新状态失败时仍会等待已开始的投递完成，返回第一个错误
+func (j *WebhookDeliveryJob) RunOnce(ctx context.Context) (int, error) {
+	// 租约覆盖整批请求的最长耗时，避免其他实例在投递过程中重复领取
+	rounds := (j.opts.BatchSize + j.opts.Concurrency - 1) / j.opts.Concurrency
+	lease := time.Duration(rounds)*j.opts.Timeout + time.Minute
+	deliveries, err := j.store.ClaimDeliveries(ctx, j.opts.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		sem      = make(chan struct{}, j.opts.Concurrency)
+	)
+	for _, delivery := range deliveries {
+		delivery := delivery
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := j.process(ctx, delivery); err != nil {
+				errOnce.Do(func() { firstErr = err })
+			}
+		}()
+	}
+	wg.Wait()
+	return len(deliveries), firstErr
+}
+
+// process 投递一个回调并更新其状态，只有更新状态失败时返回错误
+func (j *WebhookDeliveryJob) process(ctx context.Context, delivery models.WebhookDelivery) error {
+	webhook, err := j.store.GetWebhook(ctx, delivery.WebhookID)
+	if errors.Is(err, store.ErrWebhookNotFound) {
+		// 回调地址已被删除，丢弃该回调
+		return j.store.CompleteDelivery(ctx, delivery.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	deliverErr := j.deliver(ctx, webhook, delivery)
+	if deliverErr == nil {
+		return j.store.CompleteDelivery(ctx, delivery.ID)
+	}
+
+	if delivery.Attempts+1 >= j.opts.MaxAttempts {
+		log.Printf("回调 %s 投递 %d 次均失败，移入死信: %v", delivery.ID, delivery.Attempts+1, deliverErr)
+		return j.store.DeadLetter(ctx, delivery.ID, deliverErr.Error())
+	}
+	nextAttemptAt := time.Now().Add(exponentialBackoff(delivery.Attempts, j.opts.MinBackoff, j.opts.MaxBackoff))
+	return j.store.RetryDelivery(ctx, delivery.ID, deliverErr.Error(), nextAttemptAt)
+}
+
+// deliver 发送签名后的回调请求，响应状态码为2xx时视为成功
+func (j *WebhookDeliveryJob) deliver(ctx context.Context, webhook *models.Webhook, delivery models.WebhookDelivery) error {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooks.HeaderEvent, string(delivery.Event))
+	req.Header.Set(webhooks.HeaderDelivery, delivery.ID)
+	req.Header.Set(webhooks.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhooks.HeaderSignature, webhooks.Sign(webhook.Secret, timestamp, body))
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
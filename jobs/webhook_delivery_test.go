@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/store"
+	"followservice/webhooks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// enqueue 为webhook加入n个立即到期的回调
+func enqueue(t *testing.T, webhookStore store.WebhookStore, webhookID string, n int) {
+	t.Helper()
+	deliveries := make([]models.WebhookDelivery, 0, n)
+	for i := 0; i < n; i++ {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:        webhookID + "-" + strconv.Itoa(i),
+			WebhookID: webhookID,
+			Event:     models.WebhookEventFollow,
+			Payload:   `{"event":"follow"}`,
+		})
+	}
+	if err := webhookStore.EnqueueDeliveries(context.Background(), deliveries); err != nil {
+		t.Fatalf("EnqueueDeliveries() error = %v", err)
+	}
+}
+
+func TestWebhookDeliverySignsRequests(t *testing.T) {
+	ctx := context.Background()
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	webhookStore := store.NewMemoryWebhookStore()
+	webhook, _ := webhookStore.CreateWebhook(ctx, server.URL, "secret", []models.WebhookEvent{models.WebhookEventFollow})
+	enqueue(t, webhookStore, webhook.ID, 1)
+
+	if claimed, err := NewWebhookDeliveryJob(webhookStore, WebhookDeliveryOptions{}).RunOnce(ctx); err != nil || claimed != 1 {
+		t.Fatalf("RunOnce() = %d, %v, want 1 delivery", claimed, err)
+	}
+
+	req, body := <-received, <-bodies
+	timestamp, err := strconv.ParseInt(req.Header.Get(webhooks.HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("timestamp header: %v", err)
+	}
+	if !webhooks.Verify("secret", req.Header.Get(webhooks.HeaderSignature), timestamp, body) {
+		t.Error("signature does not verify against the delivered body")
+	}
+	if req.Header.Get(webhooks.HeaderEvent) != "follow" || req.Header.Get(webhooks.HeaderDelivery) != webhook.ID+"-0" {
+		t.Errorf("headers = %v", req.Header)
+	}
+	if again, _ := webhookStore.ClaimDeliveries(ctx, 10, time.Minute); len(again) != 0 {
+		t.Errorf("delivered webhook is still queued: %+v", again)
+	}
+}
+
+// TestWebhookDeliveryRetriesThenDeadLetters 非2xx响应按退避重试，达到最大次数后移入死信
+func TestWebhookDeliveryRetriesThenDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhookStore := store.NewMemoryWebhookStore()
+	webhook, _ := webhookStore.CreateWebhook(ctx, server.URL, "secret", []models.WebhookEvent{models.WebhookEventFollow})
+	enqueue(t, webhookStore, webhook.ID, 1)
+	job := NewWebhookDeliveryJob(webhookStore, WebhookDeliveryOptions{MaxAttempts: 2, MinBackoff: 10 * time.Millisecond, MaxBackoff: time.Second})
+
+	if _, err := job.RunOnce(ctx); err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if claimed, _ := job.RunOnce(ctx); claimed != 0 {
+		t.Errorf("claimed %d deliveries before the backoff elapsed", claimed)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if claimed, err := job.RunOnce(ctx); err != nil || claimed != 1 {
+		t.Fatalf("RunOnce() after backoff = %d, %v, want the retry", claimed, err)
+	}
+
+	deadLetters, total, err := webhookStore.ListDeadLetters(ctx, store.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListDeadLetters() error = %v", err)
+	}
+	if total != 1 || deadLetters[0].Attempts != 2 || deadLetters[0].LastError != "unexpected status 503" {
+		t.Errorf("dead letters = %+v, want one after 2 attempts", deadLetters)
+	}
+}
+
+// TestWebhookDeliveryConcurrency 同一批回调并行发送，且不超过并发上限
+func TestWebhookDeliveryConcurrency(t *testing.T) {
+	ctx := context.Background()
+	var (
+		mu             sync.Mutex
+		inFlight, peak int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	webhookStore := store.NewMemoryWebhookStore()
+	webhook, _ := webhookStore.CreateWebhook(ctx, server.URL, "secret", []models.WebhookEvent{models.WebhookEventFollow})
+	enqueue(t, webhookStore, webhook.ID, 12)
+
+	start := time.Now()
+	job := NewWebhookDeliveryJob(webhookStore, WebhookDeliveryOptions{BatchSize: 12, Concurrency: 4})
+	if claimed, err := job.RunOnce(ctx); err != nil || claimed != 12 {
+		t.Fatalf("RunOnce() = %d, %v, want 12 deliveries", claimed, err)
+	}
+	elapsed := time.Since(start)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if peak > 4 || peak < 2 {
+		t.Errorf("peak concurrent requests = %d, want between 2 and 4", peak)
+	}
+	if elapsed >= 12*20*time.Millisecond {
+		t.Errorf("RunOnce took %v, deliveries were sent one at a time", elapsed)
+	}
+}
+
+// TestWebhookDeliveryDeletedWebhook 回调地址已删除时丢弃待投递的回调
+func TestWebhookDeliveryDeletedWebhook(t *testing.T) {
+	ctx := context.Background()
+	webhookStore := store.NewMemoryWebhookStore()
+	enqueue(t, webhookStore, "deleted", 1)
+
+	if _, err := NewWebhookDeliveryJob(webhookStore, WebhookDeliveryOptions{}).RunOnce(ctx); err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if _, total, _ := webhookStore.ListDeadLetters(ctx, store.ListOptions{}); total != 0 {
+		t.Errorf("dead letters = %d, want the delivery dropped", total)
+	}
+	if again, _ := webhookStore.ClaimDeliveries(ctx, 10, time.Minute); len(again) != 0 {
+		t.Errorf("delivery is still queued: %+v", again)
+	}
+}
@@ -4,12 +4,22 @@ import (
 	"context"
 	"fmt"
 	"followservice/config"
+	"followservice/enrichment"
+	"followservice/events"
 	"followservice/handlers"
+	"followservice/jobs"
 	"followservice/middleware"
+	"followservice/migrations"
+	"followservice/models"
+	"followservice/store"
+	"followservice/suggestions"
+	"followservice/timeline"
+	"followservice/webhooks"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 	"log"
 	"net"
+	"os"
 	"time"
 
 	"followservice/proto"
@@ -44,7 +54,86 @@ func main() {
 	}
 	defer mongoClient.Disconnect(context.Background())
 
-	collection := mongoClient.Database(cfg.MongoDB.Database).Collection(cfg.MongoDB.Collection)
+	database := mongoClient.Database(cfg.MongoDB.Database)
+	migrationEnv := migrations.Env{
+		DB:                database,
+		FollowsCollection: cfg.MongoDB.Collection,
+	}
+
+	// migrate子命令只执行迁移，不启动服务
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), migrationEnv, cfg, os.Args[2:]); err != nil {
+			log.Fatalf("迁移失败: %v", err)
+		}
+		return
+	}
+
+	// 执行未完成的迁移并创建索引，(follower_id, following_id)唯一索引保证并发关注不会产生重复记录。
+	// 唯一索引依赖迁移先删除重复记录，因此只在所有迁移执行完毕后创建索引：
+	// 其他实例正在执行迁移时等待其完成，未开启自动迁移且有迁移未执行时跳过，由migrate up创建
+	migrationRunner := migrations.NewRunner(migrationEnv, migrations.All())
+	if cfg.Migrations.AutoMigrate {
+		if _, err := migrationRunner.UpOrWait(context.Background(), 5*time.Second); err != nil {
+			log.Fatalf("迁移失败: %v", err)
+		}
+	}
+	pending, err := migrationRunner.Pending(context.Background())
+	if err != nil {
+		log.Fatalf("无法获取迁移状态: %v", err)
+	}
+	if pending > 0 {
+		log.Printf("有 %d 个迁移尚未执行，请运行 migrate up，在此之前不会创建或更新索引", pending)
+	} else if err := migrations.EnsureIndexes(context.Background(), database, migrations.Indexes(migrationEnv, cfg.Idempotency.TTL, cfg.Outbox.Retention)); err != nil {
+		log.Fatalf("无法创建索引: %v", err)
+	}
+
+	followCollections := store.NewMongoFollowCollections(database, cfg.MongoDB.Collection)
+	followStore := store.NewMongoFollowStoreWithCollections(followCollections)
+	requestStore := store.NewMongoFollowRequestStore(database.Collection(store.RequestsCollection), followCollections.Outbox)
+	settingsStore := store.NewMongoSettingsStore(database.Collection(store.SettingsCollection))
+	blockStore := store.NewMongoBlockStore(database.Collection(store.BlocksCollection), followCollections.Outbox)
+	muteStore := store.NewMongoMuteStore(database.Collection(store.MutesCollection))
+	listStore := store.NewMongoListStore(database.Collection(store.ListsCollection), database.Collection(store.ListMembersCollection))
+	idempotencyStore := store.NewMongoIdempotencyStore(database.Collection(store.IdempotencyCollection))
+	deletionStore := store.NewMongoAccountDeletionStore(database.Collection(store.AccountDeletionsCollection))
+
+	// 开启变更前镜像，使WatchFollowEvents能够推送取消关注事件
+	if err := followStore.EnableChangeStreamPreImages(context.Background()); err != nil {
+		log.Printf("无法开启变更前镜像，WatchFollowEvents将不会推送取消关注事件: %v", err)
+	}
+
+	// 启动关注计数对账任务
+	if cfg.Counters.ReconcileInterval > 0 {
+		reconcileJob := jobs.NewCounterReconcileJob(followStore, cfg.Counters.ReconcileInterval)
+		go reconcileJob.Run(context.Background())
+	}
+
+	// 启动发件箱中继任务，先为事件创建回调，再将关注/取消关注事件发布到下游
+	webhookStore := store.NewMongoWebhookStore(database.Collection(store.WebhooksCollection), database.Collection(store.WebhookDeliveriesCollection), database.Collection(store.WebhookDeadLettersCollection))
+	publishers := []events.EventPublisher{webhooks.NewDispatcher(webhookStore)}
+	if publisher := newEventPublisher(cfg.Outbox); publisher != nil {
+		publishers = append(publishers, events.NewTypeFilter(publisher, models.EventFollowCreated, models.EventFollowDeleted))
+	}
+	relay := jobs.NewOutboxRelay(store.NewMongoOutboxStore(followCollections.Outbox), events.NewMultiPublisher(publishers...), jobs.OutboxRelayOptions{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+	})
+	go relay.Run(context.Background())
+
+	// 启动回调投递任务
+	deliveryJob := jobs.NewWebhookDeliveryJob(webhookStore, jobs.WebhookDeliveryOptions{
+		PollInterval: cfg.Webhooks.PollInterval,
+		BatchSize:    cfg.Webhooks.BatchSize,
+		Concurrency:  cfg.Webhooks.Concurrency,
+		Timeout:      cfg.Webhooks.Timeout,
+		MaxAttempts:  cfg.Webhooks.MaxAttempts,
+		MaxBackoff:   cfg.Webhooks.MaxBackoff,
+	})
+	go deliveryJob.Run(context.Background())
+
+	// 创建用户信息缓存，未配置时不启用
+	profileCache := enrichment.NewProfileCache(cfg.Enrichment.Cache, nil)
 
 	// 创建认证中间件
 	authMiddleware, err := middleware.NewAuthMiddleware(cfg.UserService.Host)
@@ -52,15 +141,74 @@ func main() {
 		log.Fatalf("无法创建认证中间件: %v", err)
 	}
 
-	// 创建处理器
-	followHandler, err := handlers.NewFollowHandler(
-		collection,
-		cfg.UserService.Host,
-		cfg.PostService.Host,
-	)
+	// 创建用户服务和帖子服务客户端
+	userConn, err := grpc.Dial(cfg.UserService.Host, grpc.WithInsecure())
 	if err != nil {
-		log.Fatalf("无法创建处理器: %v", err)
+		log.Fatalf("无法连接用户服务: %v", err)
 	}
+	postConn, err := grpc.Dial(cfg.PostService.Host, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("无法连接帖子服务: %v", err)
+	}
+	postClient := proto.NewPostServiceClient(postConn)
+	enricher := enrichment.NewEnricher(proto.NewUserServiceClient(userConn), postClient, cfg.Enrichment.Concurrency, profileCache)
+
+	// 将获取到的用户信息同步到用户资料副本，供同城推荐和新用户推荐使用
+	userDirectory := store.NewMongoUserDirectory(database.Collection(store.UserProfilesCollection))
+	profileRecorder := jobs.NewProfileRecorder(userDirectory, cfg.Suggestions.RecorderBatchSize, cfg.Suggestions.RecorderFlushInterval)
+	enricher.SetUserObserver(profileRecorder.Observe)
+	go profileRecorder.Run(context.Background())
+
+	// 创建推荐引擎，依次使用二度人脉、同城用户和新用户推荐
+	dismissalStore := store.NewMongoDismissalStore(database.Collection(store.DismissalsCollection))
+	suggester := suggestions.NewEngine(
+		blockStore,
+		requestStore,
+		dismissalStore,
+		suggestions.NewGraphSource(followStore),
+		suggestions.NewCitySource(userDirectory, followStore, enricher, cfg.Suggestions.OnlineTTL),
+		suggestions.NewNewUserSource(userDirectory, followStore, cfg.Suggestions.NewUserWindow),
+	)
+
+	// 启动注销账号清理任务
+	deletionJob := jobs.NewAccountDeletionJob(deletionStore, jobs.AccountDeletionStores{
+		Follows:    followStore,
+		Requests:   requestStore,
+		Settings:   settingsStore,
+		Blocks:     blockStore,
+		Mutes:      muteStore,
+		Lists:      listStore,
+		Directory:  userDirectory,
+		Dismissals: dismissalStore,
+	}, jobs.AccountDeletionOptions{
+		PollInterval: cfg.AccountDeletion.PollInterval,
+		BatchSize:    cfg.AccountDeletion.BatchSize,
+		Lease:        cfg.AccountDeletion.Lease,
+	})
+	go deletionJob.Run(context.Background())
+
+	// 创建关注信息流
+	feed := timeline.NewService(followStore, muteStore, postClient, timeline.Options{
+		MaxAuthors:     cfg.Timeline.MaxAuthors,
+		PerAuthorLimit: cfg.Timeline.PerAuthorLimit,
+		ScanLimit:      cfg.Timeline.ScanLimit,
+		Concurrency:    cfg.Timeline.Concurrency,
+	})
+
+	// 创建处理器
+	followHandler := handlers.NewFollowHandler(
+		followStore,
+		requestStore,
+		settingsStore,
+		blockStore,
+		muteStore,
+		listStore,
+		enricher,
+		suggester,
+		feed,
+	)
+	webhookHandler := handlers.NewWebhookHandler(webhookStore)
+	idempotency := middleware.NewIdempotency(idempotencyStore)
 
 	// 设置路由
 	r := gin.Default()
@@ -70,17 +218,55 @@ func main() {
 	{
 		follow := api.Group("/follow")
 		{
-			follow.POST("/user", authMiddleware.ValidateToken(), followHandler.FollowUser)
-			follow.DELETE("/user", authMiddleware.ValidateToken(), followHandler.UnfollowUser)
+			follow.POST("/user", authMiddleware.ValidateToken(), idempotency.Handle(), followHandler.FollowUser)
+			follow.DELETE("/user", authMiddleware.ValidateToken(), idempotency.Handle(), followHandler.UnfollowUser)
 			follow.GET("/my-follows", authMiddleware.ValidateToken(), followHandler.GetMyFollows)
 			follow.GET("/my-fans", authMiddleware.ValidateToken(), followHandler.GetMyFans)
 			follow.GET("/mutual", authMiddleware.ValidateToken(), followHandler.GetMutualFollows)
+			follow.GET("/feed", authMiddleware.ValidateToken(), followHandler.GetFeed)
+			follow.GET("/online", authMiddleware.ValidateToken(), followHandler.GetOnlineFollows)
+			follow.GET("/users/:id/follows", authMiddleware.ValidateToken(), followHandler.GetUserFollows)
+			follow.GET("/users/:id/fans", authMiddleware.ValidateToken(), followHandler.GetUserFans)
+			follow.GET("/users/:id/common-following", authMiddleware.ValidateToken(), followHandler.GetCommonFollowing)
+			follow.GET("/users/:id/followed-by", authMiddleware.ValidateToken(), followHandler.GetFollowedBy)
+			follow.GET("/suggestions", authMiddleware.ValidateToken(), followHandler.GetSuggestions)
+			follow.POST("/suggestions/dismiss", authMiddleware.ValidateToken(), followHandler.DismissSuggestion)
+			follow.GET("/stats", authMiddleware.ValidateToken(), followHandler.GetFollowStats)
+			follow.GET("/recent-unfollowers", authMiddleware.ValidateToken(), followHandler.GetRecentUnfollowers)
+			follow.POST("/lists", authMiddleware.ValidateToken(), followHandler.CreateList)
+			follow.GET("/lists", authMiddleware.ValidateToken(), followHandler.GetLists)
+			follow.PUT("/lists/:id", authMiddleware.ValidateToken(), followHandler.RenameList)
+			follow.DELETE("/lists/:id", authMiddleware.ValidateToken(), followHandler.DeleteList)
+			follow.GET("/lists/:id/members", authMiddleware.ValidateToken(), followHandler.GetListMembers)
+			follow.POST("/lists/:id/members", authMiddleware.ValidateToken(), followHandler.AddListMember)
+			follow.DELETE("/lists/:id/members", authMiddleware.ValidateToken(), followHandler.RemoveListMember)
+			follow.GET("/requests/incoming", authMiddleware.ValidateToken(), followHandler.GetIncomingFollowRequests)
+			follow.GET("/requests/outgoing", authMiddleware.ValidateToken(), followHandler.GetOutgoingFollowRequests)
+			follow.POST("/requests/:id/approve", authMiddleware.ValidateToken(), followHandler.ApproveFollowRequest)
+			follow.POST("/requests/:id/reject", authMiddleware.ValidateToken(), followHandler.RejectFollowRequest)
+			follow.DELETE("/requests/:id", authMiddleware.ValidateToken(), followHandler.CancelFollowRequest)
+			follow.POST("/block", authMiddleware.ValidateToken(), followHandler.BlockUser)
+			follow.DELETE("/block", authMiddleware.ValidateToken(), followHandler.UnblockUser)
+			follow.GET("/blocks", authMiddleware.ValidateToken(), followHandler.GetBlockedUsers)
+			follow.POST("/mute", authMiddleware.ValidateToken(), followHandler.MuteUser)
+			follow.DELETE("/mute", authMiddleware.ValidateToken(), followHandler.UnmuteUser)
+			follow.GET("/settings", authMiddleware.ValidateToken(), followHandler.GetFollowSettings)
+			follow.PUT("/settings", authMiddleware.ValidateToken(), followHandler.UpdateFollowSettings)
+		}
+
+		admin := api.Group("/admin", authMiddleware.ValidateToken(), middleware.RequireAdmin(cfg.Admin.UserIDs))
+		{
+			admin.POST("/webhooks", webhookHandler.RegisterWebhook)
+			admin.GET("/webhooks", webhookHandler.ListWebhooks)
+			admin.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+			admin.GET("/webhooks/dead-letters", webhookHandler.ListDeadLetters)
+			admin.POST("/webhooks/dead-letters/:id/replay", webhookHandler.ReplayDeadLetter)
 		}
 	}
 
 	// 创建gRPC服务器
 	grpcServer := grpc.NewServer()
-	followGrpcServer := handlers.NewFollowGrpcServer(collection)
+	followGrpcServer := handlers.NewFollowGrpcServer(followStore, requestStore, settingsStore, blockStore, muteStore, listStore, userDirectory, deletionStore, profileCache, enricher, suggester, feed)
 	proto.RegisterFollowServiceServer(grpcServer, followGrpcServer)
 
 	// 启动HTTP服务器
@@ -101,3 +287,22 @@ func main() {
 		log.Fatalf("gRPC服务器启动失败: %v", err)
 	}
 }
+
+// newEventPublisher 根据配置创建事件发布器，未配置时返回nil
+func newEventPublisher(cfg config.OutboxConfig) events.EventPublisher {
+	switch cfg.Publisher {
+	case "":
+		return nil
+	case "log":
+		return events.NewLogPublisher()
+	case "file":
+		publisher, err := events.NewFilePublisher(cfg.FilePath)
+		if err != nil {
+			log.Fatalf("无法打开事件文件: %v", err)
+		}
+		return publisher
+	default:
+		log.Fatalf("不支持的事件发布方式: %s", cfg.Publisher)
+		return nil
+	}
+}
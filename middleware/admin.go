@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin 只允许adminUserIDs中的用户访问，需在ValidateToken之后使用
+func RequireAdmin(adminUserIDs []string) gin.HandlerFunc {
+	admins := make(map[string]struct{}, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		admins[id] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		userID := c.GetString("userId")
+		if _, ok := admins[userID]; !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "无权访问"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"followservice/models"
+	"followservice/store"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader 客户端为可重试的写请求设置的请求头
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// maxIdempotencyKeyLength 幂等键的最大长度
+	maxIdempotencyKeyLength = 255
+	// idempotencyStaleAfter 超过该时间仍未完成的请求视为已放弃，允许使用同一个键重试
+	idempotencyStaleAfter = time.Minute
+)
+
+// Idempotency 对携带Idempotency-Key的请求，在有效期内使用同一个键重试时直接返回第一次请求的响应。
+// 5xx响应不会被保存，客户端可以用同一个键重试。需在ValidateToken之后使用，未携带该请求头时不做处理
+type Idempotency struct {
+	store store.IdempotencyStore
+}
+
+func NewIdempotency(idempotencyStore store.IdempotencyStore) *Idempotency {
+	return &Idempotency{
+		store: idempotencyStore,
+	}
+}
+
+// responseRecorder 在写出响应的同时保存响应体
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (m *Idempotency) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key过长"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// 同一个键只在同一用户内有效，并且只能用于相同的请求
+		id := c.GetString("userId") + ":" + key
+		fingerprint := requestFingerprint(c.Request, body)
+
+		existing, err := m.store.Reserve(c.Request.Context(), models.IdempotencyRecord{
+			ID:          id,
+			Fingerprint: fingerprint,
+			CreatedAt:   time.Now(),
+		}, idempotencyStaleAfter)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "服务器内部错误，请稍后再试"})
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.Fingerprint != fingerprint:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key已用于其他请求"})
+			case !existing.Completed:
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "相同的请求正在处理中，请稍后再试"})
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(existing.StatusCode, "application/json; charset=utf-8", existing.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			err = m.store.Release(c.Request.Context(), id)
+		} else {
+			err = m.store.Complete(c.Request.Context(), id, status, recorder.body.Bytes())
+		}
+		if err != nil {
+			log.Printf("保存幂等请求结果失败: %v", err)
+		}
+	}
+}
+
+// requestFingerprint 返回请求方法、路径、查询参数和请求体的摘要
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/store"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idempotentRouter 以userID的身份调用经过幂等中间件的handler，status为handler返回的状态码
+func idempotentRouter(idempotencyStore store.IdempotencyStore, calls *atomic.Int64, status *atomic.Int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userId", c.GetHeader("X-Test-User"))
+	}, NewIdempotency(idempotencyStore).Handle())
+	router.POST("/follow", func(c *gin.Context) {
+		n := calls.Add(1)
+		c.JSON(int(status.Load()), gin.H{"call": n})
+	})
+	return router
+}
+
+func send(router *gin.Engine, userID, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/follow", strings.NewReader(body))
+	req.Header.Set("X-Test-User", userID)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	var calls, status atomic.Int64
+	status.Store(http.StatusOK)
+	router := idempotentRouter(store.NewMemoryIdempotencyStore(time.Hour), &calls, &status)
+
+	first := send(router, "u1", "key-1", `{"targetUserId":"b"}`)
+	second := send(router, "u1", "key-1", `{"targetUserId":"b"}`)
+
+	if calls.Load() != 1 {
+		t.Errorf("handler called %d times, want 1", calls.Load())
+	}
+	if second.Code != first.Code || second.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %s, want %d %s", second.Code, second.Body.String(), first.Code, first.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("replayed response is missing the Idempotent-Replayed header")
+	}
+}
+
+func TestIdempotencyRejectsMismatchedOrOversizedKeys(t *testing.T) {
+	var calls, status atomic.Int64
+	status.Store(http.StatusOK)
+	router := idempotentRouter(store.NewMemoryIdempotencyStore(time.Hour), &calls, &status)
+	send(router, "u1", "key-1", `{"targetUserId":"b"}`)
+
+	tests := []struct {
+		name       string
+		key        string
+		body       string
+		wantStatus int
+	}{
+		{name: "同一个键用于不同请求", key: "key-1", body: `{"targetUserId":"c"}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "键过长", key: strings.Repeat("k", maxIdempotencyKeyLength+1), body: `{}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := send(router, "u1", tt.key, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+	if calls.Load() != 1 {
+		t.Errorf("handler called %d times, want 1", calls.Load())
+	}
+}
+
+// TestIdempotencyScopedPerUser 不同用户使用相同的键互不影响，未携带键的请求不做处理
+func TestIdempotencyScopedPerUser(t *testing.T) {
+	var calls, status atomic.Int64
+	status.Store(http.StatusOK)
+	router := idempotentRouter(store.NewMemoryIdempotencyStore(time.Hour), &calls, &status)
+
+	send(router, "u1", "key-1", `{}`)
+	send(router, "u2", "key-1", `{}`)
+	send(router, "u1", "", `{}`)
+	send(router, "u1", "", `{}`)
+	if calls.Load() != 4 {
+		t.Errorf("handler called %d times, want 4", calls.Load())
+	}
+}
+
+// TestIdempotencyServerErrorNotSaved 5xx响应不保存，使用同一个键重试会再次执行
+func TestIdempotencyServerErrorNotSaved(t *testing.T) {
+	var calls, status atomic.Int64
+	status.Store(http.StatusInternalServerError)
+	router := idempotentRouter(store.NewMemoryIdempotencyStore(time.Hour), &calls, &status)
+
+	send(router, "u1", "key-1", `{}`)
+	status.Store(http.StatusOK)
+	if w := send(router, "u1", "key-1", `{}`); w.Code != http.StatusOK {
+		t.Errorf("retry status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("handler called %d times, want 2", calls.Load())
+	}
+}
+
+func TestIdempotencyInProgress(t *testing.T) {
+	var calls, status atomic.Int64
+	status.Store(http.StatusOK)
+	idempotencyStore := store.NewMemoryIdempotencyStore(time.Hour)
+	router := idempotentRouter(idempotencyStore, &calls, &status)
+
+	// 模拟另一个实例正在处理同一请求
+	req := httptest.NewRequest(http.MethodPost, "/follow", nil)
+	_, err := idempotencyStore.Reserve(context.Background(), models.IdempotencyRecord{
+		ID:          "u1:key-1",
+		Fingerprint: requestFingerprint(req, []byte(`{}`)),
+		CreatedAt:   time.Now(),
+	}, idempotencyStaleAfter)
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+
+	if w := send(router, "u1", "key-1", `{}`); w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("handler called %d times while the request was in progress", calls.Load())
+	}
+}
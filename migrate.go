@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"followservice/config"
+	"followservice/migrations"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// runMigrate 执行migrate子命令：
+//
+//	migrate up          执行所有未执行的迁移并创建索引
+//	migrate down [n]    回滚最近执行的n个迁移，默认为1；其中有不可回滚的迁移时不做任何修改
+//	migrate status      查看所有迁移的执行状态以及是否可以回滚
+//
+// 大部分迁移删除或回填数据后无法还原，迁移基本只能向前执行，回滚前先用status确认
+func runMigrate(ctx context.Context, env migrations.Env, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("用法: migrate up|down [n]|status")
+	}
+
+	runner := migrations.NewRunner(env, migrations.All())
+	switch args[0] {
+	case "up":
+		executed, err := runner.Up(ctx)
+		for _, migration := range executed {
+			fmt.Printf("已执行 %d: %s\n", migration.Version, migration.Description)
+		}
+		if err != nil {
+			return err
+		}
+		if len(executed) == 0 {
+			fmt.Println("没有需要执行的迁移")
+		}
+		return migrations.EnsureIndexes(ctx, env.DB, migrations.Indexes(env, cfg.Idempotency.TTL, cfg.Outbox.Retention))
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("无效的回滚数量: %s", args[1])
+			}
+			steps = n
+		}
+		reverted, err := runner.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Printf("已回滚 %d: %s\n", migration.Version, migration.Description)
+		}
+		if errors.Is(err, migrations.ErrIrreversible) {
+			return fmt.Errorf("%w，迁移只能向前执行，需要撤销时请新增迁移或从备份恢复", err)
+		}
+		return err
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tAPPLIED AT\tREVERSIBLE\tDESCRIPTION")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Local().Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%t\t%s\n", status.Version, appliedAt, status.Reversible, status.Description)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("未知的migrate命令: %s", args[0])
+	}
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"followservice/store"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// removeDuplicateFollows 每对关注关系只保留最早创建的一条记录，删除记录后重新计算关注计数
+func removeDuplicateFollows(ctx context.Context, env Env) error {
+	follows := env.Follows()
+	cursor, err := follows.Aggregate(ctx, []bson.M{
+		{"$sort": bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{"$group": bson.M{
+			"_id":   bson.M{"follower_id": "$follower_id", "following_id": "$following_id"},
+			"ids":   bson.M{"$push": "$_id"},
+			"count": bson.M{"$sum": 1},
+		}},
+		{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var removed int64
+	for cursor.Next(ctx) {
+		var group struct {
+			IDs []string `bson:"ids"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return err
+		}
+
+		result, err := follows.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": group.IDs[1:]}})
+		if err != nil {
+			return err
+		}
+		removed += result.DeletedCount
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if removed == 0 {
+		return nil
+	}
+
+	log.Printf("已删除 %d 条重复的关注关系", removed)
+	followStore := store.NewMongoFollowStore(follows, env.DB.Collection(store.CountersCollection), env.DB.Collection(store.OutboxCollection))
+	_, err = followStore.ReconcileCounters(ctx)
+	return err
+}
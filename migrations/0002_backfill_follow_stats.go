@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/store"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// backfillBatchSize 回填时每次批量写入的汇总记录数
+const backfillBatchSize = 1000
+
+// backfillFollowStats 按关注时间统计现有关注关系，回填每日新增粉丝数。
+// 已取消的关注没有记录，因此回填的数据不包含流失粉丝。
+// 使用$max写入，服务运行期间执行时不会覆盖已经累加的更大的值
+func backfillFollowStats(ctx context.Context, env Env) error {
+	cursor, err := env.Follows().Aggregate(ctx, []bson.M{
+		{"$group": bson.M{
+			"_id": bson.M{
+				"user_id": "$following_id",
+				"day":     bson.M{"$dateTrunc": bson.M{"date": "$created_at", "unit": "day", "timezone": "UTC"}},
+			},
+			"gained": bson.M{"$sum": 1},
+		}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	stats := env.DB.Collection(store.FollowStatsCollection)
+	writes := make([]mongo.WriteModel, 0, backfillBatchSize)
+	var written int64
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		if _, err := stats.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+		written += int64(len(writes))
+		writes = writes[:0]
+		return nil
+	}
+
+	for cursor.Next(ctx) {
+		var group struct {
+			ID struct {
+				UserID string    `bson:"user_id"`
+				Day    time.Time `bson:"day"`
+			} `bson:"_id"`
+			Gained int64 `bson:"gained"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return err
+		}
+		day := models.StatsDay(group.ID.Day)
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": models.FollowStatsDayID(group.ID.UserID, day)}).
+			SetUpdate(bson.M{
+				"$max":         bson.M{"gained": group.Gained},
+				"$setOnInsert": bson.M{"user_id": group.ID.UserID, "day": day, "lost": 0},
+			}).
+			SetUpsert(true))
+		if len(writes) >= backfillBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	log.Printf("已回填 %d 条每日粉丝统计", written)
+	return nil
+}
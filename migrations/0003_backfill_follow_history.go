@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"followservice/store"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// backfillFollowHistory 为历史记录上线前建立的关注关系写入历史记录，已有记录的关注关系保持不变。
+// 上线前已取消的关注关系无法还原。
+// 该迁移不可回滚：回填的记录以关注关系ID为_id，与上线后关注时写入的记录无法区分，
+// 并且回填之后这些记录可能已被取消关注更新，删除它们会丢失真实的历史
+func backfillFollowHistory(ctx context.Context, env Env) error {
+	cursor, err := env.Follows().Aggregate(ctx, []bson.M{
+		{"$project": bson.M{
+			"_id":          1,
+			"follower_id":  1,
+			"following_id": 1,
+			"followed_at":  "$created_at",
+		}},
+		{"$merge": bson.M{
+			"into":           store.FollowHistoryCollection,
+			"on":             "_id",
+			"whenMatched":    "keepExisting",
+			"whenNotMatched": "insert",
+		}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+	"followservice/store"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// removeDuplicateBlocks 每对拉黑关系只保留最早创建的一条记录，为(blocker_id, blocked_id)唯一索引做准备
+func removeDuplicateBlocks(ctx context.Context, env Env) error {
+	blocks := env.DB.Collection(store.BlocksCollection)
+	ids, err := duplicateIDs(ctx, blocks, bson.M{}, bson.M{"blocker_id": "$blocker_id", "blocked_id": "$blocked_id"})
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	result, err := blocks.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return err
+	}
+	log.Printf("已删除 %d 条重复的拉黑关系", result.DeletedCount)
+	return nil
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+	"followservice/store"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// removeDuplicateMutes 每对静音关系只保留最早创建的一条记录，为(user_id, muted_id)唯一索引做准备
+func removeDuplicateMutes(ctx context.Context, env Env) error {
+	mutes := env.DB.Collection(store.MutesCollection)
+	ids, err := duplicateIDs(ctx, mutes, bson.M{}, bson.M{"user_id": "$user_id", "muted_id": "$muted_id"})
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	result, err := mutes.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return err
+	}
+	log.Printf("已删除 %d 条重复的静音关系", result.DeletedCount)
+	return nil
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/store"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// cancelDuplicatePendingRequests 同一对用户之间只保留最早的待处理请求，其余标记为已取消，
+// 为(requester_id, target_id)待处理请求唯一索引做准备
+func cancelDuplicatePendingRequests(ctx context.Context, env Env) error {
+	requests := env.DB.Collection(store.RequestsCollection)
+	ids, err := duplicateIDs(ctx, requests,
+		bson.M{"status": models.FollowRequestPending},
+		bson.M{"requester_id": "$requester_id", "target_id": "$target_id"})
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	result, err := requests.UpdateMany(ctx, bson.M{
+		"_id":    bson.M{"$in": ids},
+		"status": models.FollowRequestPending,
+	}, bson.M{
+		"$set": bson.M{
+			"status":     models.FollowRequestCancelled,
+			"updated_at": time.Now(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("已取消 %d 条重复的待处理关注请求", result.ModifiedCount)
+	return nil
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"followservice/store"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// markLatestUnfollows 为每对用户最近一次结束的关注关系写入latest，结束后又重新关注的同时写入refollowed。
+// 之后的关注和取消关注由服务维护这两个字段，最近取消关注的粉丝只读取latest记录
+func markLatestUnfollows(ctx context.Context, env Env) error {
+	cursor, err := env.DB.Collection(store.FollowHistoryCollection).Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"unfollowed_at": bson.M{"$exists": true}}},
+		{"$sort": bson.D{
+			{Key: "follower_id", Value: 1},
+			{Key: "following_id", Value: 1},
+			{Key: "unfollowed_at", Value: -1},
+			{Key: "_id", Value: -1},
+		}},
+		{"$group": bson.M{
+			"_id":        bson.M{"follower_id": "$follower_id", "following_id": "$following_id"},
+			"history_id": bson.M{"$first": "$_id"},
+		}},
+		// 这对用户当前仍存在关注关系说明结束之后又重新关注了
+		{"$lookup": bson.M{
+			"from": env.FollowsCollection,
+			"let":  bson.M{"follower_id": "$_id.follower_id", "following_id": "$_id.following_id"},
+			"pipeline": []bson.M{
+				{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$follower_id", "$$follower_id"}},
+					bson.M{"$eq": bson.A{"$following_id", "$$following_id"}},
+				}}}},
+				{"$project": bson.M{"_id": 1}},
+			},
+			"as": "follows",
+		}},
+		{"$project": bson.M{
+			"_id":        "$history_id",
+			"latest":     bson.M{"$literal": true},
+			"refollowed": bson.M{"$gt": bson.A{bson.M{"$size": "$follows"}, 0}},
+		}},
+		{"$merge": bson.M{
+			"into":           store.FollowHistoryCollection,
+			"on":             "_id",
+			"whenMatched":    "merge",
+			"whenNotMatched": "discard",
+		}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+// unmarkLatestUnfollows 删除latest和refollowed，旧版本的服务不读取这两个字段
+func unmarkLatestUnfollows(ctx context.Context, env Env) error {
+	_, err := env.DB.Collection(store.FollowHistoryCollection).UpdateMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"latest": bson.M{"$exists": true}},
+			{"refollowed": bson.M{"$exists": true}},
+		},
+	}, bson.M{"$unset": bson.M{"latest": "", "refollowed": ""}})
+	return err
+}
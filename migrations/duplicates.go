@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// duplicateIDs 将collection中符合filter的记录按groupKey分组，返回每组中除最早创建的一条以外的记录ID
+func duplicateIDs(ctx context.Context, collection *mongo.Collection, filter bson.M, groupKey bson.M) ([]string, error) {
+	cursor, err := collection.Aggregate(ctx, []bson.M{
+		{"$match": filter},
+		{"$sort": bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{"$group": bson.M{
+			"_id":   groupKey,
+			"ids":   bson.M{"$push": "$_id"},
+			"count": bson.M{"$sum": 1},
+		}},
+		{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := make([]string, 0)
+	for cursor.Next(ctx) {
+		var group struct {
+			IDs []string `bson:"ids"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return nil, err
+		}
+		ids = append(ids, group.IDs[1:]...)
+	}
+	return ids, cursor.Err()
+}
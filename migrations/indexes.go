@@ -0,0 +1,197 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"followservice/store"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 索引定义与现有索引冲突时MongoDB返回的错误码
+const (
+	indexOptionsConflict  = 85
+	indexKeySpecsConflict = 86
+)
+
+// IndexSpec 一个集合上的索引定义，每个索引都必须指定名称
+type IndexSpec struct {
+	Collection string
+	Models     []mongo.IndexModel
+}
+
+// Indexes 返回服务依赖的所有索引，idempotencyTTL为幂等键的保留时间，outboxRetention为已发布事件的保留时间
+func Indexes(env Env, idempotencyTTL, outboxRetention time.Duration) []IndexSpec {
+	if idempotencyTTL <= 0 {
+		idempotencyTTL = store.DefaultIdempotencyTTL
+	}
+	if outboxRetention <= 0 {
+		outboxRetention = store.DefaultOutboxRetention
+	}
+
+	return []IndexSpec{
+		{
+			Collection: env.FollowsCollection,
+			Models: []mongo.IndexModel{
+				// 保证同一对关注关系只有一条记录，依赖迁移1删除已有的重复记录
+				index("follower_id_following_id_unique", bson.D{{Key: "follower_id", Value: 1}, {Key: "following_id", Value: 1}}, options.Index().SetUnique(true)),
+				// 关注列表和粉丝列表按关注时间倒序分页
+				index("follower_id_created_at", bson.D{{Key: "follower_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}, nil),
+				index("following_id_created_at", bson.D{{Key: "following_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}, nil),
+			},
+		},
+		{
+			Collection: store.OutboxCollection,
+			Models: []mongo.IndexModel{
+				// 中继按发生时间顺序领取到期的未发布事件：等值条件在前，排序字段居中，范围条件在后，
+				// 使排序可以直接使用索引顺序，不需要在内存中排序
+				index("published_occurred_at_next_attempt_at", bson.D{{Key: "published", Value: 1}, {Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}, {Key: "next_attempt_at", Value: 1}}, nil),
+				// 只有已发布的事件有published_at，未发布的事件不会被删除
+				index("published_at_ttl", bson.D{{Key: "published_at", Value: 1}}, options.Index().SetExpireAfterSeconds(int32(outboxRetention.Seconds()))),
+			},
+		},
+		{
+			Collection: store.RequestsCollection,
+			Models: []mongo.IndexModel{
+				index("target_id_status_created_at", bson.D{{Key: "target_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}, nil),
+				index("requester_id_status_created_at", bson.D{{Key: "requester_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}, nil),
+				// 同一对用户之间只允许存在一个待处理请求，依赖迁移6取消已有的重复请求
+				index("requester_id_target_id_pending", bson.D{{Key: "requester_id", Value: 1}, {Key: "target_id", Value: 1}},
+					options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"status": models.FollowRequestPending})),
+			},
+		},
+		{
+			Collection: store.BlocksCollection,
+			Models: []mongo.IndexModel{
+				// 保证同一对拉黑关系只有一条记录，依赖迁移4删除已有的重复记录
+				index("blocker_id_blocked_id", bson.D{{Key: "blocker_id", Value: 1}, {Key: "blocked_id", Value: 1}}, options.Index().SetUnique(true)),
+				index("blocked_id", bson.D{{Key: "blocked_id", Value: 1}}, nil),
+			},
+		},
+		{
+			Collection: store.MutesCollection,
+			Models: []mongo.IndexModel{
+				// 保证同一对静音关系只有一条记录，依赖迁移5删除已有的重复记录
+				index("user_id_muted_id", bson.D{{Key: "user_id", Value: 1}, {Key: "muted_id", Value: 1}}, options.Index().SetUnique(true)),
+				index("muted_id", bson.D{{Key: "muted_id", Value: 1}}, nil),
+			},
+		},
+		{
+			Collection: store.IdempotencyCollection,
+			Models: []mongo.IndexModel{
+				index("created_at_ttl", bson.D{{Key: "created_at", Value: 1}}, options.Index().SetExpireAfterSeconds(int32(idempotencyTTL.Seconds()))),
+			},
+		},
+		{
+			Collection: store.WebhooksCollection,
+			Models: []mongo.IndexModel{
+				index("events", bson.D{{Key: "events", Value: 1}}, nil),
+			},
+		},
+		{
+			Collection: store.WebhookDeliveriesCollection,
+			Models: []mongo.IndexModel{
+				index("next_attempt_at", bson.D{{Key: "next_attempt_at", Value: 1}}, nil),
+				index("webhook_id", bson.D{{Key: "webhook_id", Value: 1}}, nil),
+			},
+		},
+		{
+			Collection: store.WebhookDeadLettersCollection,
+			Models: []mongo.IndexModel{
+				index("failed_at", bson.D{{Key: "failed_at", Value: -1}, {Key: "_id", Value: -1}}, nil),
+			},
+		},
+		{
+			Collection: store.UserProfilesCollection,
+			Models: []mongo.IndexModel{
+				// 同城推荐按城市筛选，在线状态需结合updated_at判断，排序在查询时完成
+				index("city_is_online_last_online_at", bson.D{{Key: "city", Value: 1}, {Key: "is_online", Value: -1}, {Key: "last_online_at", Value: -1}}, nil),
+				index("joined_at", bson.D{{Key: "joined_at", Value: -1}}, nil),
+			},
+		},
+		{
+			Collection: store.FollowStatsCollection,
+			Models: []mongo.IndexModel{
+				index("user_id_day", bson.D{{Key: "user_id", Value: 1}, {Key: "day", Value: 1}}, nil),
+			},
+		},
+		{
+			Collection: store.FollowHistoryCollection,
+			Models: []mongo.IndexModel{
+				// 最近取消关注的粉丝，只索引每对用户最近一次结束的关注关系，依赖迁移7标记已有的记录
+				index("following_id_unfollowed_at", bson.D{{Key: "following_id", Value: 1}, {Key: "unfollowed_at", Value: -1}, {Key: "_id", Value: -1}},
+					options.Index().SetPartialFilterExpression(bson.M{"latest": true})),
+				index("follower_id_following_id_followed_at", bson.D{{Key: "follower_id", Value: 1}, {Key: "following_id", Value: 1}, {Key: "followed_at", Value: -1}}, nil),
+			},
+		},
+		{
+			Collection: store.ListsCollection,
+			Models: []mongo.IndexModel{
+				index("owner_id_name_unique", bson.D{{Key: "owner_id", Value: 1}, {Key: "name", Value: 1}}, options.Index().SetUnique(true)),
+			},
+		},
+		{
+			Collection: store.ListMembersCollection,
+			Models: []mongo.IndexModel{
+				index("list_id_member_id_unique", bson.D{{Key: "list_id", Value: 1}, {Key: "member_id", Value: 1}}, options.Index().SetUnique(true)),
+				index("list_id_created_at", bson.D{{Key: "list_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}, nil),
+				index("owner_id_member_id", bson.D{{Key: "owner_id", Value: 1}, {Key: "member_id", Value: 1}}, nil),
+				// 用户注销账号时将其移出所有分组
+				index("member_id", bson.D{{Key: "member_id", Value: 1}}, nil),
+			},
+		},
+		{
+			Collection: store.DismissalsCollection,
+			Models: []mongo.IndexModel{
+				index("user_id_dismissed_id_unique", bson.D{{Key: "user_id", Value: 1}, {Key: "dismissed_id", Value: 1}}, options.Index().SetUnique(true)),
+				index("dismissed_id", bson.D{{Key: "dismissed_id", Value: 1}}, nil),
+			},
+		},
+		{
+			Collection: store.AccountDeletionsCollection,
+			Models: []mongo.IndexModel{
+				// 已完成的任务没有next_attempt_at
+				index("next_attempt_at", bson.D{{Key: "next_attempt_at", Value: 1}}, nil),
+			},
+		},
+	}
+}
+
+func index(name string, keys bson.D, opts *options.IndexOptions) mongo.IndexModel {
+	if opts == nil {
+		opts = options.Index()
+	}
+	return mongo.IndexModel{
+		Keys:    keys,
+		Options: opts.SetName(name),
+	}
+}
+
+// EnsureIndexes 创建specs中的所有索引。同名索引的定义发生变化时（例如TTL调整）删除后重建
+func EnsureIndexes(ctx context.Context, db *mongo.Database, specs []IndexSpec) error {
+	for _, spec := range specs {
+		indexes := db.Collection(spec.Collection).Indexes()
+		for _, model := range spec.Models {
+			_, err := indexes.CreateOne(ctx, model)
+			if isIndexConflict(err) {
+				if _, err := indexes.DropOne(ctx, *model.Options.Name); err != nil {
+					return err
+				}
+				_, err = indexes.CreateOne(ctx, model)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isIndexConflict(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && (serverErr.HasErrorCode(indexOptionsConflict) || serverErr.HasErrorCode(indexKeySpecsConflict))
+}
@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"context"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Env 迁移运行时使用的数据库，关注关系集合名由配置文件指定
+type Env struct {
+	DB                *mongo.Database
+	FollowsCollection string
+}
+
+// Follows 返回关注关系集合
+func (e Env) Follows() *mongo.Collection {
+	return e.DB.Collection(e.FollowsCollection)
+}
+
+// Migration 一次有版本号的数据迁移，按Version从小到大依次执行
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, env Env) error
+	// Down 撤销Up所做的修改，为nil表示该迁移不可回滚
+	Down func(ctx context.Context, env Env) error
+}
+
+// registered 所有迁移，新增迁移时在此追加，已发布的迁移不应再修改
+var registered = []Migration{
+	{
+		Version:     1,
+		Description: "删除重复的关注关系，为(follower_id, following_id)唯一索引做准备",
+		Up:          removeDuplicateFollows,
+	},
+	{
+		// 回填的值与服务写入的计数合并在同一条汇总记录中，无法只撤销回填的部分，不可回滚
+		Version:     2,
+		Description: "根据现有关注关系回填每日新增粉丝统计",
+		Up:          backfillFollowStats,
+	},
+	{
+		// 回填的记录与之后写入的记录无法区分，不可回滚
+		Version:     3,
+		Description: "为现有关注关系写入关注关系历史",
+		Up:          backfillFollowHistory,
+	},
+	{
+		Version:     4,
+		Description: "删除重复的拉黑关系，为(blocker_id, blocked_id)唯一索引做准备",
+		Up:          removeDuplicateBlocks,
+	},
+	{
+		Version:     5,
+		Description: "删除重复的静音关系，为(user_id, muted_id)唯一索引做准备",
+		Up:          removeDuplicateMutes,
+	},
+	{
+		Version:     6,
+		Description: "取消重复的待处理关注请求，为(requester_id, target_id)待处理请求唯一索引做准备",
+		Up:          cancelDuplicatePendingRequests,
+	},
+	{
+		Version:     7,
+		Description: "标记每对用户最近一次结束的关注关系，最近取消关注的粉丝按该标记分页",
+		Up:          markLatestUnfollows,
+		Down:        unmarkLatestUnfollows,
+	},
+}
+
+// All 按版本号从小到大返回所有迁移
+func All() []Migration {
+	migrations := make([]Migration, len(registered))
+	copy(migrations, registered)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			panic("duplicate migration version")
+		}
+	}
+	return migrations
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import "testing"
+
+func TestAllSortedByVersion(t *testing.T) {
+	migrations := All()
+	if len(migrations) != len(registered) {
+		t.Fatalf("All() returned %d migrations, want %d", len(migrations), len(registered))
+	}
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("migration %d has version %d, want consecutive versions starting at 1", i, migration.Version)
+		}
+		if migration.Up == nil || migration.Description == "" {
+			t.Errorf("migration %d is missing Up or Description", migration.Version)
+		}
+	}
+}
+
+// TestIrreversibleMigrations README中列出的不可回滚迁移与Down是否为nil保持一致
+func TestIrreversibleMigrations(t *testing.T) {
+	irreversible := map[int]bool{1: true, 2: true, 3: true, 4: true, 5: true, 6: true}
+	for _, migration := range All() {
+		if got := migration.Down == nil; got != irreversible[migration.Version] {
+			t.Errorf("migration %d irreversible = %v, want %v", migration.Version, got, irreversible[migration.Version])
+		}
+	}
+}
+
+func TestAllPanicsOnDuplicateVersion(t *testing.T) {
+	saved := registered
+	defer func() { registered = saved }()
+	registered = append([]Migration{}, saved...)
+	registered = append(registered, Migration{Version: 1, Description: "duplicate"})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("All() did not panic on a duplicate version")
+		}
+	}()
+	All()
+}
+
+// TestIndexesNamed EnsureIndexes依赖索引名称在定义变化时删除重建，每个索引都必须有唯一的名称
+func TestIndexesNamed(t *testing.T) {
+	env := Env{FollowsCollection: "follows"}
+	for _, spec := range Indexes(env, 0, 0) {
+		seen := make(map[string]bool)
+		for _, model := range spec.Models {
+			if model.Options == nil || model.Options.Name == nil || *model.Options.Name == "" {
+				t.Errorf("%s has an unnamed index %v", spec.Collection, model.Keys)
+				continue
+			}
+			name := *model.Options.Name
+			if seen[name] {
+				t.Errorf("%s has duplicate index name %s", spec.Collection, name)
+			}
+			seen[name] = true
+		}
+	}
+}
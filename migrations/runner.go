@@ -0,0 +1,273 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SchemaMigrationsCollection 记录已执行迁移的集合
+const SchemaMigrationsCollection = "schema_migrations"
+
+const (
+	// lockID 迁移锁文档的_id，与以版本号为_id的迁移记录共用同一个集合
+	lockID = "lock"
+	// lockTTL 迁移锁的有效期，持有锁的进程崩溃后其他进程最多等待该时间
+	lockTTL = 2 * time.Minute
+	// lockRenewInterval 执行迁移期间续期迁移锁的间隔，需远小于lockTTL
+	lockRenewInterval = lockTTL / 4
+)
+
+var (
+	// ErrLocked 表示其他进程正在执行迁移
+	ErrLocked = errors.New("migrations are locked by another process")
+	// ErrIrreversible 表示迁移不可回滚
+	ErrIrreversible = errors.New("migration is irreversible")
+	// ErrLockLost 表示执行迁移期间迁移锁续期失败，锁可能已被其他进程接管
+	ErrLockLost = errors.New("migration lock lost")
+)
+
+// record 已执行的迁移
+type record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Status 一个迁移的执行状态，AppliedAt为nil表示尚未执行
+type Status struct {
+	Version     int
+	Description string
+	AppliedAt   *time.Time
+	Reversible  bool
+}
+
+// Runner 执行迁移并记录到schema_migrations集合
+type Runner struct {
+	env        Env
+	migrations []Migration
+	records    *mongo.Collection
+}
+
+func NewRunner(env Env, migrations []Migration) *Runner {
+	return &Runner{
+		env:        env,
+		migrations: migrations,
+		records:    env.DB.Collection(SchemaMigrationsCollection),
+	}
+}
+
+// Up 按版本号依次执行所有未执行的迁移，返回本次执行的迁移
+func (r *Runner) Up(ctx context.Context) ([]Migration, error) {
+	var executed []Migration
+	err := r.withLock(ctx, func(ctx context.Context) error {
+		applied, err := r.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range r.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := migration.Up(ctx, r.env); err != nil {
+				return fmt.Errorf("migration %d: %w", migration.Version, err)
+			}
+			if _, err := r.records.InsertOne(ctx, record{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now(),
+			}); err != nil {
+				return err
+			}
+			executed = append(executed, migration)
+		}
+		return nil
+	})
+	return executed, err
+}
+
+// UpOrWait 与Up相同，但其他进程持有迁移锁时每隔interval重试，直到所有迁移执行完毕或ctx结束。
+// 持有锁的进程崩溃时，锁在lockTTL后过期，由当前进程接着执行
+func (r *Runner) UpOrWait(ctx context.Context, interval time.Duration) ([]Migration, error) {
+	for {
+		executed, err := r.Up(ctx)
+		if !errors.Is(err, ErrLocked) {
+			return executed, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Down 从最新的迁移开始回滚最多steps个已执行的迁移，返回本次回滚的迁移。
+// 其中有不可回滚的迁移时不回滚任何迁移，直接返回ErrIrreversible
+func (r *Runner) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := r.withLock(ctx, func(ctx context.Context) error {
+		applied, err := r.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		var targets []Migration
+		for i := len(r.migrations) - 1; i >= 0 && len(targets) < steps; i-- {
+			migration := r.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d: %w", migration.Version, ErrIrreversible)
+			}
+			targets = append(targets, migration)
+		}
+
+		for _, migration := range targets {
+			if err := migration.Down(ctx, r.env); err != nil {
+				return fmt.Errorf("migration %d: %w", migration.Version, err)
+			}
+			if _, err := r.records.DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status 按版本号返回所有迁移的执行状态
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, migration := range r.migrations {
+		status := Status{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Reversible:  migration.Down != nil,
+		}
+		if rec, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &rec.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending 返回尚未执行的迁移数量
+func (r *Runner) Pending(ctx context.Context) (int, error) {
+	statuses, err := r.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// applied 返回已执行的迁移记录，以版本号为键
+func (r *Runner) applied(ctx context.Context) (map[int]record, error) {
+	cursor, err := r.records.Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]record, len(records))
+	for _, rec := range records {
+		applied[rec.Version] = rec
+	}
+	return applied, nil
+}
+
+// withLock 持有迁移锁执行fn，避免多个实例同时启动时重复执行迁移。
+// fn执行期间每隔lockRenewInterval续期一次，续期失败时取消传给fn的ctx，fn返回ErrLockLost。
+// 锁记录本次持有者的owner，释放时只删除自己持有的锁，不会误删已被其他进程接管的锁
+func (r *Runner) withLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	owner := uuid.New().String()
+	now := time.Now()
+	_, err := r.records.UpdateOne(ctx, bson.M{
+		"_id":          lockID,
+		"locked_until": bson.M{"$lt": now},
+	}, bson.M{
+		"$set": bson.M{
+			"owner":        owner,
+			"locked_until": now.Add(lockTTL),
+		},
+	}, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrLocked
+	}
+	if err != nil {
+		return err
+	}
+	defer r.records.DeleteOne(context.Background(), bson.M{"_id": lockID, "owner": owner})
+
+	lockCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	done := make(chan struct{})
+	defer close(done)
+	go r.renewLock(lockCtx, owner, done, cancel)
+
+	err = fn(lockCtx)
+	if cause := context.Cause(lockCtx); errors.Is(cause, ErrLockLost) {
+		return cause
+	}
+	return err
+}
+
+// renewLock 在done关闭前定期延长owner持有的迁移锁，锁已不属于owner或续期失败到锁过期时调用cancel
+func (r *Runner) renewLock(ctx context.Context, owner string, done <-chan struct{}, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(lockRenewInterval)
+	defer ticker.Stop()
+
+	lockedUntil := time.Now().Add(lockTTL)
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		result, err := r.records.UpdateOne(ctx, bson.M{"_id": lockID, "owner": owner}, bson.M{
+			"$set": bson.M{"locked_until": now.Add(lockTTL)},
+		})
+		switch {
+		case err == nil && result.MatchedCount == 0:
+			cancel(ErrLockLost)
+			return
+		case err == nil:
+			lockedUntil = now.Add(lockTTL)
+		case now.After(lockedUntil):
+			// 暂时性错误时继续重试，直到锁已过期
+			cancel(fmt.Errorf("%w: %v", ErrLockLost, err))
+			return
+		}
+	}
+}
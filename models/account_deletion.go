@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// AccountDeletion 用户注销账号后的清理任务，每个用户一条记录，
+// 由后台任务分批结束关注关系并删除拉黑、静音、关注请求、设置、分组和推荐反馈
+type AccountDeletion struct {
+	UserID      string    `bson:"_id"`
+	RequestedAt time.Time `bson:"requested_at"`
+	// NextAttemptAt 任务被领取后推迟到租约结束，执行任务的实例崩溃后由其他实例在该时间之后接管
+	NextAttemptAt time.Time `bson:"next_attempt_at"`
+	// RemovedCount 已结束的关注关系数量
+	RemovedCount int64      `bson:"removed_count"`
+	CompletedAt  *time.Time `bson:"completed_at,omitempty"`
+}
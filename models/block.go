@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// Block 拉黑关系，BlockerID拉黑了BlockedID
+type Block struct {
+	ID        string    `bson:"_id"`
+	BlockerID string    `bson:"blocker_id"`
+	BlockedID string    `bson:"blocked_id"`
+	CreatedAt time.Time `bson:"created_at"`
+}
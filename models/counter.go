@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// FollowCounter 用户关注数和粉丝数的冗余计数，随关注/取消关注在同一事务中更新
+type FollowCounter struct {
+	UserID         string    `bson:"_id"`
+	FollowersCount int64     `bson:"followers_count"`
+	FollowingCount int64     `bson:"following_count"`
+	UpdatedAt      time.Time `bson:"updated_at"`
+}
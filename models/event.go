@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// EventType 关注关系事件类型
+type EventType string
+
+const (
+	EventFollowCreated EventType = "FollowCreated"
+	EventFollowDeleted EventType = "FollowDeleted"
+	// 以下事件只用于创建回调，不发布到下游，FollowerID为发起操作的用户，FollowingID为对方
+	EventBlockCreated         EventType = "BlockCreated"
+	EventFollowRequestCreated EventType = "FollowRequestCreated"
+)
+
+// OutboxEvent 与关注关系在同一事务中写入发件箱的事件，由中继任务发布到下游，保证至少投递一次
+type OutboxEvent struct {
+	ID            string     `bson:"_id"`
+	Type          EventType  `bson:"type"`
+	FollowerID    string     `bson:"follower_id"`
+	FollowingID   string     `bson:"following_id"`
+	OccurredAt    time.Time  `bson:"occurred_at"`
+	Published     bool       `bson:"published"`
+	PublishedAt   *time.Time `bson:"published_at,omitempty"`
+	Attempts      int        `bson:"attempts"`
+	NextAttemptAt time.Time  `bson:"next_attempt_at"`
+	LastError     string     `bson:"last_error,omitempty"`
+}
+
+// NewOutboxEvent 创建待发布的关注关系事件
+func NewOutboxEvent(id string, eventType EventType, followerID, followingID string, occurredAt time.Time) *OutboxEvent {
+	return &OutboxEvent{
+		ID:            id,
+		Type:          eventType,
+		FollowerID:    followerID,
+		FollowingID:   followingID,
+		OccurredAt:    occurredAt,
+		NextAttemptAt: occurredAt,
+	}
+}
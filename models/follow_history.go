@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// UnfollowReason 关注关系结束的原因
+type UnfollowReason string
+
+const (
+	// UnfollowReasonUser 用户主动取消关注
+	UnfollowReasonUser UnfollowReason = "user"
+	// UnfollowReasonBlock 任意一方拉黑了另一方
+	UnfollowReasonBlock UnfollowReason = "block"
+	// UnfollowReasonAccountDeleted 任意一方注销了账号
+	UnfollowReasonAccountDeleted UnfollowReason = "account_deleted"
+)
+
+// FollowHistory 一段关注关系的历史记录，ID与对应的关注关系相同。
+// 关注时写入，取消关注时补充UnfollowedAt和Reason，之后除Latest和Refollowed外不再修改，也不会被删除
+type FollowHistory struct {
+	ID           string         `bson:"_id"`
+	FollowerID   string         `bson:"follower_id"`
+	FollowingID  string         `bson:"following_id"`
+	FollowedAt   time.Time      `bson:"followed_at"`
+	UnfollowedAt *time.Time     `bson:"unfollowed_at,omitempty"` // 为nil表示关注关系仍然存在
+	Reason       UnfollowReason `bson:"reason,omitempty"`
+	// Latest 为true表示这是同一对用户之间最近一次结束的关注关系，取消关注时从上一条记录转移到新记录
+	Latest bool `bson:"latest,omitempty"`
+	// Refollowed 为true表示结束之后又重新关注，只在Latest为true的记录上维护
+	Refollowed bool `bson:"refollowed,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// FollowList 用户创建的关注分组，例如"密友"、"同事"，成员只能是所有者关注的用户
+type FollowList struct {
+	ID          string    `bson:"_id"`
+	OwnerID     string    `bson:"owner_id"`
+	Name        string    `bson:"name"`
+	MemberCount int64     `bson:"-"` // 查询列表时统计得出，不保存
+	CreatedAt   time.Time `bson:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
+
+// FollowListMember 分组中的一个成员，CreatedAt为加入分组的时间
+type FollowListMember struct {
+	ID        string    `bson:"_id"`
+	ListID    string    `bson:"list_id"`
+	OwnerID   string    `bson:"owner_id"`
+	MemberID  string    `bson:"member_id"`
+	CreatedAt time.Time `bson:"created_at"`
+}
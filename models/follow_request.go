@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// FollowRequestStatus 关注请求的状态
+type FollowRequestStatus string
+
+const (
+	FollowRequestPending   FollowRequestStatus = "pending"
+	FollowRequestApproved  FollowRequestStatus = "approved"
+	FollowRequestRejected  FollowRequestStatus = "rejected"
+	FollowRequestCancelled FollowRequestStatus = "cancelled"
+)
+
+// FollowRequest 关注需要审批的用户时产生的关注请求
+type FollowRequest struct {
+	ID          string              `bson:"_id"`
+	RequesterID string              `bson:"requester_id"`
+	TargetID    string              `bson:"target_id"`
+	Status      FollowRequestStatus `bson:"status"`
+	CreatedAt   time.Time           `bson:"created_at"`
+	UpdatedAt   time.Time           `bson:"updated_at"`
+}
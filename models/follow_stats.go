@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// FollowStatsDay 用户一天内的粉丝变化汇总，随关注/取消关注在同一事务中更新。
+// Day为UTC零点，每个用户每天一条记录，ID为"用户ID:日期"
+type FollowStatsDay struct {
+	ID     string    `bson:"_id"`
+	UserID string    `bson:"user_id"`
+	Day    time.Time `bson:"day"`
+	Gained int64     `bson:"gained"` // 新增粉丝数
+	Lost   int64     `bson:"lost"`   // 流失粉丝数
+}
+
+// StatsDay 返回t所在的UTC日期的零点
+func StatsDay(t time.Time) time.Time {
+	return time.Date(t.UTC().Year(), t.UTC().Month(), t.UTC().Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// FollowStatsDayID 返回userID在day这一天的汇总记录ID
+func FollowStatsDayID(userID string, day time.Time) string {
+	return userID + ":" + day.UTC().Format("2006-01-02")
+}
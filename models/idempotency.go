@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyRecord 记录带Idempotency-Key的请求及其响应，客户端重试时直接返回原响应
+type IdempotencyRecord struct {
+	ID          string    `bson:"_id"`         // 用户ID与Idempotency-Key组合而成
+	Fingerprint string    `bson:"fingerprint"` // 请求方法、路径和请求体的摘要，同一个key不能用于不同的请求
+	Completed   bool      `bson:"completed"`   // 为false表示请求仍在处理中
+	StatusCode  int       `bson:"status_code,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// Mute 静音关系，UserID静音了自己关注的MutedID，不影响关注关系
+type Mute struct {
+	ID        string    `bson:"_id"`
+	UserID    string    `bson:"user_id"`
+	MutedID   string    `bson:"muted_id"`
+	CreatedAt time.Time `bson:"created_at"`
+}
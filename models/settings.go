@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// ListVisibility 关注列表或粉丝列表对其他用户的可见范围
+type ListVisibility string
+
+const (
+	ListVisibilityPublic  ListVisibility = "public"  // 所有用户可见
+	ListVisibilityMutuals ListVisibility = "mutuals" // 仅互关的用户可见
+	ListVisibilityHidden  ListVisibility = "hidden"  // 仅自己可见
+)
+
+// UserSettings 用户在关注服务中的个人设置
+type UserSettings struct {
+	UserID           string `bson:"_id"`
+	RequiresApproval bool   `bson:"requires_approval"`
+	// FollowsVisibility 和 FansVisibility 为空表示未设置，按public处理
+	FollowsVisibility ListVisibility `bson:"follows_visibility,omitempty"`
+	FansVisibility    ListVisibility `bson:"fans_visibility,omitempty"`
+	UpdatedAt         time.Time      `bson:"updated_at"`
+}
+
+// FollowsVisibilityOrDefault 返回关注列表的可见范围，未设置时为public
+func (s *UserSettings) FollowsVisibilityOrDefault() ListVisibility {
+	if s.FollowsVisibility == "" {
+		return ListVisibilityPublic
+	}
+	return s.FollowsVisibility
+}
+
+// FansVisibilityOrDefault 返回粉丝列表的可见范围，未设置时为public
+func (s *UserSettings) FansVisibilityOrDefault() ListVisibility {
+	if s.FansVisibility == "" {
+		return ListVisibilityPublic
+	}
+	return s.FansVisibility
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// UserProfile 本服务保存的用户资料副本，用于同城推荐和新用户推荐。
+// 用户服务不提供按城市查询用户的接口，因此由用户服务通过SyncUserProfiles推送，并从获取过的用户信息中同步
+type UserProfile struct {
+	UserID       string    `bson:"_id"`
+	City         string    `bson:"city"`
+	Gender       string    `bson:"gender"`
+	IsOnline     bool      `bson:"is_online"`
+	LastOnlineAt time.Time `bson:"last_online_at"`
+	JoinedAt     time.Time `bson:"joined_at"` // 用户注册时间
+	UpdatedAt    time.Time `bson:"updated_at"`
+}
+
+// OnlineAsOf 判断用户是否在线：副本中的在线状态只在获取用户信息或同步资料时更新，用户下线后不会被清除，
+// 因此只有在since之后更新的在线状态才可信
+func (p UserProfile) OnlineAsOf(since time.Time) bool {
+	return p.IsOnline && !p.UpdatedAt.Before(since)
+}
+
+// SuggestionDismissal 用户对某个推荐标记了"不感兴趣"，该用户不会再被推荐
+type SuggestionDismissal struct {
+	ID          string    `bson:"_id"`
+	UserID      string    `bson:"user_id"`
+	DismissedID string    `bson:"dismissed_id"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookEvent 可订阅的关系变更事件
+type WebhookEvent string
+
+const (
+	WebhookEventFollow        WebhookEvent = "follow"
+	WebhookEventUnfollow      WebhookEvent = "unfollow"
+	WebhookEventBlock         WebhookEvent = "block"
+	WebhookEventFollowRequest WebhookEvent = "follow_request"
+)
+
+// Webhook 外部系统注册的回调地址，Secret用于对请求体做HMAC签名
+type Webhook struct {
+	ID        string         `bson:"_id"`
+	URL       string         `bson:"url"`
+	Secret    string         `bson:"secret"`
+	Events    []WebhookEvent `bson:"events"`
+	CreatedAt time.Time      `bson:"created_at"`
+}
+
+// WebhookDelivery 一次待投递（或已进入死信）的回调，Payload为序列化后的请求体
+type WebhookDelivery struct {
+	ID            string       `bson:"_id"`
+	WebhookID     string       `bson:"webhook_id"`
+	Event         WebhookEvent `bson:"event"`
+	Payload       string       `bson:"payload"`
+	Attempts      int          `bson:"attempts"`
+	NextAttemptAt time.Time    `bson:"next_attempt_at"`
+	LastError     string       `bson:"last_error,omitempty"`
+	CreatedAt     time.Time    `bson:"created_at"`
+	// FailedAt 进入死信的时间，仅死信记录有值
+	FailedAt *time.Time `bson:"failed_at,omitempty"`
+}
@@ -19,8 +19,36 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FollowService_GetFollowCount_FullMethodName      = "/proto.FollowService/GetFollowCount"
-	FollowService_GetFollowingUserIds_FullMethodName = "/proto.FollowService/GetFollowingUserIds"
+	FollowService_GetFollowCount_FullMethodName         = "/proto.FollowService/GetFollowCount"
+	FollowService_GetFollowingUserIds_FullMethodName    = "/proto.FollowService/GetFollowingUserIds"
+	FollowService_BlockUser_FullMethodName              = "/proto.FollowService/BlockUser"
+	FollowService_UnblockUser_FullMethodName            = "/proto.FollowService/UnblockUser"
+	FollowService_IsBlocked_FullMethodName              = "/proto.FollowService/IsBlocked"
+	FollowService_GetMutedUserIds_FullMethodName        = "/proto.FollowService/GetMutedUserIds"
+	FollowService_IsFollowing_FullMethodName            = "/proto.FollowService/IsFollowing"
+	FollowService_GetRelationships_FullMethodName       = "/proto.FollowService/GetRelationships"
+	FollowService_ListFollowing_FullMethodName          = "/proto.FollowService/ListFollowing"
+	FollowService_ListFollowers_FullMethodName          = "/proto.FollowService/ListFollowers"
+	FollowService_ListMutualFollows_FullMethodName      = "/proto.FollowService/ListMutualFollows"
+	FollowService_GetFollowerUserIds_FullMethodName     = "/proto.FollowService/GetFollowerUserIds"
+	FollowService_StreamFollowingUserIds_FullMethodName = "/proto.FollowService/StreamFollowingUserIds"
+	FollowService_StreamFollowerUserIds_FullMethodName  = "/proto.FollowService/StreamFollowerUserIds"
+	FollowService_InvalidateProfileCache_FullMethodName = "/proto.FollowService/InvalidateProfileCache"
+	FollowService_GetProfileCacheStats_FullMethodName   = "/proto.FollowService/GetProfileCacheStats"
+	FollowService_WatchFollowEvents_FullMethodName      = "/proto.FollowService/WatchFollowEvents"
+	FollowService_GetSuggestions_FullMethodName         = "/proto.FollowService/GetSuggestions"
+	FollowService_DismissSuggestion_FullMethodName      = "/proto.FollowService/DismissSuggestion"
+	FollowService_GetFollowStats_FullMethodName         = "/proto.FollowService/GetFollowStats"
+	FollowService_ListRecentUnfollowers_FullMethodName  = "/proto.FollowService/ListRecentUnfollowers"
+	FollowService_GetRelationshipHistory_FullMethodName = "/proto.FollowService/GetRelationshipHistory"
+	FollowService_DeleteUserFollows_FullMethodName      = "/proto.FollowService/DeleteUserFollows"
+	FollowService_IsInList_FullMethodName               = "/proto.FollowService/IsInList"
+	FollowService_GetListMemberIds_FullMethodName       = "/proto.FollowService/GetListMemberIds"
+	FollowService_GetFollowingFeed_FullMethodName       = "/proto.FollowService/GetFollowingFeed"
+	FollowService_GetOnlineFollowing_FullMethodName     = "/proto.FollowService/GetOnlineFollowing"
+	FollowService_ListCommonFollowing_FullMethodName    = "/proto.FollowService/ListCommonFollowing"
+	FollowService_ListFollowedBy_FullMethodName         = "/proto.FollowService/ListFollowedBy"
+	FollowService_SyncUserProfiles_FullMethodName       = "/proto.FollowService/SyncUserProfiles"
 )
 
 // FollowServiceClient is the client API for FollowService service.
@@ -29,6 +57,34 @@ const (
 type FollowServiceClient interface {
 	GetFollowCount(ctx context.Context, in *GetFollowCountRequest, opts ...grpc.CallOption) (*GetFollowCountResponse, error)
 	GetFollowingUserIds(ctx context.Context, in *GetFollowingUserIdsRequest, opts ...grpc.CallOption) (*GetFollowingUserIdsResponse, error)
+	BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error)
+	UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error)
+	IsBlocked(ctx context.Context, in *IsBlockedRequest, opts ...grpc.CallOption) (*IsBlockedResponse, error)
+	GetMutedUserIds(ctx context.Context, in *GetMutedUserIdsRequest, opts ...grpc.CallOption) (*GetMutedUserIdsResponse, error)
+	IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error)
+	GetRelationships(ctx context.Context, in *GetRelationshipsRequest, opts ...grpc.CallOption) (*GetRelationshipsResponse, error)
+	ListFollowing(ctx context.Context, in *ListFollowsRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error)
+	ListFollowers(ctx context.Context, in *ListFollowsRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error)
+	ListMutualFollows(ctx context.Context, in *ListFollowsRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error)
+	GetFollowerUserIds(ctx context.Context, in *GetFollowerUserIdsRequest, opts ...grpc.CallOption) (*GetFollowerUserIdsResponse, error)
+	StreamFollowingUserIds(ctx context.Context, in *StreamUserIdsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserIdsChunk], error)
+	StreamFollowerUserIds(ctx context.Context, in *StreamUserIdsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserIdsChunk], error)
+	InvalidateProfileCache(ctx context.Context, in *InvalidateProfileCacheRequest, opts ...grpc.CallOption) (*InvalidateProfileCacheResponse, error)
+	GetProfileCacheStats(ctx context.Context, in *GetProfileCacheStatsRequest, opts ...grpc.CallOption) (*GetProfileCacheStatsResponse, error)
+	WatchFollowEvents(ctx context.Context, in *WatchFollowEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FollowEvent], error)
+	GetSuggestions(ctx context.Context, in *GetSuggestionsRequest, opts ...grpc.CallOption) (*GetSuggestionsResponse, error)
+	DismissSuggestion(ctx context.Context, in *DismissSuggestionRequest, opts ...grpc.CallOption) (*DismissSuggestionResponse, error)
+	GetFollowStats(ctx context.Context, in *GetFollowStatsRequest, opts ...grpc.CallOption) (*GetFollowStatsResponse, error)
+	ListRecentUnfollowers(ctx context.Context, in *ListFollowsRequest, opts ...grpc.CallOption) (*ListFollowHistoryResponse, error)
+	GetRelationshipHistory(ctx context.Context, in *GetRelationshipHistoryRequest, opts ...grpc.CallOption) (*GetRelationshipHistoryResponse, error)
+	DeleteUserFollows(ctx context.Context, in *DeleteUserFollowsRequest, opts ...grpc.CallOption) (*DeleteUserFollowsResponse, error)
+	IsInList(ctx context.Context, in *IsInListRequest, opts ...grpc.CallOption) (*IsInListResponse, error)
+	GetListMemberIds(ctx context.Context, in *GetListMemberIdsRequest, opts ...grpc.CallOption) (*GetListMemberIdsResponse, error)
+	GetFollowingFeed(ctx context.Context, in *GetFollowingFeedRequest, opts ...grpc.CallOption) (*GetFollowingFeedResponse, error)
+	GetOnlineFollowing(ctx context.Context, in *GetOnlineFollowingRequest, opts ...grpc.CallOption) (*GetOnlineFollowingResponse, error)
+	ListCommonFollowing(ctx context.Context, in *ListBetweenUsersRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error)
+	ListFollowedBy(ctx context.Context, in *ListBetweenUsersRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error)
+	SyncUserProfiles(ctx context.Context, in *SyncUserProfilesRequest, opts ...grpc.CallOption) (*SyncUserProfilesResponse, error)
 }
 
 type followServiceClient struct {
@@ -59,12 +115,347 @@ func (c *followServiceClient) GetFollowingUserIds(ctx context.Context, in *GetFo
 	return out, nil
 }
 
+func (c *followServiceClient) BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockUserResponse)
+	err := c.cc.Invoke(ctx, FollowService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockUserResponse)
+	err := c.cc.Invoke(ctx, FollowService_UnblockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) IsBlocked(ctx context.Context, in *IsBlockedRequest, opts ...grpc.CallOption) (*IsBlockedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsBlockedResponse)
+	err := c.cc.Invoke(ctx, FollowService_IsBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetMutedUserIds(ctx context.Context, in *GetMutedUserIdsRequest, opts ...grpc.CallOption) (*GetMutedUserIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMutedUserIdsResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetMutedUserIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) IsFollowing(ctx context.Context, in *IsFollowingRequest, opts ...grpc.CallOption) (*IsFollowingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsFollowingResponse)
+	err := c.cc.Invoke(ctx, FollowService_IsFollowing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetRelationships(ctx context.Context, in *GetRelationshipsRequest, opts ...grpc.CallOption) (*GetRelationshipsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelationshipsResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetRelationships_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ListFollowing(ctx context.Context, in *ListFollowsRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowsResponse)
+	err := c.cc.Invoke(ctx, FollowService_ListFollowing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ListFollowers(ctx context.Context, in *ListFollowsRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowsResponse)
+	err := c.cc.Invoke(ctx, FollowService_ListFollowers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ListMutualFollows(ctx context.Context, in *ListFollowsRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowsResponse)
+	err := c.cc.Invoke(ctx, FollowService_ListMutualFollows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetFollowerUserIds(ctx context.Context, in *GetFollowerUserIdsRequest, opts ...grpc.CallOption) (*GetFollowerUserIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowerUserIdsResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetFollowerUserIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) StreamFollowingUserIds(ctx context.Context, in *StreamUserIdsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserIdsChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FollowService_ServiceDesc.Streams[0], FollowService_StreamFollowingUserIds_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamUserIdsRequest, UserIdsChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FollowService_StreamFollowingUserIdsClient = grpc.ServerStreamingClient[UserIdsChunk]
+
+func (c *followServiceClient) StreamFollowerUserIds(ctx context.Context, in *StreamUserIdsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[UserIdsChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FollowService_ServiceDesc.Streams[1], FollowService_StreamFollowerUserIds_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamUserIdsRequest, UserIdsChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FollowService_StreamFollowerUserIdsClient = grpc.ServerStreamingClient[UserIdsChunk]
+
+func (c *followServiceClient) InvalidateProfileCache(ctx context.Context, in *InvalidateProfileCacheRequest, opts ...grpc.CallOption) (*InvalidateProfileCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvalidateProfileCacheResponse)
+	err := c.cc.Invoke(ctx, FollowService_InvalidateProfileCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetProfileCacheStats(ctx context.Context, in *GetProfileCacheStatsRequest, opts ...grpc.CallOption) (*GetProfileCacheStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProfileCacheStatsResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetProfileCacheStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) WatchFollowEvents(ctx context.Context, in *WatchFollowEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FollowEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FollowService_ServiceDesc.Streams[2], FollowService_WatchFollowEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchFollowEventsRequest, FollowEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FollowService_WatchFollowEventsClient = grpc.ServerStreamingClient[FollowEvent]
+
+func (c *followServiceClient) GetSuggestions(ctx context.Context, in *GetSuggestionsRequest, opts ...grpc.CallOption) (*GetSuggestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSuggestionsResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetSuggestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) DismissSuggestion(ctx context.Context, in *DismissSuggestionRequest, opts ...grpc.CallOption) (*DismissSuggestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DismissSuggestionResponse)
+	err := c.cc.Invoke(ctx, FollowService_DismissSuggestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetFollowStats(ctx context.Context, in *GetFollowStatsRequest, opts ...grpc.CallOption) (*GetFollowStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowStatsResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetFollowStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ListRecentUnfollowers(ctx context.Context, in *ListFollowsRequest, opts ...grpc.CallOption) (*ListFollowHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowHistoryResponse)
+	err := c.cc.Invoke(ctx, FollowService_ListRecentUnfollowers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetRelationshipHistory(ctx context.Context, in *GetRelationshipHistoryRequest, opts ...grpc.CallOption) (*GetRelationshipHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelationshipHistoryResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetRelationshipHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) DeleteUserFollows(ctx context.Context, in *DeleteUserFollowsRequest, opts ...grpc.CallOption) (*DeleteUserFollowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserFollowsResponse)
+	err := c.cc.Invoke(ctx, FollowService_DeleteUserFollows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) IsInList(ctx context.Context, in *IsInListRequest, opts ...grpc.CallOption) (*IsInListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IsInListResponse)
+	err := c.cc.Invoke(ctx, FollowService_IsInList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetListMemberIds(ctx context.Context, in *GetListMemberIdsRequest, opts ...grpc.CallOption) (*GetListMemberIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetListMemberIdsResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetListMemberIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetFollowingFeed(ctx context.Context, in *GetFollowingFeedRequest, opts ...grpc.CallOption) (*GetFollowingFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFollowingFeedResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetFollowingFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) GetOnlineFollowing(ctx context.Context, in *GetOnlineFollowingRequest, opts ...grpc.CallOption) (*GetOnlineFollowingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOnlineFollowingResponse)
+	err := c.cc.Invoke(ctx, FollowService_GetOnlineFollowing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ListCommonFollowing(ctx context.Context, in *ListBetweenUsersRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowsResponse)
+	err := c.cc.Invoke(ctx, FollowService_ListCommonFollowing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) ListFollowedBy(ctx context.Context, in *ListBetweenUsersRequest, opts ...grpc.CallOption) (*ListFollowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowsResponse)
+	err := c.cc.Invoke(ctx, FollowService_ListFollowedBy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *followServiceClient) SyncUserProfiles(ctx context.Context, in *SyncUserProfilesRequest, opts ...grpc.CallOption) (*SyncUserProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncUserProfilesResponse)
+	err := c.cc.Invoke(ctx, FollowService_SyncUserProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FollowServiceServer is the server API for FollowService service.
 // All implementations must embed UnimplementedFollowServiceServer
 // for forward compatibility.
 type FollowServiceServer interface {
 	GetFollowCount(context.Context, *GetFollowCountRequest) (*GetFollowCountResponse, error)
 	GetFollowingUserIds(context.Context, *GetFollowingUserIdsRequest) (*GetFollowingUserIdsResponse, error)
+	BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error)
+	UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error)
+	IsBlocked(context.Context, *IsBlockedRequest) (*IsBlockedResponse, error)
+	GetMutedUserIds(context.Context, *GetMutedUserIdsRequest) (*GetMutedUserIdsResponse, error)
+	IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error)
+	GetRelationships(context.Context, *GetRelationshipsRequest) (*GetRelationshipsResponse, error)
+	ListFollowing(context.Context, *ListFollowsRequest) (*ListFollowsResponse, error)
+	ListFollowers(context.Context, *ListFollowsRequest) (*ListFollowsResponse, error)
+	ListMutualFollows(context.Context, *ListFollowsRequest) (*ListFollowsResponse, error)
+	GetFollowerUserIds(context.Context, *GetFollowerUserIdsRequest) (*GetFollowerUserIdsResponse, error)
+	StreamFollowingUserIds(*StreamUserIdsRequest, grpc.ServerStreamingServer[UserIdsChunk]) error
+	StreamFollowerUserIds(*StreamUserIdsRequest, grpc.ServerStreamingServer[UserIdsChunk]) error
+	InvalidateProfileCache(context.Context, *InvalidateProfileCacheRequest) (*InvalidateProfileCacheResponse, error)
+	GetProfileCacheStats(context.Context, *GetProfileCacheStatsRequest) (*GetProfileCacheStatsResponse, error)
+	WatchFollowEvents(*WatchFollowEventsRequest, grpc.ServerStreamingServer[FollowEvent]) error
+	GetSuggestions(context.Context, *GetSuggestionsRequest) (*GetSuggestionsResponse, error)
+	DismissSuggestion(context.Context, *DismissSuggestionRequest) (*DismissSuggestionResponse, error)
+	GetFollowStats(context.Context, *GetFollowStatsRequest) (*GetFollowStatsResponse, error)
+	ListRecentUnfollowers(context.Context, *ListFollowsRequest) (*ListFollowHistoryResponse, error)
+	GetRelationshipHistory(context.Context, *GetRelationshipHistoryRequest) (*GetRelationshipHistoryResponse, error)
+	DeleteUserFollows(context.Context, *DeleteUserFollowsRequest) (*DeleteUserFollowsResponse, error)
+	IsInList(context.Context, *IsInListRequest) (*IsInListResponse, error)
+	GetListMemberIds(context.Context, *GetListMemberIdsRequest) (*GetListMemberIdsResponse, error)
+	GetFollowingFeed(context.Context, *GetFollowingFeedRequest) (*GetFollowingFeedResponse, error)
+	GetOnlineFollowing(context.Context, *GetOnlineFollowingRequest) (*GetOnlineFollowingResponse, error)
+	ListCommonFollowing(context.Context, *ListBetweenUsersRequest) (*ListFollowsResponse, error)
+	ListFollowedBy(context.Context, *ListBetweenUsersRequest) (*ListFollowsResponse, error)
+	SyncUserProfiles(context.Context, *SyncUserProfilesRequest) (*SyncUserProfilesResponse, error)
 	mustEmbedUnimplementedFollowServiceServer()
 }
 
@@ -81,6 +472,90 @@ func (UnimplementedFollowServiceServer) GetFollowCount(context.Context, *GetFoll
 func (UnimplementedFollowServiceServer) GetFollowingUserIds(context.Context, *GetFollowingUserIdsRequest) (*GetFollowingUserIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFollowingUserIds not implemented")
 }
+func (UnimplementedFollowServiceServer) BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedFollowServiceServer) UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedFollowServiceServer) IsBlocked(context.Context, *IsBlockedRequest) (*IsBlockedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsBlocked not implemented")
+}
+func (UnimplementedFollowServiceServer) GetMutedUserIds(context.Context, *GetMutedUserIdsRequest) (*GetMutedUserIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMutedUserIds not implemented")
+}
+func (UnimplementedFollowServiceServer) IsFollowing(context.Context, *IsFollowingRequest) (*IsFollowingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsFollowing not implemented")
+}
+func (UnimplementedFollowServiceServer) GetRelationships(context.Context, *GetRelationshipsRequest) (*GetRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelationships not implemented")
+}
+func (UnimplementedFollowServiceServer) ListFollowing(context.Context, *ListFollowsRequest) (*ListFollowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowing not implemented")
+}
+func (UnimplementedFollowServiceServer) ListFollowers(context.Context, *ListFollowsRequest) (*ListFollowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowers not implemented")
+}
+func (UnimplementedFollowServiceServer) ListMutualFollows(context.Context, *ListFollowsRequest) (*ListFollowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMutualFollows not implemented")
+}
+func (UnimplementedFollowServiceServer) GetFollowerUserIds(context.Context, *GetFollowerUserIdsRequest) (*GetFollowerUserIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowerUserIds not implemented")
+}
+func (UnimplementedFollowServiceServer) StreamFollowingUserIds(*StreamUserIdsRequest, grpc.ServerStreamingServer[UserIdsChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFollowingUserIds not implemented")
+}
+func (UnimplementedFollowServiceServer) StreamFollowerUserIds(*StreamUserIdsRequest, grpc.ServerStreamingServer[UserIdsChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFollowerUserIds not implemented")
+}
+func (UnimplementedFollowServiceServer) InvalidateProfileCache(context.Context, *InvalidateProfileCacheRequest) (*InvalidateProfileCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvalidateProfileCache not implemented")
+}
+func (UnimplementedFollowServiceServer) GetProfileCacheStats(context.Context, *GetProfileCacheStatsRequest) (*GetProfileCacheStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfileCacheStats not implemented")
+}
+func (UnimplementedFollowServiceServer) WatchFollowEvents(*WatchFollowEventsRequest, grpc.ServerStreamingServer[FollowEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFollowEvents not implemented")
+}
+func (UnimplementedFollowServiceServer) GetSuggestions(context.Context, *GetSuggestionsRequest) (*GetSuggestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSuggestions not implemented")
+}
+func (UnimplementedFollowServiceServer) DismissSuggestion(context.Context, *DismissSuggestionRequest) (*DismissSuggestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DismissSuggestion not implemented")
+}
+func (UnimplementedFollowServiceServer) GetFollowStats(context.Context, *GetFollowStatsRequest) (*GetFollowStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowStats not implemented")
+}
+func (UnimplementedFollowServiceServer) ListRecentUnfollowers(context.Context, *ListFollowsRequest) (*ListFollowHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentUnfollowers not implemented")
+}
+func (UnimplementedFollowServiceServer) GetRelationshipHistory(context.Context, *GetRelationshipHistoryRequest) (*GetRelationshipHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelationshipHistory not implemented")
+}
+func (UnimplementedFollowServiceServer) DeleteUserFollows(context.Context, *DeleteUserFollowsRequest) (*DeleteUserFollowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserFollows not implemented")
+}
+func (UnimplementedFollowServiceServer) IsInList(context.Context, *IsInListRequest) (*IsInListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsInList not implemented")
+}
+func (UnimplementedFollowServiceServer) GetListMemberIds(context.Context, *GetListMemberIdsRequest) (*GetListMemberIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListMemberIds not implemented")
+}
+func (UnimplementedFollowServiceServer) GetFollowingFeed(context.Context, *GetFollowingFeedRequest) (*GetFollowingFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowingFeed not implemented")
+}
+func (UnimplementedFollowServiceServer) GetOnlineFollowing(context.Context, *GetOnlineFollowingRequest) (*GetOnlineFollowingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOnlineFollowing not implemented")
+}
+func (UnimplementedFollowServiceServer) ListCommonFollowing(context.Context, *ListBetweenUsersRequest) (*ListFollowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCommonFollowing not implemented")
+}
+func (UnimplementedFollowServiceServer) ListFollowedBy(context.Context, *ListBetweenUsersRequest) (*ListFollowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowedBy not implemented")
+}
+func (UnimplementedFollowServiceServer) SyncUserProfiles(context.Context, *SyncUserProfilesRequest) (*SyncUserProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncUserProfiles not implemented")
+}
 func (UnimplementedFollowServiceServer) mustEmbedUnimplementedFollowServiceServer() {}
 func (UnimplementedFollowServiceServer) testEmbeddedByValue()                       {}
 
@@ -138,22 +613,621 @@ func _FollowService_GetFollowingUserIds_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-// FollowService_ServiceDesc is the grpc.ServiceDesc for FollowService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var FollowService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.FollowService",
-	HandlerType: (*FollowServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetFollowCount",
-			Handler:    _FollowService_GetFollowCount_Handler,
-		},
-		{
-			MethodName: "GetFollowingUserIds",
-			Handler:    _FollowService_GetFollowingUserIds_Handler,
+func _FollowService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).BlockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).UnblockUser(ctx, req.(*UnblockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_IsBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).IsBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_IsBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).IsBlocked(ctx, req.(*IsBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetMutedUserIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMutedUserIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetMutedUserIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetMutedUserIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetMutedUserIds(ctx, req.(*GetMutedUserIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_IsFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsFollowingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).IsFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_IsFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).IsFollowing(ctx, req.(*IsFollowingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetRelationships_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetRelationships(ctx, req.(*GetRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ListFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ListFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ListFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ListFollowing(ctx, req.(*ListFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ListFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ListFollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ListFollowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ListFollowers(ctx, req.(*ListFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ListMutualFollows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ListMutualFollows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ListMutualFollows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ListMutualFollows(ctx, req.(*ListFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetFollowerUserIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowerUserIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollowerUserIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollowerUserIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollowerUserIds(ctx, req.(*GetFollowerUserIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_StreamFollowingUserIds_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamUserIdsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FollowServiceServer).StreamFollowingUserIds(m, &grpc.GenericServerStream[StreamUserIdsRequest, UserIdsChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FollowService_StreamFollowingUserIdsServer = grpc.ServerStreamingServer[UserIdsChunk]
+
+func _FollowService_StreamFollowerUserIds_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamUserIdsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FollowServiceServer).StreamFollowerUserIds(m, &grpc.GenericServerStream[StreamUserIdsRequest, UserIdsChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FollowService_StreamFollowerUserIdsServer = grpc.ServerStreamingServer[UserIdsChunk]
+
+func _FollowService_InvalidateProfileCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateProfileCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).InvalidateProfileCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_InvalidateProfileCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).InvalidateProfileCache(ctx, req.(*InvalidateProfileCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetProfileCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileCacheStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetProfileCacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetProfileCacheStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetProfileCacheStats(ctx, req.(*GetProfileCacheStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_WatchFollowEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFollowEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FollowServiceServer).WatchFollowEvents(m, &grpc.GenericServerStream[WatchFollowEventsRequest, FollowEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FollowService_WatchFollowEventsServer = grpc.ServerStreamingServer[FollowEvent]
+
+func _FollowService_GetSuggestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSuggestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetSuggestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetSuggestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetSuggestions(ctx, req.(*GetSuggestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_DismissSuggestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DismissSuggestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).DismissSuggestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_DismissSuggestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).DismissSuggestion(ctx, req.(*DismissSuggestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetFollowStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollowStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollowStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollowStats(ctx, req.(*GetFollowStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ListRecentUnfollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ListRecentUnfollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ListRecentUnfollowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ListRecentUnfollowers(ctx, req.(*ListFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetRelationshipHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelationshipHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetRelationshipHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetRelationshipHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetRelationshipHistory(ctx, req.(*GetRelationshipHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_DeleteUserFollows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserFollowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).DeleteUserFollows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_DeleteUserFollows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).DeleteUserFollows(ctx, req.(*DeleteUserFollowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_IsInList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsInListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).IsInList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_IsInList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).IsInList(ctx, req.(*IsInListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetListMemberIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListMemberIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetListMemberIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetListMemberIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetListMemberIds(ctx, req.(*GetListMemberIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetFollowingFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFollowingFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetFollowingFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetFollowingFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetFollowingFeed(ctx, req.(*GetFollowingFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_GetOnlineFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOnlineFollowingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).GetOnlineFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_GetOnlineFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).GetOnlineFollowing(ctx, req.(*GetOnlineFollowingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ListCommonFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBetweenUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ListCommonFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ListCommonFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ListCommonFollowing(ctx, req.(*ListBetweenUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_ListFollowedBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBetweenUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).ListFollowedBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_ListFollowedBy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).ListFollowedBy(ctx, req.(*ListBetweenUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FollowService_SyncUserProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncUserProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FollowServiceServer).SyncUserProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FollowService_SyncUserProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FollowServiceServer).SyncUserProfiles(ctx, req.(*SyncUserProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FollowService_ServiceDesc is the grpc.ServiceDesc for FollowService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FollowService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.FollowService",
+	HandlerType: (*FollowServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFollowCount",
+			Handler:    _FollowService_GetFollowCount_Handler,
+		},
+		{
+			MethodName: "GetFollowingUserIds",
+			Handler:    _FollowService_GetFollowingUserIds_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _FollowService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _FollowService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "IsBlocked",
+			Handler:    _FollowService_IsBlocked_Handler,
+		},
+		{
+			MethodName: "GetMutedUserIds",
+			Handler:    _FollowService_GetMutedUserIds_Handler,
+		},
+		{
+			MethodName: "IsFollowing",
+			Handler:    _FollowService_IsFollowing_Handler,
+		},
+		{
+			MethodName: "GetRelationships",
+			Handler:    _FollowService_GetRelationships_Handler,
+		},
+		{
+			MethodName: "ListFollowing",
+			Handler:    _FollowService_ListFollowing_Handler,
+		},
+		{
+			MethodName: "ListFollowers",
+			Handler:    _FollowService_ListFollowers_Handler,
+		},
+		{
+			MethodName: "ListMutualFollows",
+			Handler:    _FollowService_ListMutualFollows_Handler,
+		},
+		{
+			MethodName: "GetFollowerUserIds",
+			Handler:    _FollowService_GetFollowerUserIds_Handler,
+		},
+		{
+			MethodName: "InvalidateProfileCache",
+			Handler:    _FollowService_InvalidateProfileCache_Handler,
+		},
+		{
+			MethodName: "GetProfileCacheStats",
+			Handler:    _FollowService_GetProfileCacheStats_Handler,
+		},
+		{
+			MethodName: "GetSuggestions",
+			Handler:    _FollowService_GetSuggestions_Handler,
+		},
+		{
+			MethodName: "DismissSuggestion",
+			Handler:    _FollowService_DismissSuggestion_Handler,
+		},
+		{
+			MethodName: "GetFollowStats",
+			Handler:    _FollowService_GetFollowStats_Handler,
+		},
+		{
+			MethodName: "ListRecentUnfollowers",
+			Handler:    _FollowService_ListRecentUnfollowers_Handler,
+		},
+		{
+			MethodName: "GetRelationshipHistory",
+			Handler:    _FollowService_GetRelationshipHistory_Handler,
+		},
+		{
+			MethodName: "DeleteUserFollows",
+			Handler:    _FollowService_DeleteUserFollows_Handler,
+		},
+		{
+			MethodName: "IsInList",
+			Handler:    _FollowService_IsInList_Handler,
+		},
+		{
+			MethodName: "GetListMemberIds",
+			Handler:    _FollowService_GetListMemberIds_Handler,
+		},
+		{
+			MethodName: "GetFollowingFeed",
+			Handler:    _FollowService_GetFollowingFeed_Handler,
+		},
+		{
+			MethodName: "GetOnlineFollowing",
+			Handler:    _FollowService_GetOnlineFollowing_Handler,
+		},
+		{
+			MethodName: "ListCommonFollowing",
+			Handler:    _FollowService_ListCommonFollowing_Handler,
+		},
+		{
+			MethodName: "ListFollowedBy",
+			Handler:    _FollowService_ListFollowedBy_Handler,
+		},
+		{
+			MethodName: "SyncUserProfiles",
+			Handler:    _FollowService_SyncUserProfiles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFollowingUserIds",
+			Handler:       _FollowService_StreamFollowingUserIds_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamFollowerUserIds",
+			Handler:       _FollowService_StreamFollowerUserIds_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchFollowEvents",
+			Handler:       _FollowService_WatchFollowEvents_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/follow.proto",
 }
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+)
+
+// AccountDeletionStore 定义注销账号清理任务的存储接口
+type AccountDeletionStore interface {
+	// RequestDeletion 为userID创建清理任务，已有未完成的任务时保持不变，已完成的任务会重新执行
+	RequestDeletion(ctx context.Context, userID string) error
+	// ClaimDeletion 领取一个到期的未完成任务，领取后lease时间内不会被再次领取，没有任务时返回nil
+	ClaimDeletion(ctx context.Context, lease time.Duration) (*models.AccountDeletion, error)
+	// RecordProgress 累加已结束的关注关系数量，并将租约从现在起延长lease
+	RecordProgress(ctx context.Context, userID string, removed int64, lease time.Duration) error
+	// CompleteDeletion 将任务标记为已完成
+	CompleteDeletion(ctx context.Context, userID string) error
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryAccountDeletionStore 基于内存的注销账号清理任务存储
+type MemoryAccountDeletionStore struct {
+	mu        sync.Mutex
+	deletions map[string]models.AccountDeletion
+}
+
+func NewMemoryAccountDeletionStore() *MemoryAccountDeletionStore {
+	return &MemoryAccountDeletionStore{
+		deletions: make(map[string]models.AccountDeletion),
+	}
+}
+
+func (s *MemoryAccountDeletionStore) RequestDeletion(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if deletion, ok := s.deletions[userID]; ok && deletion.CompletedAt == nil {
+		return nil
+	}
+	now := time.Now()
+	s.deletions[userID] = models.AccountDeletion{
+		UserID:        userID,
+		RequestedAt:   now,
+		NextAttemptAt: now,
+	}
+	return nil
+}
+
+func (s *MemoryAccountDeletionStore) ClaimDeletion(ctx context.Context, lease time.Duration) (*models.AccountDeletion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	due := make([]models.AccountDeletion, 0)
+	for _, deletion := range s.deletions {
+		if deletion.CompletedAt == nil && !deletion.NextAttemptAt.After(now) {
+			due = append(due, deletion)
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+
+	deletion := due[0]
+	deletion.NextAttemptAt = now.Add(lease)
+	s.deletions[deletion.UserID] = deletion
+	return &deletion, nil
+}
+
+func (s *MemoryAccountDeletionStore) RecordProgress(ctx context.Context, userID string, removed int64, lease time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if deletion, ok := s.deletions[userID]; ok {
+		deletion.RemovedCount += removed
+		deletion.NextAttemptAt = time.Now().Add(lease)
+		s.deletions[userID] = deletion
+	}
+	return nil
+}
+
+func (s *MemoryAccountDeletionStore) CompleteDeletion(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if deletion, ok := s.deletions[userID]; ok {
+		now := time.Now()
+		deletion.CompletedAt = &now
+		deletion.NextAttemptAt = time.Time{}
+		s.deletions[userID] = deletion
+	}
+	return nil
+}
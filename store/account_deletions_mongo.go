@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoAccountDeletionStore 基于MongoDB的注销账号清理任务存储，已完成的任务没有next_attempt_at
+type MongoAccountDeletionStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoAccountDeletionStore(collection *mongo.Collection) *MongoAccountDeletionStore {
+	return &MongoAccountDeletionStore{
+		collection: collection,
+	}
+}
+
+func (s *MongoAccountDeletionStore) RequestDeletion(ctx context.Context, userID string) error {
+	now := time.Now()
+	// 只重置已完成的任务，执行中的任务保留租约，避免被两个实例同时执行
+	result, err := s.collection.UpdateOne(ctx, bson.M{
+		"_id":          userID,
+		"completed_at": bson.M{"$exists": true},
+	}, bson.M{
+		"$set": bson.M{
+			"requested_at":    now,
+			"next_attempt_at": now,
+			"removed_count":   0,
+		},
+		"$unset": bson.M{"completed_at": ""},
+	})
+	if err != nil || result.MatchedCount > 0 {
+		return err
+	}
+
+	_, err = s.collection.InsertOne(ctx, models.AccountDeletion{
+		UserID:        userID,
+		RequestedAt:   now,
+		NextAttemptAt: now,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *MongoAccountDeletionStore) ClaimDeletion(ctx context.Context, lease time.Duration) (*models.AccountDeletion, error) {
+	now := time.Now()
+	var deletion models.AccountDeletion
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{
+		"next_attempt_at": bson.M{"$lte": now},
+	}, bson.M{
+		"$set": bson.M{"next_attempt_at": now.Add(lease)},
+	}, options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After),
+	).Decode(&deletion)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+func (s *MongoAccountDeletionStore) RecordProgress(ctx context.Context, userID string, removed int64, lease time.Duration) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{
+		"_id":          userID,
+		"completed_at": bson.M{"$exists": false},
+	}, bson.M{
+		"$set": bson.M{"next_attempt_at": time.Now().Add(lease)},
+		"$inc": bson.M{"removed_count": removed},
+	})
+	return err
+}
+
+func (s *MongoAccountDeletionStore) CompleteDeletion(ctx context.Context, userID string) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{
+		"$set":   bson.M{"completed_at": time.Now()},
+		"$unset": bson.M{"next_attempt_at": ""},
+	})
+	return err
+}
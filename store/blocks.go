@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+)
+
+var (
+	// ErrAlreadyBlocked 表示拉黑关系已存在
+	ErrAlreadyBlocked = errors.New("already blocked")
+	// ErrNotBlocked 表示拉黑关系不存在
+	ErrNotBlocked = errors.New("not blocked")
+)
+
+// BlockPage 定义一页拉黑关系及其总数
+type BlockPage struct {
+	Blocks     []models.Block
+	TotalCount int64
+}
+
+// BlockStore 定义拉黑关系的存储接口
+type BlockStore interface {
+	// Block 创建blockerID对blockedID的拉黑关系
+	Block(ctx context.Context, blockerID, blockedID string) (*models.Block, error)
+	// Unblock 解除blockerID对blockedID的拉黑
+	Unblock(ctx context.Context, blockerID, blockedID string) error
+	// IsBlocked 判断blockerID是否拉黑了blockedID
+	IsBlocked(ctx context.Context, blockerID, blockedID string) (bool, error)
+	// ListBlocked 按拉黑时间倒序返回userID拉黑的用户
+	ListBlocked(ctx context.Context, userID string, opts ListOptions) (*BlockPage, error)
+	// RelatedUserIDs 返回userID拉黑的以及拉黑了userID的所有用户ID
+	RelatedUserIDs(ctx context.Context, userID string) ([]string, error)
+	// DeleteUserBlocks 删除userID拉黑的以及拉黑了userID的所有拉黑关系，在用户注销账号时调用
+	DeleteUserBlocks(ctx context.Context, userID string) error
+}
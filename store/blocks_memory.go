@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryBlockStore 基于内存的拉黑关系存储，拉黑时向outbox写入BlockCreated事件
+type MemoryBlockStore struct {
+	mu     sync.RWMutex
+	blocks map[blockKey]models.Block
+	outbox *MemoryOutboxStore
+}
+
+type blockKey struct {
+	blockerID string
+	blockedID string
+}
+
+// NewMemoryBlockStore 创建拉黑关系存储，outbox通常为MemoryFollowStore.Outbox()
+func NewMemoryBlockStore(outbox *MemoryOutboxStore) *MemoryBlockStore {
+	return &MemoryBlockStore{
+		blocks: make(map[blockKey]models.Block),
+		outbox: outbox,
+	}
+}
+
+func (s *MemoryBlockStore) Block(ctx context.Context, blockerID, blockedID string) (*models.Block, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := blockKey{blockerID, blockedID}
+	if _, ok := s.blocks[key]; ok {
+		return nil, ErrAlreadyBlocked
+	}
+
+	block := models.Block{
+		ID:        uuid.New().String(),
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	}
+	s.blocks[key] = block
+	s.outbox.append(models.NewOutboxEvent(uuid.New().String(), models.EventBlockCreated, blockerID, blockedID, block.CreatedAt))
+	return &block, nil
+}
+
+func (s *MemoryBlockStore) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := blockKey{blockerID, blockedID}
+	if _, ok := s.blocks[key]; !ok {
+		return ErrNotBlocked
+	}
+	delete(s.blocks, key)
+	return nil
+}
+
+func (s *MemoryBlockStore) DeleteUserBlocks(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.blocks {
+		if key.blockerID == userID || key.blockedID == userID {
+			delete(s.blocks, key)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryBlockStore) IsBlocked(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.blocks[blockKey{blockerID, blockedID}]
+	return ok, nil
+}
+
+func (s *MemoryBlockStore) ListBlocked(ctx context.Context, userID string, opts ListOptions) (*BlockPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matched := make([]models.Block, 0)
+	for key, block := range s.blocks {
+		if key.blockerID == userID {
+			matched = append(matched, block)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	return &BlockPage{
+		Blocks:     paginate(matched, opts),
+		TotalCount: int64(len(matched)),
+	}, nil
+}
+
+func (s *MemoryBlockStore) RelatedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	userIDs := make([]string, 0)
+	for key := range s.blocks {
+		if key.blockerID == userID {
+			userIDs = append(userIDs, key.blockedID)
+		} else if key.blockedID == userID {
+			userIDs = append(userIDs, key.blockerID)
+		}
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoBlockStore 基于MongoDB的拉黑关系存储，拉黑关系和outbox中的BlockCreated事件在同一事务中写入
+type MongoBlockStore struct {
+	collection *mongo.Collection
+	outbox     *mongo.Collection
+}
+
+func NewMongoBlockStore(collection, outbox *mongo.Collection) *MongoBlockStore {
+	return &MongoBlockStore{
+		collection: collection,
+		outbox:     outbox,
+	}
+}
+
+// Block 依赖(blocker_id, blocked_id)唯一索引防止并发请求重复拉黑
+func (s *MongoBlockStore) Block(ctx context.Context, blockerID, blockedID string) (*models.Block, error) {
+	block := &models.Block{
+		ID:        uuid.New().String(),
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	}
+	err := withTransaction(ctx, s.collection, func(sessCtx mongo.SessionContext) error {
+		if _, err := s.collection.InsertOne(sessCtx, block); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return ErrAlreadyBlocked
+			}
+			return err
+		}
+		return writeOutboxEvent(sessCtx, s.outbox, models.EventBlockCreated, blockerID, blockedID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (s *MongoBlockStore) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	result, err := s.collection.DeleteOne(ctx, bson.M{
+		"blocker_id": blockerID,
+		"blocked_id": blockedID,
+	})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotBlocked
+	}
+	return nil
+}
+
+func (s *MongoBlockStore) DeleteUserBlocks(ctx context.Context, userID string) error {
+	_, err := s.collection.DeleteMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"blocker_id": userID},
+			{"blocked_id": userID},
+		},
+	})
+	return err
+}
+
+func (s *MongoBlockStore) IsBlocked(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	count, err := s.collection.CountDocuments(ctx, bson.M{
+		"blocker_id": blockerID,
+		"blocked_id": blockedID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *MongoBlockStore) ListBlocked(ctx context.Context, userID string, opts ListOptions) (*BlockPage, error) {
+	filter := bson.M{"blocker_id": userID}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if opts.Offset > 0 {
+		findOptions.SetSkip(int64(opts.Offset))
+	}
+	if opts.Limit > 0 {
+		findOptions.SetLimit(int64(opts.Limit))
+	}
+
+	cursor, err := s.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	blocks := []models.Block{}
+	if err := cursor.All(ctx, &blocks); err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockPage{
+		Blocks:     blocks,
+		TotalCount: totalCount,
+	}, nil
+}
+
+func (s *MongoBlockStore) RelatedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{
+		"$or": []bson.M{
+			{"blocker_id": userID},
+			{"blocked_id": userID},
+		},
+	}, options.Find().SetProjection(bson.M{
+		"blocker_id": 1,
+		"blocked_id": 1,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var blocks []models.Block
+	if err := cursor.All(ctx, &blocks); err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if block.BlockerID == userID {
+			userIDs = append(userIDs, block.BlockedID)
+		} else {
+			userIDs = append(userIDs, block.BlockerID)
+		}
+	}
+	return userIDs, nil
+}
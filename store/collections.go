@@ -0,0 +1,22 @@
+package store
+
+// 各存储使用的MongoDB集合名，关注关系集合名由配置文件指定
+const (
+	CountersCollection           = "follow_counters"
+	OutboxCollection             = "follow_outbox"
+	RequestsCollection           = "follow_requests"
+	SettingsCollection           = "user_settings"
+	BlocksCollection             = "blocks"
+	MutesCollection              = "mutes"
+	IdempotencyCollection        = "idempotency_keys"
+	WebhooksCollection           = "webhooks"
+	WebhookDeliveriesCollection  = "webhook_deliveries"
+	WebhookDeadLettersCollection = "webhook_dead_letters"
+	UserProfilesCollection       = "user_profiles"
+	DismissalsCollection         = "suggestion_dismissals"
+	FollowStatsCollection        = "follow_stats_daily"
+	FollowHistoryCollection      = "follow_history"
+	ListsCollection              = "follow_lists"
+	ListMembersCollection        = "follow_list_members"
+	AccountDeletionsCollection   = "account_deletions"
+)
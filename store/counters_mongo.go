@@ -0,0 +1,207 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// incrementCounters 调整关注方的关注数和被关注方的粉丝数，需在事务中调用
+func (s *MongoFollowStore) incrementCounters(ctx context.Context, followerID, followingID string, delta int64) error {
+	now := time.Now()
+	_, err := s.counters.BulkWrite(ctx, []mongo.WriteModel{
+		mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": followerID}).
+			SetUpdate(bson.M{
+				"$inc": bson.M{"following_count": delta},
+				"$set": bson.M{"updated_at": now},
+			}).
+			SetUpsert(true),
+		mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": followingID}).
+			SetUpdate(bson.M{
+				"$inc": bson.M{"followers_count": delta},
+				"$set": bson.M{"updated_at": now},
+			}).
+			SetUpsert(true),
+	})
+	return err
+}
+
+// Counts 从冗余计数中读取关注数和粉丝数，计数不存在时（对账任务尚未运行）回退到统计关注关系
+func (s *MongoFollowStore) Counts(ctx context.Context, userID string) (*FollowCounts, error) {
+	var counter models.FollowCounter
+	err := s.counters.FindOne(ctx, bson.M{"_id": userID}).Decode(&counter)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return s.countFollows(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 计数为负说明与关注关系不一致，记录日志以便发现问题，返回时按0处理，由对账任务修正
+	if counter.FollowersCount < 0 || counter.FollowingCount < 0 {
+		log.Printf("用户 %s 的冗余计数为负（粉丝数 %d，关注数 %d），等待对账修正", userID, counter.FollowersCount, counter.FollowingCount)
+	}
+	return &FollowCounts{
+		FollowersCount: max(counter.FollowersCount, 0),
+		FollowingCount: max(counter.FollowingCount, 0),
+	}, nil
+}
+
+// countFollows 直接统计关注关系得到关注数和粉丝数
+func (s *MongoFollowStore) countFollows(ctx context.Context, userID string) (*FollowCounts, error) {
+	// 获取关注数量
+	followingCount, err := s.collection.CountDocuments(ctx, bson.M{
+		"follower_id": userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取粉丝数量
+	followersCount, err := s.collection.CountDocuments(ctx, bson.M{
+		"following_id": userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FollowCounts{
+		FollowersCount: followersCount,
+		FollowingCount: followingCount,
+	}, nil
+}
+
+// ReconcileCounters 根据关注关系重新计算所有用户的冗余计数，返回被修正的计数文档数。
+// 先按分组统计找出可能不一致的用户，再逐个在事务中重新统计并写入：分组统计与读取计数之间发生的关注变更
+// 会使统计结果过时，直接覆盖会丢失这些变更，而事务内的统计与关注/取消关注的计数更新互相冲突并重试，写入的计数总是准确的
+func (s *MongoFollowStore) ReconcileCounters(ctx context.Context) (int64, error) {
+	following, err := s.groupCount(ctx, "$follower_id")
+	if err != nil {
+		return 0, err
+	}
+	followers, err := s.groupCount(ctx, "$following_id")
+	if err != nil {
+		return 0, err
+	}
+
+	expected := make(map[string]*models.FollowCounter, len(following)+len(followers))
+	counterFor := func(userID string) *models.FollowCounter {
+		counter, ok := expected[userID]
+		if !ok {
+			counter = &models.FollowCounter{UserID: userID}
+			expected[userID] = counter
+		}
+		return counter
+	}
+	for userID, count := range following {
+		counterFor(userID).FollowingCount = count
+	}
+	for userID, count := range followers {
+		counterFor(userID).FollowersCount = count
+	}
+
+	// 已有计数中没有任何关注关系的用户应归零
+	cursor, err := s.counters.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	suspects := make([]string, 0)
+	for cursor.Next(ctx) {
+		var current models.FollowCounter
+		if err := cursor.Decode(&current); err != nil {
+			return 0, err
+		}
+		want, ok := expected[current.UserID]
+		if !ok {
+			want = &models.FollowCounter{UserID: current.UserID}
+		}
+		delete(expected, current.UserID)
+
+		if current.FollowersCount != want.FollowersCount || current.FollowingCount != want.FollowingCount {
+			suspects = append(suspects, current.UserID)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, err
+	}
+	// 尚未建立计数的用户
+	for userID := range expected {
+		suspects = append(suspects, userID)
+	}
+
+	var fixed int64
+	for _, userID := range suspects {
+		changed, err := s.reconcileUser(ctx, userID)
+		if err != nil {
+			return fixed, err
+		}
+		if changed {
+			fixed++
+		}
+	}
+	return fixed, nil
+}
+
+// reconcileUser 在事务中重新统计userID的关注数和粉丝数，与计数不一致时写入，返回是否修正了计数
+func (s *MongoFollowStore) reconcileUser(ctx context.Context, userID string) (bool, error) {
+	var changed bool
+	err := s.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		changed = false
+		counts, err := s.countFollows(sessCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		var current models.FollowCounter
+		err = s.counters.FindOne(sessCtx, bson.M{"_id": userID}).Decode(&current)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		if err == nil && current.FollowersCount == counts.FollowersCount && current.FollowingCount == counts.FollowingCount {
+			return nil
+		}
+
+		_, err = s.counters.UpdateOne(sessCtx, bson.M{"_id": userID}, bson.M{"$set": bson.M{
+			"followers_count": counts.FollowersCount,
+			"following_count": counts.FollowingCount,
+			"updated_at":      time.Now(),
+		}}, options.Update().SetUpsert(true))
+		changed = err == nil
+		return err
+	})
+	return changed, err
+}
+
+// groupCount 按字段分组统计关注关系数量
+func (s *MongoFollowStore) groupCount(ctx context.Context, field string) (map[string]int64, error) {
+	cursor, err := s.collection.Aggregate(ctx, []bson.M{
+		{"$group": bson.M{"_id": field, "count": bson.M{"$sum": 1}}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(map[string]int64)
+	for cursor.Next(ctx) {
+		var group struct {
+			UserID string `bson:"_id"`
+			Count  int64  `bson:"count"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return nil, err
+		}
+		counts[group.UserID] = group.Count
+	}
+	return counts, cursor.Err()
+}
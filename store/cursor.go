@@ -0,0 +1,83 @@
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"followservice/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor 表示分页游标无法解析
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor 定义基于(created_at, _id)的分页位置，列表按该组合倒序排列，
+// 下一页从严格小于该位置的记录开始，不受翻页期间新增或删除记录的影响
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// EncodeCursor 将分页位置编码为不透明的游标字符串
+func EncodeCursor(createdAt time.Time, id string) string {
+	raw := fmt.Sprintf("%d:%s", createdAt.UnixNano(), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor 解析EncodeCursor生成的游标字符串
+func DecodeCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{
+		CreatedAt: time.Unix(0, unixNano),
+		ID:        id,
+	}, nil
+}
+
+// before 判断(createdAt, id)在倒序排列中是否位于游标之后
+func (c *Cursor) before(createdAt time.Time, id string) bool {
+	if !createdAt.Equal(c.CreatedAt) {
+		return createdAt.Before(c.CreatedAt)
+	}
+	return id < c.ID
+}
+
+// after 判断(createdAt, id)在正序排列中是否位于游标之后
+func (c *Cursor) after(createdAt time.Time, id string) bool {
+	if !createdAt.Equal(c.CreatedAt) {
+		return createdAt.After(c.CreatedAt)
+	}
+	return id > c.ID
+}
+
+// follows 判断(createdAt, id)是否位于游标之后，ascending表示列表按正序排列
+func (c *Cursor) follows(createdAt time.Time, id string, ascending bool) bool {
+	if ascending {
+		return c.after(createdAt, id)
+	}
+	return c.before(createdAt, id)
+}
+
+// trimPage 去掉为判断下一页而多取的记录，并生成下一页的游标
+func trimPage(follows []models.Follow, opts ListOptions) ([]models.Follow, string) {
+	if opts.Limit <= 0 || len(follows) <= opts.Limit {
+		return follows, ""
+	}
+	follows = follows[:opts.Limit]
+	last := follows[len(follows)-1]
+	return follows, EncodeCursor(last.CreatedAt, last.ID)
+}
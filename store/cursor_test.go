@@ -0,0 +1,51 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		id        string
+	}{
+		{name: "UTC时间", createdAt: testBase, id: "f1"},
+		{name: "纳秒精度", createdAt: testBase.Add(123456789 * time.Nanosecond), id: "0f6d2b5e-0c1a-4f7e-9b61-6f1f5d1e2a3b"},
+		{name: "ID包含冒号", createdAt: testBase, id: "a:b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := DecodeCursor(EncodeCursor(tt.createdAt, tt.id))
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if !cursor.CreatedAt.Equal(tt.createdAt) || cursor.ID != tt.id {
+				t.Errorf("cursor = (%v, %q), want (%v, %q)", cursor.CreatedAt, cursor.ID, tt.createdAt, tt.id)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "不是base64", token: "!!!"},
+		{name: "缺少分隔符", token: "MTIz"},      // "123"
+		{name: "缺少ID", token: "MTIzOg"},     // "123:"
+		{name: "时间不是数字", token: "YWJjOmYx"}, // "abc:f1"
+		{name: "空字符串", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.token); err != ErrInvalidCursor {
+				t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", tt.token, err)
+			}
+		})
+	}
+}
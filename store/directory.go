@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+)
+
+// DirectoryQuery 定义查询用户资料的参数
+type DirectoryQuery struct {
+	Limit          int
+	ExcludeUserIDs []string
+}
+
+// UserDirectory 定义用户资料副本的存储接口
+type UserDirectory interface {
+	// UpsertProfiles 写入或更新用户资料
+	UpsertProfiles(ctx context.Context, profiles []models.UserProfile) error
+	// NearbyUsers 返回city中的用户，在线用户在前，其余按最近在线时间倒序。
+	// 在线状态在onlineSince之前更新的用户视为不在线，见models.UserProfile.OnlineAsOf
+	NearbyUsers(ctx context.Context, city string, onlineSince time.Time, query DirectoryQuery) ([]models.UserProfile, error)
+	// NewUsers 按注册时间倒序返回since之后注册的用户
+	NewUsers(ctx context.Context, since time.Time, query DirectoryQuery) ([]models.UserProfile, error)
+	// DeleteProfile 删除userID的资料副本，在用户注销账号时调用
+	DeleteProfile(ctx context.Context, userID string) error
+}
+
+// DismissalStore 定义推荐反馈的存储接口
+type DismissalStore interface {
+	// Dismiss 记录userID对dismissedID不感兴趣，重复调用不会报错
+	Dismiss(ctx context.Context, userID, dismissedID string) error
+	// DismissedUserIDs 返回userID标记过不感兴趣的所有用户
+	DismissedUserIDs(ctx context.Context, userID string) ([]string, error)
+	// DeleteUserDismissals 删除userID标记的以及其他用户对userID标记的不感兴趣，在用户注销账号时调用
+	DeleteUserDismissals(ctx context.Context, userID string) error
+}
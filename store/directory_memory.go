@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryUserDirectory 基于内存的用户资料副本
+type MemoryUserDirectory struct {
+	mu       sync.RWMutex
+	profiles map[string]models.UserProfile
+}
+
+func NewMemoryUserDirectory() *MemoryUserDirectory {
+	return &MemoryUserDirectory{
+		profiles: make(map[string]models.UserProfile),
+	}
+}
+
+func (s *MemoryUserDirectory) UpsertProfiles(ctx context.Context, profiles []models.UserProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, profile := range profiles {
+		s.profiles[profile.UserID] = profile
+	}
+	return nil
+}
+
+func (s *MemoryUserDirectory) DeleteProfile(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.profiles, userID)
+	return nil
+}
+
+func (s *MemoryUserDirectory) NearbyUsers(ctx context.Context, city string, onlineSince time.Time, query DirectoryQuery) ([]models.UserProfile, error) {
+	return s.find(func(p models.UserProfile) bool {
+		return p.City == city
+	}, func(a, b models.UserProfile) bool {
+		if aOnline, bOnline := a.OnlineAsOf(onlineSince), b.OnlineAsOf(onlineSince); aOnline != bOnline {
+			return aOnline
+		}
+		if !a.LastOnlineAt.Equal(b.LastOnlineAt) {
+			return a.LastOnlineAt.After(b.LastOnlineAt)
+		}
+		return a.UserID < b.UserID
+	}, query), nil
+}
+
+func (s *MemoryUserDirectory) NewUsers(ctx context.Context, since time.Time, query DirectoryQuery) ([]models.UserProfile, error) {
+	return s.find(func(p models.UserProfile) bool {
+		return !p.JoinedAt.Before(since)
+	}, func(a, b models.UserProfile) bool {
+		if !a.JoinedAt.Equal(b.JoinedAt) {
+			return a.JoinedAt.After(b.JoinedAt)
+		}
+		return a.UserID < b.UserID
+	}, query), nil
+}
+
+func (s *MemoryUserDirectory) find(match func(models.UserProfile) bool, less func(a, b models.UserProfile) bool, query DirectoryQuery) []models.UserProfile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	excluded := toSet(query.ExcludeUserIDs)
+	profiles := make([]models.UserProfile, 0)
+	for _, profile := range s.profiles {
+		if match(profile) && !excluded[profile.UserID] {
+			profiles = append(profiles, profile)
+		}
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return less(profiles[i], profiles[j])
+	})
+	return paginate(profiles, ListOptions{Limit: query.Limit})
+}
+
+// MemoryDismissalStore 基于内存的推荐反馈存储
+type MemoryDismissalStore struct {
+	mu         sync.RWMutex
+	dismissals map[string]map[string]bool
+}
+
+func NewMemoryDismissalStore() *MemoryDismissalStore {
+	return &MemoryDismissalStore{
+		dismissals: make(map[string]map[string]bool),
+	}
+}
+
+func (s *MemoryDismissalStore) Dismiss(ctx context.Context, userID, dismissedID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dismissals[userID] == nil {
+		s.dismissals[userID] = make(map[string]bool)
+	}
+	s.dismissals[userID][dismissedID] = true
+	return nil
+}
+
+func (s *MemoryDismissalStore) DismissedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	userIDs := make([]string, 0, len(s.dismissals[userID]))
+	for dismissedID := range s.dismissals[userID] {
+		userIDs = append(userIDs, dismissedID)
+	}
+	return userIDs, nil
+}
+
+func (s *MemoryDismissalStore) DeleteUserDismissals(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.dismissals, userID)
+	for _, dismissed := range s.dismissals {
+		delete(dismissed, userID)
+	}
+	return nil
+}
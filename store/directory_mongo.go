@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoUserDirectory 基于MongoDB的用户资料副本
+type MongoUserDirectory struct {
+	collection *mongo.Collection
+}
+
+func NewMongoUserDirectory(collection *mongo.Collection) *MongoUserDirectory {
+	return &MongoUserDirectory{
+		collection: collection,
+	}
+}
+
+func (s *MongoUserDirectory) UpsertProfiles(ctx context.Context, profiles []models.UserProfile) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(profiles))
+	for _, profile := range profiles {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": profile.UserID}).
+			SetReplacement(profile).
+			SetUpsert(true))
+	}
+	_, err := s.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (s *MongoUserDirectory) DeleteProfile(ctx context.Context, userID string) error {
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": userID})
+	return err
+}
+
+func (s *MongoUserDirectory) NearbyUsers(ctx context.Context, city string, onlineSince time.Time, query DirectoryQuery) ([]models.UserProfile, error) {
+	filter := bson.M{"city": city}
+	if len(query.ExcludeUserIDs) > 0 {
+		filter["_id"] = bson.M{"$nin": query.ExcludeUserIDs}
+	}
+	// is_online在用户下线后不会被清除，只有onlineSince之后更新的在线状态参与排序
+	stages := []bson.M{
+		{"$match": filter},
+		{"$addFields": bson.M{"online": bson.M{"$and": bson.A{
+			"$is_online",
+			bson.M{"$gte": bson.A{"$updated_at", onlineSince}},
+		}}}},
+		{"$sort": bson.D{
+			{Key: "online", Value: -1},
+			{Key: "last_online_at", Value: -1},
+			{Key: "_id", Value: 1},
+		}},
+	}
+	if query.Limit > 0 {
+		stages = append(stages, bson.M{"$limit": query.Limit})
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, stages)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	profiles := []models.UserProfile{}
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (s *MongoUserDirectory) NewUsers(ctx context.Context, since time.Time, query DirectoryQuery) ([]models.UserProfile, error) {
+	return s.find(ctx, bson.M{"joined_at": bson.M{"$gte": since}}, bson.D{
+		{Key: "joined_at", Value: -1},
+		{Key: "_id", Value: 1},
+	}, query)
+}
+
+func (s *MongoUserDirectory) find(ctx context.Context, filter bson.M, sort bson.D, query DirectoryQuery) ([]models.UserProfile, error) {
+	if len(query.ExcludeUserIDs) > 0 {
+		filter["_id"] = bson.M{"$nin": query.ExcludeUserIDs}
+	}
+	findOptions := options.Find().SetSort(sort)
+	if query.Limit > 0 {
+		findOptions.SetLimit(int64(query.Limit))
+	}
+
+	cursor, err := s.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	profiles := []models.UserProfile{}
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// MongoDismissalStore 基于MongoDB的推荐反馈存储
+type MongoDismissalStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoDismissalStore(collection *mongo.Collection) *MongoDismissalStore {
+	return &MongoDismissalStore{
+		collection: collection,
+	}
+}
+
+func (s *MongoDismissalStore) Dismiss(ctx context.Context, userID, dismissedID string) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{
+		"user_id":      userID,
+		"dismissed_id": dismissedID,
+	}, bson.M{
+		"$setOnInsert": bson.M{
+			"_id":        uuid.New().String(),
+			"created_at": time.Now(),
+		},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (s *MongoDismissalStore) DismissedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{"user_id": userID}, options.Find().SetProjection(bson.M{
+		"dismissed_id": 1,
+		"_id":          0,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var dismissals []models.SuggestionDismissal
+	if err := cursor.All(ctx, &dismissals); err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(dismissals))
+	for _, dismissal := range dismissals {
+		userIDs = append(userIDs, dismissal.DismissedID)
+	}
+	return userIDs, nil
+}
+
+func (s *MongoDismissalStore) DeleteUserDismissals(ctx context.Context, userID string) error {
+	_, err := s.collection.DeleteMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"user_id": userID},
+			{"dismissed_id": userID},
+		},
+	})
+	return err
+}
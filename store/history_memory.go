@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+)
+
+func (s *MemoryFollowStore) ListUnfollowers(ctx context.Context, userID string, opts ListOptions) (*HistoryPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	excluded := toSet(opts.ExcludeUserIDs)
+	entries := make([]models.FollowHistory, 0)
+	for key, history := range s.latest {
+		if key.followingID != userID || excluded[key.followerID] || (opts.ExcludeRefollowed && history.Refollowed) {
+			continue
+		}
+		if opts.Cursor != nil && !opts.Cursor.before(*history.UnfollowedAt, history.ID) {
+			continue
+		}
+		entries = append(entries, *history)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return unfollowedAfter(&entries[i], &entries[j])
+	})
+
+	// 多取一条记录用于判断是否存在下一页
+	fetch := ListOptions{Limit: opts.Limit}
+	if fetch.Limit > 0 {
+		fetch.Limit++
+	}
+	return trimHistoryPage(paginate(entries, fetch), opts), nil
+}
+
+// unfollowedAfter 判断a是否在b之后结束，结束时间相同时按ID比较
+func unfollowedAfter(a, b *models.FollowHistory) bool {
+	if !a.UnfollowedAt.Equal(*b.UnfollowedAt) {
+		return a.UnfollowedAt.After(*b.UnfollowedAt)
+	}
+	return a.ID > b.ID
+}
+
+func (s *MemoryFollowStore) RelationshipHistory(ctx context.Context, followerID, followingID string) ([]models.FollowHistory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]models.FollowHistory, 0)
+	for _, history := range s.history {
+		if history.FollowerID == followerID && history.FollowingID == followingID {
+			entries = append(entries, *history)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].FollowedAt.Equal(entries[j].FollowedAt) {
+			return entries[i].FollowedAt.After(entries[j].FollowedAt)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+	return entries, nil
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"reflect"
+	"testing"
+)
+
+// churn 让followerID依次关注并取消关注userID times次
+func churn(t *testing.T, s *MemoryFollowStore, followerID, userID string, times int) {
+	t.Helper()
+	for i := 0; i < times; i++ {
+		if _, err := s.Follow(context.Background(), followerID, userID); err != nil {
+			t.Fatalf("Follow() error = %v", err)
+		}
+		if err := s.Unfollow(context.Background(), followerID, userID, models.UnfollowReasonUser); err != nil {
+			t.Fatalf("Unfollow() error = %v", err)
+		}
+	}
+}
+
+func unfollowerIDs(page *HistoryPage) []string {
+	ids := make([]string, 0, len(page.Entries))
+	for _, entry := range page.Entries {
+		ids = append(ids, entry.FollowerID)
+	}
+	return ids
+}
+
+// TestMemoryListUnfollowersPaging 同一用户多次取消关注只按最近一次出现一次，翻页时不会重复出现
+func TestMemoryListUnfollowersPaging(t *testing.T) {
+	s := NewMemoryFollowStore()
+	churn(t, s, "a", "u", 2)
+	churn(t, s, "b", "u", 1)
+	churn(t, s, "a", "u", 1)
+	churn(t, s, "c", "u", 3)
+	churn(t, s, "u", "a", 1)
+
+	var got []string
+	opts := ListOptions{Limit: 2}
+	for {
+		page, err := s.ListUnfollowers(context.Background(), "u", opts)
+		if err != nil {
+			t.Fatalf("ListUnfollowers() error = %v", err)
+		}
+		got = append(got, unfollowerIDs(page)...)
+		if page.NextCursor == "" {
+			break
+		}
+		if opts.Cursor, err = DecodeCursor(page.NextCursor); err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+	}
+	if !reflect.DeepEqual(got, []string{"c", "a", "b"}) {
+		t.Errorf("unfollowers = %v, want [c a b]", got)
+	}
+}
+
+func TestMemoryListUnfollowersRefollowed(t *testing.T) {
+	s := NewMemoryFollowStore()
+	churn(t, s, "a", "u", 1)
+	churn(t, s, "b", "u", 1)
+	if _, err := s.Follow(context.Background(), "b", "u"); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+
+	tests := []struct {
+		name              string
+		excludeRefollowed bool
+		want              []string
+	}{
+		{name: "包含重新关注的用户", want: []string{"b", "a"}},
+		{name: "跳过重新关注的用户", excludeRefollowed: true, want: []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.ListUnfollowers(context.Background(), "u", ListOptions{ExcludeRefollowed: tt.excludeRefollowed})
+			if err != nil {
+				t.Fatalf("ListUnfollowers() error = %v", err)
+			}
+			if got := unfollowerIDs(page); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unfollowers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 再次取消关注后b重新出现，并且不再被视为重新关注
+	if err := s.Unfollow(context.Background(), "b", "u", models.UnfollowReasonUser); err != nil {
+		t.Fatalf("Unfollow() error = %v", err)
+	}
+	page, err := s.ListUnfollowers(context.Background(), "u", ListOptions{ExcludeRefollowed: true})
+	if err != nil {
+		t.Fatalf("ListUnfollowers() error = %v", err)
+	}
+	if got := unfollowerIDs(page); !reflect.DeepEqual(got, []string{"b", "a"}) {
+		t.Errorf("unfollowers = %v, want [b a]", got)
+	}
+}
+
+// TestMemoryHistoryLatestFlag 每对用户只有最近一次结束的记录带有Latest标记，审计历史保留所有记录
+func TestMemoryHistoryLatestFlag(t *testing.T) {
+	s := NewMemoryFollowStore()
+	churn(t, s, "a", "u", 3)
+
+	entries, err := s.RelationshipHistory(context.Background(), "a", "u")
+	if err != nil {
+		t.Fatalf("RelationshipHistory() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d history entries, want 3", len(entries))
+	}
+	for i, entry := range entries {
+		if want := i == 0; entry.Latest != want {
+			t.Errorf("entry %d Latest = %v, want %v", i, entry.Latest, want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// openHistory 为新建的关注关系写入历史记录，并将这对用户最近一次结束的关注关系标记为已重新关注，需在事务中调用
+func (s *MongoFollowStore) openHistory(ctx context.Context, follow *models.Follow) error {
+	_, err := s.history.InsertOne(ctx, models.FollowHistory{
+		ID:          follow.ID,
+		FollowerID:  follow.FollowerID,
+		FollowingID: follow.FollowingID,
+		FollowedAt:  follow.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.history.UpdateOne(ctx, bson.M{
+		"follower_id":  follow.FollowerID,
+		"following_id": follow.FollowingID,
+		"latest":       true,
+	}, bson.M{"$set": bson.M{"refollowed": true}})
+	return err
+}
+
+// closeHistory 记录关注关系的结束时间和原因，并将其标记为这对用户最近一次结束的关注关系，需在事务中调用。
+// 历史记录上线前建立的关注关系没有对应的记录，此时直接写入完整的记录
+func (s *MongoFollowStore) closeHistory(ctx context.Context, follow models.Follow, reason models.UnfollowReason, at time.Time) error {
+	_, err := s.history.UpdateMany(ctx, bson.M{
+		"follower_id":  follow.FollowerID,
+		"following_id": follow.FollowingID,
+		"latest":       true,
+		"_id":          bson.M{"$ne": follow.ID},
+	}, bson.M{"$unset": bson.M{"latest": "", "refollowed": ""}})
+	if err != nil {
+		return err
+	}
+	_, err = s.history.UpdateOne(ctx, bson.M{
+		"_id": follow.ID,
+	}, bson.M{
+		"$set": bson.M{
+			"unfollowed_at": at,
+			"reason":        reason,
+			"latest":        true,
+		},
+		"$setOnInsert": bson.M{
+			"follower_id":  follow.FollowerID,
+			"following_id": follow.FollowingID,
+			"followed_at":  follow.CreatedAt,
+		},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (s *MongoFollowStore) ListUnfollowers(ctx context.Context, userID string, opts ListOptions) (*HistoryPage, error) {
+	// 每个用户只有一条latest记录，游标和数量限制可以直接使用索引顺序，不需要先去重
+	filter := bson.M{
+		"following_id": userID,
+		"latest":       true,
+	}
+	if len(opts.ExcludeUserIDs) > 0 {
+		filter["follower_id"] = bson.M{"$nin": opts.ExcludeUserIDs}
+	}
+	if opts.ExcludeRefollowed {
+		filter["refollowed"] = bson.M{"$ne": true}
+	}
+	if opts.Cursor != nil {
+		filter["$or"] = []bson.M{
+			{"unfollowed_at": bson.M{"$lt": opts.Cursor.CreatedAt}},
+			{"unfollowed_at": opts.Cursor.CreatedAt, "_id": bson.M{"$lt": opts.Cursor.ID}},
+		}
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "unfollowed_at", Value: -1}, {Key: "_id", Value: -1}})
+	if opts.Limit > 0 {
+		findOptions.SetLimit(int64(opts.Limit + 1))
+	}
+	cursor, err := s.history.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.FollowHistory{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return trimHistoryPage(entries, opts), nil
+}
+
+func (s *MongoFollowStore) RelationshipHistory(ctx context.Context, followerID, followingID string) ([]models.FollowHistory, error) {
+	cursor, err := s.history.Find(ctx, bson.M{
+		"follower_id":  followerID,
+		"following_id": followingID,
+	}, options.Find().SetSort(bson.D{{Key: "followed_at", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.FollowHistory{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// trimHistoryPage 去掉为判断下一页而多取的记录，并以最后一条记录的取消关注时间生成下一页的游标
+func trimHistoryPage(entries []models.FollowHistory, opts ListOptions) *HistoryPage {
+	page := &HistoryPage{Entries: entries}
+	if opts.Limit <= 0 || len(entries) <= opts.Limit {
+		return page
+	}
+	page.Entries = entries[:opts.Limit]
+	last := page.Entries[len(page.Entries)-1]
+	page.NextCursor = EncodeCursor(*last.UnfollowedAt, last.ID)
+	return page
+}
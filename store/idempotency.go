@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+)
+
+// DefaultIdempotencyTTL 未配置有效期时幂等键的保留时间
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyStore 定义幂等键的存储接口，记录在一段时间后自动过期
+type IdempotencyStore interface {
+	// Reserve 为record.ID占位。占位成功时返回nil；该键已被占用时返回已有记录，
+	// 但创建超过staleAfter仍未完成的占位会被视为已放弃并由本次请求接管
+	Reserve(ctx context.Context, record models.IdempotencyRecord, staleAfter time.Duration) (*models.IdempotencyRecord, error)
+	// Complete 保存请求的响应
+	Complete(ctx context.Context, id string, statusCode int, body []byte) error
+	// Release 删除占位，使客户端可以用同一个键重试
+	Release(ctx context.Context, id string) error
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sync"
+	"time"
+)
+
+// MemoryIdempotencyStore 基于内存的幂等键存储，过期记录在访问时清理
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	records map[string]models.IdempotencyRecord
+}
+
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &MemoryIdempotencyStore{
+		ttl:     ttl,
+		records: make(map[string]models.IdempotencyRecord),
+	}
+}
+
+func (s *MemoryIdempotencyStore) Reserve(ctx context.Context, record models.IdempotencyRecord, staleAfter time.Duration) (*models.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	existing, ok := s.records[record.ID]
+	if ok && now.Sub(existing.CreatedAt) < s.ttl && (existing.Completed || now.Sub(existing.CreatedAt) < staleAfter) {
+		return &existing, nil
+	}
+	s.records[record.ID] = record
+	return nil, nil
+}
+
+func (s *MemoryIdempotencyStore) Complete(ctx context.Context, id string, statusCode int, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[id]; ok {
+		record.Completed = true
+		record.StatusCode = statusCode
+		record.Body = body
+		s.records[id] = record
+	}
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Release(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, id)
+	return nil
+}
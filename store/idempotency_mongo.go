@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MongoIdempotencyStore 基于MongoDB的幂等键存储，过期记录由created_at上的TTL索引清理（见migrations.Indexes）
+type MongoIdempotencyStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoIdempotencyStore(collection *mongo.Collection) *MongoIdempotencyStore {
+	return &MongoIdempotencyStore{
+		collection: collection,
+	}
+}
+
+func (s *MongoIdempotencyStore) Reserve(ctx context.Context, record models.IdempotencyRecord, staleAfter time.Duration) (*models.IdempotencyRecord, error) {
+	_, err := s.collection.InsertOne(ctx, record)
+	if err == nil {
+		return nil, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	// 接管超时未完成的占位
+	result, err := s.collection.ReplaceOne(ctx, bson.M{
+		"_id":        record.ID,
+		"completed":  false,
+		"created_at": bson.M{"$lt": time.Now().Add(-staleAfter)},
+	}, record)
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount > 0 {
+		return nil, nil
+	}
+
+	var existing models.IdempotencyRecord
+	if err := s.collection.FindOne(ctx, bson.M{"_id": record.ID}).Decode(&existing); err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+func (s *MongoIdempotencyStore) Complete(ctx context.Context, id string, statusCode int, body []byte) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$set": bson.M{
+			"completed":   true,
+			"status_code": statusCode,
+			"body":        body,
+		},
+	})
+	return err
+}
+
+func (s *MongoIdempotencyStore) Release(ctx context.Context, id string) error {
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
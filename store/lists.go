@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+)
+
+const (
+	// MaxListsPerUser 每个用户最多创建的分组数
+	MaxListsPerUser = 20
+	// MaxListMembers 每个分组最多包含的成员数
+	MaxListMembers = 1000
+)
+
+var (
+	// ErrListNotFound 表示分组不存在
+	ErrListNotFound = errors.New("list not found")
+	// ErrListNameTaken 表示用户已有同名分组
+	ErrListNameTaken = errors.New("list name taken")
+	// ErrTooManyLists 表示用户的分组数已达到MaxListsPerUser
+	ErrTooManyLists = errors.New("too many lists")
+	// ErrListFull 表示分组成员数已达到MaxListMembers
+	ErrListFull = errors.New("list full")
+	// ErrAlreadyInList 表示用户已在分组中
+	ErrAlreadyInList = errors.New("already in list")
+	// ErrNotInList 表示用户不在分组中
+	ErrNotInList = errors.New("not in list")
+)
+
+// ListMemberPage 定义一页分组成员，NextCursor为空表示没有下一页
+type ListMemberPage struct {
+	Members    []models.FollowListMember
+	NextCursor string
+}
+
+// ListStore 定义关注分组的存储接口，成员是否为所有者关注的用户由调用方检查
+type ListStore interface {
+	// CreateList 为ownerID创建名为name的分组
+	CreateList(ctx context.Context, ownerID, name string) (*models.FollowList, error)
+	// GetList 返回分组，不统计成员数
+	GetList(ctx context.Context, listID string) (*models.FollowList, error)
+	// ListsByOwner 按创建时间返回ownerID的所有分组及其成员数
+	ListsByOwner(ctx context.Context, ownerID string) ([]models.FollowList, error)
+	// RenameList 修改分组名称
+	RenameList(ctx context.Context, listID, name string) error
+	// DeleteList 删除分组及其所有成员
+	DeleteList(ctx context.Context, listID string) error
+	// AddMember 将memberID加入ownerID的分组listID
+	AddMember(ctx context.Context, ownerID, listID, memberID string) error
+	// RemoveMember 将memberID移出分组
+	RemoveMember(ctx context.Context, listID, memberID string) error
+	// ListMembers 按加入时间倒序返回分组成员，仅使用opts中的Limit和Cursor
+	ListMembers(ctx context.Context, listID string, opts ListOptions) (*ListMemberPage, error)
+	// MemberIDs 返回分组所有成员的用户ID
+	MemberIDs(ctx context.Context, listID string) ([]string, error)
+	// IsMember 判断memberID是否在分组中
+	IsMember(ctx context.Context, listID, memberID string) (bool, error)
+	// RemoveFromOwnerLists 将memberID移出ownerID的所有分组，在ownerID不再关注memberID时调用
+	RemoveFromOwnerLists(ctx context.Context, ownerID, memberID string) error
+	// DeleteOwnerLists 删除ownerID的所有分组
+	DeleteOwnerLists(ctx context.Context, ownerID string) error
+	// RemoveFromAllLists 将memberID移出所有用户的分组，在用户注销账号时调用
+	RemoveFromAllLists(ctx context.Context, memberID string) error
+}
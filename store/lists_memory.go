@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryListStore 基于内存的关注分组存储
+type MemoryListStore struct {
+	mu      sync.RWMutex
+	lists   map[string]models.FollowList
+	members map[string]map[string]models.FollowListMember // list_id -> member_id -> 成员
+}
+
+func NewMemoryListStore() *MemoryListStore {
+	return &MemoryListStore{
+		lists:   make(map[string]models.FollowList),
+		members: make(map[string]map[string]models.FollowListMember),
+	}
+}
+
+func (s *MemoryListStore) CreateList(ctx context.Context, ownerID, name string) (*models.FollowList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, list := range s.lists {
+		if list.OwnerID != ownerID {
+			continue
+		}
+		if list.Name == name {
+			return nil, ErrListNameTaken
+		}
+		count++
+	}
+	if count >= MaxListsPerUser {
+		return nil, ErrTooManyLists
+	}
+
+	now := time.Now()
+	list := models.FollowList{
+		ID:        uuid.New().String(),
+		OwnerID:   ownerID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.lists[list.ID] = list
+	return &list, nil
+}
+
+func (s *MemoryListStore) GetList(ctx context.Context, listID string) (*models.FollowList, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list, ok := s.lists[listID]
+	if !ok {
+		return nil, ErrListNotFound
+	}
+	return &list, nil
+}
+
+func (s *MemoryListStore) ListsByOwner(ctx context.Context, ownerID string) ([]models.FollowList, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lists := make([]models.FollowList, 0)
+	for _, list := range s.lists {
+		if list.OwnerID == ownerID {
+			list.MemberCount = int64(len(s.members[list.ID]))
+			lists = append(lists, list)
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		if !lists[i].CreatedAt.Equal(lists[j].CreatedAt) {
+			return lists[i].CreatedAt.Before(lists[j].CreatedAt)
+		}
+		return lists[i].ID < lists[j].ID
+	})
+	return lists, nil
+}
+
+func (s *MemoryListStore) RenameList(ctx context.Context, listID, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, ok := s.lists[listID]
+	if !ok {
+		return ErrListNotFound
+	}
+	for _, other := range s.lists {
+		if other.OwnerID == list.OwnerID && other.ID != listID && other.Name == name {
+			return ErrListNameTaken
+		}
+	}
+	list.Name = name
+	list.UpdatedAt = time.Now()
+	s.lists[listID] = list
+	return nil
+}
+
+func (s *MemoryListStore) DeleteList(ctx context.Context, listID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lists[listID]; !ok {
+		return ErrListNotFound
+	}
+	delete(s.lists, listID)
+	delete(s.members, listID)
+	return nil
+}
+
+func (s *MemoryListStore) AddMember(ctx context.Context, ownerID, listID, memberID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members := s.members[listID]
+	if members == nil {
+		members = make(map[string]models.FollowListMember)
+		s.members[listID] = members
+	}
+	if _, ok := members[memberID]; ok {
+		return ErrAlreadyInList
+	}
+	if len(members) >= MaxListMembers {
+		return ErrListFull
+	}
+	members[memberID] = models.FollowListMember{
+		ID:        uuid.New().String(),
+		ListID:    listID,
+		OwnerID:   ownerID,
+		MemberID:  memberID,
+		CreatedAt: time.Now(),
+	}
+	return nil
+}
+
+func (s *MemoryListStore) RemoveMember(ctx context.Context, listID, memberID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.members[listID][memberID]; !ok {
+		return ErrNotInList
+	}
+	delete(s.members[listID], memberID)
+	return nil
+}
+
+func (s *MemoryListStore) ListMembers(ctx context.Context, listID string, opts ListOptions) (*ListMemberPage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	members := make([]models.FollowListMember, 0, len(s.members[listID]))
+	for _, member := range s.members[listID] {
+		if opts.Cursor == nil || opts.Cursor.before(member.CreatedAt, member.ID) {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if !members[i].CreatedAt.Equal(members[j].CreatedAt) {
+			return members[i].CreatedAt.After(members[j].CreatedAt)
+		}
+		return members[i].ID > members[j].ID
+	})
+
+	// 多取一条记录用于判断是否存在下一页
+	fetch := ListOptions{Limit: opts.Limit}
+	if fetch.Limit > 0 {
+		fetch.Limit++
+	}
+	return trimMemberPage(paginate(members, fetch), opts), nil
+}
+
+func (s *MemoryListStore) MemberIDs(ctx context.Context, listID string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	memberIDs := make([]string, 0, len(s.members[listID]))
+	for memberID := range s.members[listID] {
+		memberIDs = append(memberIDs, memberID)
+	}
+	return memberIDs, nil
+}
+
+func (s *MemoryListStore) IsMember(ctx context.Context, listID, memberID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.members[listID][memberID]
+	return ok, nil
+}
+
+func (s *MemoryListStore) RemoveFromOwnerLists(ctx context.Context, ownerID, memberID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for listID, list := range s.lists {
+		if list.OwnerID == ownerID {
+			delete(s.members[listID], memberID)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryListStore) RemoveFromAllLists(ctx context.Context, memberID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, members := range s.members {
+		delete(members, memberID)
+	}
+	return nil
+}
+
+func (s *MemoryListStore) DeleteOwnerLists(ctx context.Context, ownerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for listID, list := range s.lists {
+		if list.OwnerID == ownerID {
+			delete(s.lists, listID)
+			delete(s.members, listID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoListStore 基于MongoDB的关注分组存储，分组和成员分别保存在两个集合中
+type MongoListStore struct {
+	lists   *mongo.Collection
+	members *mongo.Collection
+}
+
+func NewMongoListStore(lists, members *mongo.Collection) *MongoListStore {
+	return &MongoListStore{
+		lists:   lists,
+		members: members,
+	}
+}
+
+func (s *MongoListStore) CreateList(ctx context.Context, ownerID, name string) (*models.FollowList, error) {
+	count, err := s.lists.CountDocuments(ctx, bson.M{"owner_id": ownerID})
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxListsPerUser {
+		return nil, ErrTooManyLists
+	}
+
+	now := time.Now()
+	list := &models.FollowList{
+		ID:        uuid.New().String(),
+		OwnerID:   ownerID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	// 由(owner_id, name)唯一索引保证分组名不重复
+	if _, err := s.lists.InsertOne(ctx, list); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrListNameTaken
+		}
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *MongoListStore) GetList(ctx context.Context, listID string) (*models.FollowList, error) {
+	var list models.FollowList
+	err := s.lists.FindOne(ctx, bson.M{"_id": listID}).Decode(&list)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrListNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (s *MongoListStore) ListsByOwner(ctx context.Context, ownerID string) ([]models.FollowList, error) {
+	cursor, err := s.lists.Find(ctx, bson.M{"owner_id": ownerID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	lists := []models.FollowList{}
+	if err := cursor.All(ctx, &lists); err != nil {
+		return nil, err
+	}
+	if len(lists) == 0 {
+		return lists, nil
+	}
+
+	// 统计每个分组的成员数
+	countCursor, err := s.members.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{"owner_id": ownerID}},
+		{"$group": bson.M{"_id": "$list_id", "count": bson.M{"$sum": 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer countCursor.Close(ctx)
+
+	var counts []struct {
+		ListID string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := countCursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	byList := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		byList[count.ListID] = count.Count
+	}
+	for i := range lists {
+		lists[i].MemberCount = byList[lists[i].ID]
+	}
+	return lists, nil
+}
+
+func (s *MongoListStore) RenameList(ctx context.Context, listID, name string) error {
+	result, err := s.lists.UpdateOne(ctx, bson.M{"_id": listID}, bson.M{
+		"$set": bson.M{"name": name, "updated_at": time.Now()},
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrListNameTaken
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrListNotFound
+	}
+	return nil
+}
+
+func (s *MongoListStore) DeleteList(ctx context.Context, listID string) error {
+	result, err := s.lists.DeleteOne(ctx, bson.M{"_id": listID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrListNotFound
+	}
+	_, err = s.members.DeleteMany(ctx, bson.M{"list_id": listID})
+	return err
+}
+
+func (s *MongoListStore) AddMember(ctx context.Context, ownerID, listID, memberID string) error {
+	count, err := s.members.CountDocuments(ctx, bson.M{"list_id": listID})
+	if err != nil {
+		return err
+	}
+	if count >= MaxListMembers {
+		return ErrListFull
+	}
+
+	// 由(list_id, member_id)唯一索引保证同一用户只加入一次
+	_, err = s.members.InsertOne(ctx, models.FollowListMember{
+		ID:        uuid.New().String(),
+		ListID:    listID,
+		OwnerID:   ownerID,
+		MemberID:  memberID,
+		CreatedAt: time.Now(),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrAlreadyInList
+	}
+	return err
+}
+
+func (s *MongoListStore) RemoveMember(ctx context.Context, listID, memberID string) error {
+	result, err := s.members.DeleteOne(ctx, bson.M{"list_id": listID, "member_id": memberID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotInList
+	}
+	return nil
+}
+
+func (s *MongoListStore) ListMembers(ctx context.Context, listID string, opts ListOptions) (*ListMemberPage, error) {
+	filter := bson.M{"list_id": listID}
+	if opts.Cursor != nil {
+		filter = bson.M{"$and": []bson.M{filter, cursorFilter(opts.Cursor)}}
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if opts.Limit > 0 {
+		findOptions.SetLimit(int64(opts.Limit + 1))
+	}
+
+	cursor, err := s.members.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	members := []models.FollowListMember{}
+	if err := cursor.All(ctx, &members); err != nil {
+		return nil, err
+	}
+	return trimMemberPage(members, opts), nil
+}
+
+func (s *MongoListStore) MemberIDs(ctx context.Context, listID string) ([]string, error) {
+	cursor, err := s.members.Find(ctx, bson.M{"list_id": listID}, options.Find().SetProjection(bson.M{
+		"member_id": 1,
+		"_id":       0,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var members []models.FollowListMember
+	if err := cursor.All(ctx, &members); err != nil {
+		return nil, err
+	}
+
+	memberIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.MemberID)
+	}
+	return memberIDs, nil
+}
+
+func (s *MongoListStore) IsMember(ctx context.Context, listID, memberID string) (bool, error) {
+	count, err := s.members.CountDocuments(ctx, bson.M{"list_id": listID, "member_id": memberID})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *MongoListStore) RemoveFromOwnerLists(ctx context.Context, ownerID, memberID string) error {
+	_, err := s.members.DeleteMany(ctx, bson.M{"owner_id": ownerID, "member_id": memberID})
+	return err
+}
+
+func (s *MongoListStore) RemoveFromAllLists(ctx context.Context, memberID string) error {
+	_, err := s.members.DeleteMany(ctx, bson.M{"member_id": memberID})
+	return err
+}
+
+func (s *MongoListStore) DeleteOwnerLists(ctx context.Context, ownerID string) error {
+	if _, err := s.lists.DeleteMany(ctx, bson.M{"owner_id": ownerID}); err != nil {
+		return err
+	}
+	_, err := s.members.DeleteMany(ctx, bson.M{"owner_id": ownerID})
+	return err
+}
+
+// trimMemberPage 去掉为判断下一页而多取的记录，并生成下一页的游标
+func trimMemberPage(members []models.FollowListMember, opts ListOptions) *ListMemberPage {
+	page := &ListMemberPage{Members: members}
+	if opts.Limit <= 0 || len(members) <= opts.Limit {
+		return page
+	}
+	page.Members = members[:opts.Limit]
+	last := page.Members[len(page.Members)-1]
+	page.NextCursor = EncodeCursor(last.CreatedAt, last.ID)
+	return page
+}
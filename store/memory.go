@@ -0,0 +1,398 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryFollowStore 基于内存的关注关系存储，用于单元测试和本地开发
+type MemoryFollowStore struct {
+	mu      sync.RWMutex
+	follows map[followKey]models.Follow
+	outbox  *MemoryOutboxStore
+	stats   map[string]*models.FollowStatsDay
+	history map[string]*models.FollowHistory
+	// latest 每对用户最近一次结束的关注关系，与其Latest字段保持一致
+	latest map[followKey]*models.FollowHistory
+	// changes 按发生顺序记录所有变更，changed在每次变更时关闭并替换，用于唤醒WatchFollows
+	changes []FollowChange
+	changed chan struct{}
+}
+
+type followKey struct {
+	followerID  string
+	followingID string
+}
+
+func NewMemoryFollowStore() *MemoryFollowStore {
+	return &MemoryFollowStore{
+		follows: make(map[followKey]models.Follow),
+		outbox:  NewMemoryOutboxStore(),
+		stats:   make(map[string]*models.FollowStatsDay),
+		history: make(map[string]*models.FollowHistory),
+		latest:  make(map[followKey]*models.FollowHistory),
+		changed: make(chan struct{}),
+	}
+}
+
+// Outbox 返回关注/取消关注时写入事件的发件箱
+func (s *MemoryFollowStore) Outbox() *MemoryOutboxStore {
+	return s.outbox
+}
+
+func (s *MemoryFollowStore) Follow(ctx context.Context, followerID, followingID string) (*models.Follow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := followKey{followerID, followingID}
+	if _, ok := s.follows[key]; ok {
+		return nil, ErrAlreadyFollowing
+	}
+
+	follow := models.Follow{
+		ID:          uuid.New().String(),
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		CreatedAt:   time.Now(),
+	}
+	s.follows[key] = follow
+	s.recordStats(followingID, models.EventFollowCreated, follow.CreatedAt)
+	s.history[follow.ID] = &models.FollowHistory{
+		ID:          follow.ID,
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		FollowedAt:  follow.CreatedAt,
+	}
+	if latest, ok := s.latest[key]; ok {
+		latest.Refollowed = true
+	}
+	s.outbox.append(models.NewOutboxEvent(uuid.New().String(), models.EventFollowCreated, followerID, followingID, follow.CreatedAt))
+	s.recordChange(FollowChange{
+		Type:        models.EventFollowCreated,
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		OccurredAt:  follow.CreatedAt,
+	})
+	return &follow, nil
+}
+
+func (s *MemoryFollowStore) Unfollow(ctx context.Context, followerID, followingID string, reason models.UnfollowReason) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := followKey{followerID, followingID}
+	follow, ok := s.follows[key]
+	if !ok {
+		return ErrNotFollowing
+	}
+	delete(s.follows, key)
+	now := time.Now()
+	s.recordStats(followingID, models.EventFollowDeleted, now)
+	if history, ok := s.history[follow.ID]; ok {
+		history.UnfollowedAt = &now
+		history.Reason = reason
+		if previous, ok := s.latest[key]; ok {
+			previous.Latest = false
+			previous.Refollowed = false
+		}
+		history.Latest = true
+		s.latest[key] = history
+	}
+	s.outbox.append(models.NewOutboxEvent(uuid.New().String(), models.EventFollowDeleted, followerID, followingID, now))
+	s.recordChange(FollowChange{
+		Type:        models.EventFollowDeleted,
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		OccurredAt:  now,
+	})
+	return nil
+}
+
+func (s *MemoryFollowStore) Exists(ctx context.Context, followerID, followingID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.follows[followKey{followerID, followingID}]
+	return ok, nil
+}
+
+func (s *MemoryFollowStore) ListFollowing(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error) {
+	return s.list(func(f models.Follow) (bool, string) {
+		return f.FollowerID == userID && s.matchesMutual(f, opts.Mutual), f.FollowingID
+	}, opts), nil
+}
+
+func (s *MemoryFollowStore) ListFollowers(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error) {
+	return s.list(func(f models.Follow) (bool, string) {
+		return f.FollowingID == userID && s.matchesMutual(f, opts.Mutual), f.FollowerID
+	}, opts), nil
+}
+
+// matchesMutual 判断关注关系是否符合互关筛选条件，需持有读锁
+func (s *MemoryFollowStore) matchesMutual(follow models.Follow, mutual MutualFilter) bool {
+	if mutual == MutualAny {
+		return true
+	}
+	_, reverse := s.follows[followKey{follow.FollowingID, follow.FollowerID}]
+	return reverse == (mutual == MutualOnly)
+}
+
+func (s *MemoryFollowStore) ForEachFollowing(ctx context.Context, userID string, opts ListOptions, fn func(models.Follow) error) error {
+	page, err := s.ListFollowing(ctx, userID, opts)
+	if err != nil {
+		return err
+	}
+	return forEach(page.Follows, fn)
+}
+
+func (s *MemoryFollowStore) ForEachFollower(ctx context.Context, userID string, opts ListOptions, fn func(models.Follow) error) error {
+	page, err := s.ListFollowers(ctx, userID, opts)
+	if err != nil {
+		return err
+	}
+	return forEach(page.Follows, fn)
+}
+
+func forEach(follows []models.Follow, fn func(models.Follow) error) error {
+	for _, follow := range follows {
+		if err := fn(follow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemoryFollowStore) ListMutual(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error) {
+	return s.list(func(f models.Follow) (bool, string) {
+		if f.FollowerID != userID {
+			return false, f.FollowingID
+		}
+		_, ok := s.follows[followKey{f.FollowingID, userID}]
+		return ok, f.FollowingID
+	}, opts), nil
+}
+
+func (s *MemoryFollowStore) ListCommonFollowing(ctx context.Context, userID, otherID string, opts ListOptions) (*FollowPage, error) {
+	return s.list(func(f models.Follow) (bool, string) {
+		if f.FollowerID != userID {
+			return false, f.FollowingID
+		}
+		_, ok := s.follows[followKey{otherID, f.FollowingID}]
+		return ok, f.FollowingID
+	}, opts), nil
+}
+
+func (s *MemoryFollowStore) ListFollowingWhoFollow(ctx context.Context, userID, targetID string, opts ListOptions) (*FollowPage, error) {
+	return s.list(func(f models.Follow) (bool, string) {
+		if f.FollowerID != userID {
+			return false, f.FollowingID
+		}
+		_, ok := s.follows[followKey{f.FollowingID, targetID}]
+		return ok, f.FollowingID
+	}, opts), nil
+}
+
+func (s *MemoryFollowStore) Counts(ctx context.Context, userID string) (*FollowCounts, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := &FollowCounts{}
+	for key := range s.follows {
+		if key.followerID == userID {
+			counts.FollowingCount++
+		}
+		if key.followingID == userID {
+			counts.FollowersCount++
+		}
+	}
+	return counts, nil
+}
+
+func (s *MemoryFollowStore) FollowStates(ctx context.Context, viewerID string, targetIDs []string) (map[string]FollowState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	states := make(map[string]FollowState)
+	for _, targetID := range targetIDs {
+		_, following := s.follows[followKey{viewerID, targetID}]
+		_, followedBy := s.follows[followKey{targetID, viewerID}]
+		if following || followedBy {
+			states[targetID] = FollowState{
+				Following:  following,
+				FollowedBy: followedBy,
+			}
+		}
+	}
+	return states, nil
+}
+
+// ReconcileCounters 内存实现的计数直接由关注关系统计得出，无需对账
+func (s *MemoryFollowStore) ReconcileCounters(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// list 筛选符合条件的关注关系，按关注时间倒序排序后分页。
+// match返回关系是否匹配以及关系另一方的用户ID
+func (s *MemoryFollowStore) list(match func(models.Follow) (bool, string), opts ListOptions) *FollowPage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	excluded := toSet(opts.ExcludeUserIDs)
+	matched := make([]models.Follow, 0)
+	for _, follow := range s.follows {
+		if ok, otherID := match(follow); ok && !excluded[otherID] {
+			matched = append(matched, follow)
+		}
+	}
+	sortFollowsDesc(matched)
+	if opts.Ascending {
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+	}
+
+	page := matched
+	if opts.Cursor != nil {
+		page = make([]models.Follow, 0, len(matched))
+		for _, follow := range matched {
+			if opts.Cursor.follows(follow.CreatedAt, follow.ID, opts.Ascending) {
+				page = append(page, follow)
+			}
+		}
+		opts.Offset = 0
+	}
+	// 多取一条记录用于判断是否存在下一页
+	fetch := opts
+	if fetch.Limit > 0 {
+		fetch.Limit++
+	}
+
+	follows, nextCursor := trimPage(paginate(page, fetch), opts)
+	return &FollowPage{
+		Follows:    follows,
+		TotalCount: int64(len(matched)),
+		NextCursor: nextCursor,
+	}
+}
+
+// sortFollowsDesc 按created_at和_id倒序排序，与Mongo实现保持一致
+func sortFollowsDesc(follows []models.Follow) {
+	sort.Slice(follows, func(i, j int) bool {
+		if !follows[i].CreatedAt.Equal(follows[j].CreatedAt) {
+			return follows[i].CreatedAt.After(follows[j].CreatedAt)
+		}
+		return follows[i].ID > follows[j].ID
+	})
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// paginate 按ListOptions截取一页数据
+func paginate[T any](items []T, opts ListOptions) []T {
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+	if opts.Offset >= len(items) {
+		return []T{}
+	}
+	items = items[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(items) {
+		items = items[:opts.Limit]
+	}
+	return items
+}
+
+func (s *MemoryFollowStore) SecondDegree(ctx context.Context, userID string, opts SecondDegreeOptions) ([]SecondDegreeCandidate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	excluded := toSet(opts.ExcludeUserIDs)
+	excluded[userID] = true
+	seeds := make(map[string]bool)
+	for key := range s.follows {
+		if key.followerID == userID {
+			seeds[key.followingID] = true
+			excluded[key.followingID] = true
+		}
+	}
+
+	// 按关注时间倒序遍历，使Via与Mongo实现的顺序一致
+	follows := make([]models.Follow, 0)
+	for _, follow := range s.follows {
+		if seeds[follow.FollowerID] && !excluded[follow.FollowingID] {
+			follows = append(follows, follow)
+		}
+	}
+	sortFollowsDesc(follows)
+
+	byUser := make(map[string]*SecondDegreeCandidate)
+	candidates := make([]*SecondDegreeCandidate, 0)
+	for _, follow := range follows {
+		candidate, ok := byUser[follow.FollowingID]
+		if !ok {
+			candidate = &SecondDegreeCandidate{UserID: follow.FollowingID, Via: []string{}}
+			byUser[follow.FollowingID] = candidate
+			candidates = append(candidates, candidate)
+		}
+		candidate.MutualCount++
+		if len(candidate.Via) < opts.MaxVia {
+			candidate.Via = append(candidate.Via, follow.FollowerID)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].MutualCount != candidates[j].MutualCount {
+			return candidates[i].MutualCount > candidates[j].MutualCount
+		}
+		return candidates[i].UserID < candidates[j].UserID
+	})
+
+	result := make([]SecondDegreeCandidate, 0, len(candidates))
+	for _, candidate := range paginate(candidates, ListOptions{Limit: opts.Limit}) {
+		result = append(result, *candidate)
+	}
+	return result, nil
+}
+
+// recordStats 在被关注方当天的汇总中累加新增或流失的粉丝数，需持有写锁
+func (s *MemoryFollowStore) recordStats(followingID string, eventType models.EventType, at time.Time) {
+	day := models.StatsDay(at)
+	id := models.FollowStatsDayID(followingID, day)
+	stats, ok := s.stats[id]
+	if !ok {
+		stats = &models.FollowStatsDay{ID: id, UserID: followingID, Day: day}
+		s.stats[id] = stats
+	}
+	if eventType == models.EventFollowDeleted {
+		stats.Lost++
+	} else {
+		stats.Gained++
+	}
+}
+
+func (s *MemoryFollowStore) DailyStats(ctx context.Context, userID string, from, to time.Time) ([]models.FollowStatsDay, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	from, to = models.StatsDay(from), models.StatsDay(to)
+	days := make([]models.FollowStatsDay, 0)
+	for _, stats := range s.stats {
+		if stats.UserID == userID && !stats.Day.Before(from) && !stats.Day.After(to) {
+			days = append(days, *stats)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Day.Before(days[j].Day)
+	})
+	return days, nil
+}
@@ -0,0 +1,227 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testBase = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// seedFollows 直接写入关注关系，使关注时间和_id固定
+func seedFollows(s *MemoryFollowStore, follows ...models.Follow) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, follow := range follows {
+		s.follows[followKey{follow.FollowerID, follow.FollowingID}] = follow
+	}
+}
+
+// follow 构造关注时间为testBase之后minutes分钟的关注关系
+func follow(id, followerID, followingID string, minutes int) models.Follow {
+	return models.Follow{
+		ID:          id,
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		CreatedAt:   testBase.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+// newPagingStore u关注a到e，其中c和d的关注时间相同；a、b、x关注了u
+func newPagingStore() *MemoryFollowStore {
+	s := NewMemoryFollowStore()
+	seedFollows(s,
+		follow("f1", "u", "a", 1),
+		follow("f2", "u", "b", 2),
+		follow("f3", "u", "c", 3),
+		follow("f4", "u", "d", 3),
+		follow("f5", "u", "e", 5),
+		follow("f6", "a", "u", 6),
+		follow("f7", "b", "u", 7),
+		follow("f8", "x", "u", 8),
+	)
+	return s
+}
+
+func TestMemoryFollowStoreFollowUnfollow(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryFollowStore()
+
+	if _, err := s.Follow(ctx, "a", "b"); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+	if _, err := s.Follow(ctx, "a", "b"); !errors.Is(err, ErrAlreadyFollowing) {
+		t.Errorf("second Follow() error = %v, want ErrAlreadyFollowing", err)
+	}
+	if exists, _ := s.Exists(ctx, "a", "b"); !exists {
+		t.Error("Exists(a, b) = false after Follow")
+	}
+	if exists, _ := s.Exists(ctx, "b", "a"); exists {
+		t.Error("Exists(b, a) = true, follows are directed")
+	}
+
+	if err := s.Unfollow(ctx, "a", "b", models.UnfollowReasonUser); err != nil {
+		t.Fatalf("Unfollow() error = %v", err)
+	}
+	if err := s.Unfollow(ctx, "a", "b", models.UnfollowReasonUser); !errors.Is(err, ErrNotFollowing) {
+		t.Errorf("second Unfollow() error = %v, want ErrNotFollowing", err)
+	}
+	if exists, _ := s.Exists(ctx, "a", "b"); exists {
+		t.Error("Exists(a, b) = true after Unfollow")
+	}
+}
+
+func TestMemoryFollowStoreCounts(t *testing.T) {
+	s := newPagingStore()
+	tests := []struct {
+		userID string
+		want   FollowCounts
+	}{
+		{userID: "u", want: FollowCounts{FollowersCount: 3, FollowingCount: 5}},
+		{userID: "a", want: FollowCounts{FollowersCount: 1, FollowingCount: 1}},
+		{userID: "x", want: FollowCounts{FollowingCount: 1}},
+		{userID: "nobody", want: FollowCounts{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.userID, func(t *testing.T) {
+			counts, err := s.Counts(context.Background(), tt.userID)
+			if err != nil {
+				t.Fatalf("Counts() error = %v", err)
+			}
+			if *counts != tt.want {
+				t.Errorf("Counts() = %+v, want %+v", *counts, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryFollowStoreOffsetPaging(t *testing.T) {
+	tests := []struct {
+		name  string
+		list  func(*MemoryFollowStore, ListOptions) (*FollowPage, error)
+		opts  ListOptions
+		want  []string
+		total int64
+	}{
+		{name: "不限制数量", list: listFollowing, want: []string{"e", "d", "c", "b", "a"}, total: 5},
+		{name: "第一页", list: listFollowing, opts: ListOptions{Limit: 2}, want: []string{"e", "d"}, total: 5},
+		{name: "中间页", list: listFollowing, opts: ListOptions{Limit: 2, Offset: 2}, want: []string{"c", "b"}, total: 5},
+		{name: "最后一页", list: listFollowing, opts: ListOptions{Limit: 2, Offset: 4}, want: []string{"a"}, total: 5},
+		{name: "offset超出范围", list: listFollowing, opts: ListOptions{Limit: 2, Offset: 10}, want: []string{}, total: 5},
+		{name: "负数offset", list: listFollowing, opts: ListOptions{Limit: 1, Offset: -1}, want: []string{"e"}, total: 5},
+		{name: "粉丝", list: listFollowers, want: []string{"x", "b", "a"}, total: 3},
+		{name: "互关", list: listMutual, want: []string{"b", "a"}, total: 2},
+		{name: "互关分页", list: listMutual, opts: ListOptions{Limit: 1, Offset: 1}, want: []string{"a"}, total: 2},
+	}
+
+	s := newPagingStore()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := tt.list(s, tt.opts)
+			if err != nil {
+				t.Fatalf("list error = %v", err)
+			}
+			got := make([]string, 0, len(page.Follows))
+			for _, follow := range page.Follows {
+				other := follow.FollowingID
+				if other == "u" {
+					other = follow.FollowerID
+				}
+				got = append(got, other)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("page = %v, want %v", got, tt.want)
+			}
+			if page.TotalCount != tt.total {
+				t.Errorf("TotalCount = %d, want %d", page.TotalCount, tt.total)
+			}
+		})
+	}
+}
+
+func listFollowing(s *MemoryFollowStore, opts ListOptions) (*FollowPage, error) {
+	return s.ListFollowing(context.Background(), "u", opts)
+}
+
+func listFollowers(s *MemoryFollowStore, opts ListOptions) (*FollowPage, error) {
+	return s.ListFollowers(context.Background(), "u", opts)
+}
+
+func listMutual(s *MemoryFollowStore, opts ListOptions) (*FollowPage, error) {
+	return s.ListMutual(context.Background(), "u", opts)
+}
+
+// TestMemoryFollowStoreCursorPaging 按游标翻完所有页，关注时间相同的关系按_id排序且不重复、不遗漏
+func TestMemoryFollowStoreCursorPaging(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  ListOptions
+		pages [][]string
+	}{
+		{name: "倒序", opts: ListOptions{Limit: 2}, pages: [][]string{{"e", "d"}, {"c", "b"}, {"a"}}},
+		{name: "正序", opts: ListOptions{Limit: 2, Ascending: true}, pages: [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+		{name: "首页之后忽略offset", opts: ListOptions{Limit: 3, Offset: 1}, pages: [][]string{{"d", "c", "b"}, {"a"}}},
+		{name: "排除用户", opts: ListOptions{Limit: 1, ExcludeUserIDs: []string{"d", "b"}}, pages: [][]string{{"e"}, {"c"}, {"a"}}},
+	}
+
+	s := newPagingStore()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			got := make([][]string, 0)
+			for i := 0; i <= len(tt.pages); i++ {
+				page, err := s.ListFollowing(context.Background(), "u", opts)
+				if err != nil {
+					t.Fatalf("ListFollowing() error = %v", err)
+				}
+				ids := make([]string, 0, len(page.Follows))
+				for _, follow := range page.Follows {
+					ids = append(ids, follow.FollowingID)
+				}
+				got = append(got, ids)
+				if page.NextCursor == "" {
+					break
+				}
+				if opts.Cursor, err = DecodeCursor(page.NextCursor); err != nil {
+					t.Fatalf("DecodeCursor(%q) error = %v", page.NextCursor, err)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.pages) {
+				t.Errorf("pages = %v, want %v", got, tt.pages)
+			}
+		})
+	}
+}
+
+// TestMemoryFollowStoreCursorStable 翻页期间新增和删除关注关系，后续页不重复也不遗漏
+func TestMemoryFollowStoreCursorStable(t *testing.T) {
+	ctx := context.Background()
+	s := newPagingStore()
+	page, err := s.ListFollowing(ctx, "u", ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListFollowing() error = %v", err)
+	}
+
+	// 首页之后新关注一个用户，并取消关注第一页中的用户
+	seedFollows(s, follow("f9", "u", "z", 9))
+	if err := s.Unfollow(ctx, "u", "e", models.UnfollowReasonUser); err != nil {
+		t.Fatalf("Unfollow() error = %v", err)
+	}
+
+	cursor, err := DecodeCursor(page.NextCursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	next, err := s.ListFollowing(ctx, "u", ListOptions{Limit: 2, Cursor: cursor})
+	if err != nil {
+		t.Fatalf("ListFollowing() error = %v", err)
+	}
+	got := []string{next.Follows[0].FollowingID, next.Follows[1].FollowingID}
+	if !reflect.DeepEqual(got, []string{"c", "b"}) {
+		t.Errorf("second page = %v, want [c b]", got)
+	}
+}
@@ -0,0 +1,521 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoFollowStore 基于MongoDB的关注关系存储。
+// 关注关系、counters中的冗余计数、stats中的每日粉丝变化、history中的关注关系历史以及outbox中的事件在同一事务中写入，
+// 因此要求MongoDB以副本集方式部署。取消关注时关注关系从collection中删除，只保留在history中
+type MongoFollowStore struct {
+	collection *mongo.Collection
+	counters   *mongo.Collection
+	outbox     *mongo.Collection
+	stats      *mongo.Collection
+	history    *mongo.Collection
+}
+
+// MongoFollowCollections 定义MongoFollowStore使用的集合
+type MongoFollowCollections struct {
+	Follows  *mongo.Collection
+	Counters *mongo.Collection
+	Outbox   *mongo.Collection
+	Stats    *mongo.Collection
+	History  *mongo.Collection
+}
+
+// NewMongoFollowCollections 返回db中关注关系存储使用的集合，关注关系集合名由配置文件指定
+func NewMongoFollowCollections(db *mongo.Database, followsCollection string) MongoFollowCollections {
+	return MongoFollowCollections{
+		Follows:  db.Collection(followsCollection),
+		Counters: db.Collection(CountersCollection),
+		Outbox:   db.Collection(OutboxCollection),
+		Stats:    db.Collection(FollowStatsCollection),
+		History:  db.Collection(FollowHistoryCollection),
+	}
+}
+
+// NewMongoFollowStore 使用指定的关注关系、计数和发件箱集合创建存储，每日统计和关注关系历史使用collection所在数据库中的默认集合。
+// 已发布的迁移依赖该签名，不应修改
+func NewMongoFollowStore(collection, counters, outbox *mongo.Collection) *MongoFollowStore {
+	db := collection.Database()
+	return NewMongoFollowStoreWithCollections(MongoFollowCollections{
+		Follows:  collection,
+		Counters: counters,
+		Outbox:   outbox,
+		Stats:    db.Collection(FollowStatsCollection),
+		History:  db.Collection(FollowHistoryCollection),
+	})
+}
+
+// NewMongoFollowStoreWithCollections 使用collections中的集合创建存储
+func NewMongoFollowStoreWithCollections(collections MongoFollowCollections) *MongoFollowStore {
+	return &MongoFollowStore{
+		collection: collections.Follows,
+		counters:   collections.Counters,
+		outbox:     collections.Outbox,
+		stats:      collections.Stats,
+		history:    collections.History,
+	}
+}
+
+// withTransaction 在collection所属客户端的事务中执行fn，fn可能因事务冲突被重试多次
+func withTransaction(ctx context.Context, collection *mongo.Collection, fn func(sessCtx mongo.SessionContext) error) error {
+	session, err := collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// writeOutboxEvent 向发件箱写入事件，需在事务中调用
+func writeOutboxEvent(ctx context.Context, outbox *mongo.Collection, eventType models.EventType, userID, targetID string) error {
+	event := models.NewOutboxEvent(uuid.New().String(), eventType, userID, targetID, time.Now())
+	_, err := outbox.InsertOne(ctx, event)
+	return err
+}
+
+// withTransaction 在事务中执行fn，fn可能因事务冲突被重试多次
+func (s *MongoFollowStore) withTransaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error {
+	return withTransaction(ctx, s.collection, fn)
+}
+
+// writeEvent 向发件箱写入关注关系事件，需在事务中调用
+func (s *MongoFollowStore) writeEvent(ctx context.Context, eventType models.EventType, followerID, followingID string) error {
+	return writeOutboxEvent(ctx, s.outbox, eventType, followerID, followingID)
+}
+
+func (s *MongoFollowStore) Follow(ctx context.Context, followerID, followingID string) (*models.Follow, error) {
+	follow := &models.Follow{
+		ID:          uuid.New().String(),
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		CreatedAt:   time.Now(),
+	}
+
+	err := s.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		// 由(follower_id, following_id)唯一索引保证同一关系只有一条记录，
+		// 先查询再插入无法避免并发请求重复创建
+		if _, err := s.collection.InsertOne(sessCtx, follow); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return ErrAlreadyFollowing
+			}
+			return err
+		}
+		if err := s.incrementCounters(sessCtx, followerID, followingID, 1); err != nil {
+			return err
+		}
+		if err := s.recordStats(sessCtx, followingID, models.EventFollowCreated, follow.CreatedAt); err != nil {
+			return err
+		}
+		if err := s.openHistory(sessCtx, follow); err != nil {
+			return err
+		}
+		return s.writeEvent(sessCtx, models.EventFollowCreated, followerID, followingID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return follow, nil
+}
+
+func (s *MongoFollowStore) Unfollow(ctx context.Context, followerID, followingID string, reason models.UnfollowReason) error {
+	return s.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		var follow models.Follow
+		err := s.collection.FindOneAndDelete(sessCtx, bson.M{
+			"follower_id":  followerID,
+			"following_id": followingID,
+		}).Decode(&follow)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrNotFollowing
+		}
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := s.incrementCounters(sessCtx, followerID, followingID, -1); err != nil {
+			return err
+		}
+		if err := s.recordStats(sessCtx, followingID, models.EventFollowDeleted, now); err != nil {
+			return err
+		}
+		if err := s.closeHistory(sessCtx, follow, reason, now); err != nil {
+			return err
+		}
+		return s.writeEvent(sessCtx, models.EventFollowDeleted, followerID, followingID)
+	})
+}
+
+func (s *MongoFollowStore) Exists(ctx context.Context, followerID, followingID string) (bool, error) {
+	count, err := s.collection.CountDocuments(ctx, bson.M{
+		"follower_id":  followerID,
+		"following_id": followingID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *MongoFollowStore) ListFollowing(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error) {
+	return s.listByField(ctx, "follower_id", "following_id", userID, opts)
+}
+
+func (s *MongoFollowStore) ListFollowers(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error) {
+	return s.listByField(ctx, "following_id", "follower_id", userID, opts)
+}
+
+// listByField 查询field为userID的关注关系，otherField为关系另一方的字段
+func (s *MongoFollowStore) listByField(ctx context.Context, field, otherField, userID string, opts ListOptions) (*FollowPage, error) {
+	filter := bson.M{field: userID}
+	if len(opts.ExcludeUserIDs) > 0 {
+		filter[otherField] = bson.M{"$nin": opts.ExcludeUserIDs}
+	}
+
+	pipeline := []bson.M{
+		{"$match": filter},
+	}
+	if opts.Mutual != MutualAny {
+		return s.aggregatePage(ctx, append(pipeline, s.reverseFollowStages(field, otherField, userID, opts.Mutual)...), opts)
+	}
+	pipeline = appendPaging(pipeline, opts)
+
+	follows, err := s.aggregateFollows(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	follows, nextCursor := trimPage(follows, opts)
+	return &FollowPage{
+		Follows:    follows,
+		TotalCount: totalCount,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (s *MongoFollowStore) ForEachFollowing(ctx context.Context, userID string, opts ListOptions, fn func(models.Follow) error) error {
+	return s.forEachByField(ctx, "follower_id", "following_id", userID, opts, fn)
+}
+
+func (s *MongoFollowStore) ForEachFollower(ctx context.Context, userID string, opts ListOptions, fn func(models.Follow) error) error {
+	return s.forEachByField(ctx, "following_id", "follower_id", userID, opts, fn)
+}
+
+// forEachBatchSize 遍历时每批从MongoDB读取的文档数
+const forEachBatchSize = 1000
+
+func (s *MongoFollowStore) forEachByField(ctx context.Context, field, otherField, userID string, opts ListOptions, fn func(models.Follow) error) error {
+	filter := bson.M{field: userID}
+	if len(opts.ExcludeUserIDs) > 0 {
+		filter[otherField] = bson.M{"$nin": opts.ExcludeUserIDs}
+	}
+	if opts.Cursor != nil {
+		filter = bson.M{"$and": []bson.M{filter, cursorFilter(opts.Cursor)}}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetBatchSize(forEachBatchSize)
+	if opts.Limit > 0 {
+		findOptions.SetLimit(int64(opts.Limit))
+	}
+
+	cursor, err := s.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var follow models.Follow
+		if err := cursor.Decode(&follow); err != nil {
+			return err
+		}
+		if err := fn(follow); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (s *MongoFollowStore) ListMutual(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error) {
+	return s.aggregatePage(ctx, s.mutualStages(userID, opts.ExcludeUserIDs), opts)
+}
+
+func (s *MongoFollowStore) ListCommonFollowing(ctx context.Context, userID, otherID string, opts ListOptions) (*FollowPage, error) {
+	return s.aggregatePage(ctx, s.intersectStages(userID, opts.ExcludeUserIDs, bson.M{
+		"follower_id": otherID,
+		"$expr":       bson.M{"$eq": bson.A{"$following_id", "$$following_id"}},
+	}), opts)
+}
+
+func (s *MongoFollowStore) ListFollowingWhoFollow(ctx context.Context, userID, targetID string, opts ListOptions) (*FollowPage, error) {
+	return s.aggregatePage(ctx, s.intersectStages(userID, opts.ExcludeUserIDs, bson.M{
+		"following_id": targetID,
+		"$expr":        bson.M{"$eq": bson.A{"$follower_id", "$$following_id"}},
+	}), opts)
+}
+
+// aggregatePage 对筛选关注关系的聚合阶段分页，并统计符合条件的关注关系总数
+func (s *MongoFollowStore) aggregatePage(ctx context.Context, stages []bson.M, opts ListOptions) (*FollowPage, error) {
+	// 两次追加阶段都需要复制stages，避免共用底层数组
+	pipeline := appendPaging(stages[:len(stages):len(stages)], opts)
+
+	follows, err := s.aggregateFollows(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取总数
+	countPipeline := append(stages[:len(stages):len(stages)], bson.M{"$count": "total"})
+	cursor, err := s.collection.Aggregate(ctx, countPipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var totalResults []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &totalResults); err != nil {
+		return nil, err
+	}
+
+	var totalCount int64
+	if len(totalResults) > 0 {
+		totalCount = totalResults[0].Total
+	}
+
+	follows, nextCursor := trimPage(follows, opts)
+	return &FollowPage{
+		Follows:    follows,
+		TotalCount: totalCount,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// mutualStages 返回筛选userID互相关注关系的聚合阶段
+func (s *MongoFollowStore) mutualStages(userID string, excludeUserIDs []string) []bson.M {
+	match := bson.M{"follower_id": userID}
+	if len(excludeUserIDs) > 0 {
+		match["following_id"] = bson.M{"$nin": excludeUserIDs}
+	}
+
+	return []bson.M{
+		{
+			"$match": match,
+		},
+		{
+			"$lookup": bson.M{
+				"from":         s.collection.Name(),
+				"localField":   "following_id",
+				"foreignField": "follower_id",
+				"as":           "mutual",
+			},
+		},
+		{
+			"$match": bson.M{
+				"mutual": bson.M{
+					"$elemMatch": bson.M{
+						"following_id": userID,
+					},
+				},
+			},
+		},
+		{
+			"$project": bson.M{
+				"mutual": 0,
+			},
+		},
+	}
+}
+
+// reverseFollowStages 返回按关系另一方是否也关注了userID筛选的聚合阶段，
+// field和otherField的含义与listByField相同
+func (s *MongoFollowStore) reverseFollowStages(field, otherField, userID string, mutual MutualFilter) []bson.M {
+	matched := bson.M{"$ne": bson.A{}}
+	if mutual == MutualExclude {
+		matched = bson.M{"$eq": bson.A{}}
+	}
+
+	return []bson.M{
+		{
+			"$lookup": bson.M{
+				"from": s.collection.Name(),
+				"let":  bson.M{"other_id": "$" + otherField},
+				"pipeline": bson.A{
+					bson.M{"$match": bson.M{
+						otherField: userID,
+						"$expr":    bson.M{"$eq": bson.A{"$" + field, "$$other_id"}},
+					}},
+					bson.M{"$limit": 1},
+					bson.M{"$project": bson.M{"_id": 1}},
+				},
+				"as": "reverse",
+			},
+		},
+		{
+			"$match": bson.M{
+				"reverse": matched,
+			},
+		},
+		{
+			"$project": bson.M{
+				"reverse": 0,
+			},
+		},
+	}
+}
+
+// intersectStages 返回筛选userID的关注关系中，被关注方满足lookupMatch的关注关系的聚合阶段。
+// lookupMatch中可以通过$$following_id引用被关注方的用户ID，每条关注关系最多查找一条匹配记录
+func (s *MongoFollowStore) intersectStages(userID string, excludeUserIDs []string, lookupMatch bson.M) []bson.M {
+	match := bson.M{"follower_id": userID}
+	if len(excludeUserIDs) > 0 {
+		match["following_id"] = bson.M{"$nin": excludeUserIDs}
+	}
+
+	return []bson.M{
+		{
+			"$match": match,
+		},
+		{
+			"$lookup": bson.M{
+				"from": s.collection.Name(),
+				"let":  bson.M{"following_id": "$following_id"},
+				"pipeline": bson.A{
+					bson.M{"$match": lookupMatch},
+					bson.M{"$limit": 1},
+					bson.M{"$project": bson.M{"_id": 1}},
+				},
+				"as": "matched",
+			},
+		},
+		{
+			"$match": bson.M{
+				"matched": bson.M{"$ne": bson.A{}},
+			},
+		},
+		{
+			"$project": bson.M{
+				"matched": 0,
+			},
+		},
+	}
+}
+
+func (s *MongoFollowStore) FollowStates(ctx context.Context, viewerID string, targetIDs []string) (map[string]FollowState, error) {
+	states := make(map[string]FollowState)
+	if len(targetIDs) == 0 {
+		return states, nil
+	}
+
+	// 一次查询同时取出两个方向的关注关系，两个分支分别命中follower_id和following_id索引
+	cursor, err := s.collection.Find(ctx, bson.M{
+		"$or": []bson.M{
+			{"follower_id": viewerID, "following_id": bson.M{"$in": targetIDs}},
+			{"following_id": viewerID, "follower_id": bson.M{"$in": targetIDs}},
+		},
+	}, options.Find().SetProjection(bson.M{
+		"follower_id":  1,
+		"following_id": 1,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var follows []models.Follow
+	if err := cursor.All(ctx, &follows); err != nil {
+		return nil, err
+	}
+
+	for _, follow := range follows {
+		if follow.FollowerID == viewerID {
+			state := states[follow.FollowingID]
+			state.Following = true
+			states[follow.FollowingID] = state
+		}
+		if follow.FollowingID == viewerID {
+			state := states[follow.FollowerID]
+			state.FollowedBy = true
+			states[follow.FollowerID] = state
+		}
+	}
+	return states, nil
+}
+
+func (s *MongoFollowStore) aggregateFollows(ctx context.Context, pipeline []bson.M) ([]models.Follow, error) {
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	follows := []models.Follow{}
+	if err := cursor.All(ctx, &follows); err != nil {
+		return nil, err
+	}
+	return follows, nil
+}
+
+// appendPaging 为聚合管道追加排序和分页阶段，多取一条记录用于判断是否存在下一页
+func appendPaging(pipeline []bson.M, opts ListOptions) []bson.M {
+	direction := -1
+	if opts.Ascending {
+		direction = 1
+	}
+	if opts.Cursor != nil {
+		filter := cursorFilter(opts.Cursor)
+		if opts.Ascending {
+			filter = ascendingCursorFilter(opts.Cursor)
+		}
+		pipeline = append(pipeline, bson.M{"$match": filter})
+	}
+	pipeline = append(pipeline, bson.M{"$sort": bson.D{{Key: "created_at", Value: direction}, {Key: "_id", Value: direction}}})
+	if opts.Cursor == nil && opts.Offset > 0 {
+		pipeline = append(pipeline, bson.M{"$skip": opts.Offset})
+	}
+	if opts.Limit > 0 {
+		pipeline = append(pipeline, bson.M{"$limit": opts.Limit + 1})
+	}
+	return pipeline
+}
+
+// cursorFilter 返回倒序排列中位于游标之后的记录的筛选条件
+func cursorFilter(cursor *Cursor) bson.M {
+	return bson.M{
+		"$or": []bson.M{
+			{"created_at": bson.M{"$lt": cursor.CreatedAt}},
+			{"created_at": cursor.CreatedAt, "_id": bson.M{"$lt": cursor.ID}},
+		},
+	}
+}
+
+// ascendingCursorFilter 返回正序排列中位于游标之后的记录的筛选条件
+func ascendingCursorFilter(cursor *Cursor) bson.M {
+	return bson.M{
+		"$or": []bson.M{
+			{"created_at": bson.M{"$gt": cursor.CreatedAt}},
+			{"created_at": cursor.CreatedAt, "_id": bson.M{"$gt": cursor.ID}},
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+)
+
+var (
+	// ErrAlreadyMuted 表示静音关系已存在
+	ErrAlreadyMuted = errors.New("already muted")
+	// ErrNotMuted 表示静音关系不存在
+	ErrNotMuted = errors.New("not muted")
+)
+
+// MuteStore 定义静音关系的存储接口
+type MuteStore interface {
+	// Mute 创建userID对mutedID的静音关系
+	Mute(ctx context.Context, userID, mutedID string) (*models.Mute, error)
+	// Unmute 解除userID对mutedID的静音
+	Unmute(ctx context.Context, userID, mutedID string) error
+	// MutedUserIDs 返回userID静音的所有用户ID
+	MutedUserIDs(ctx context.Context, userID string) ([]string, error)
+	// DeleteUserMutes 删除userID静音的以及静音了userID的所有静音关系，在用户注销账号时调用
+	DeleteUserMutes(ctx context.Context, userID string) error
+}
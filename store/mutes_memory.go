@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryMuteStore 基于内存的静音关系存储
+type MemoryMuteStore struct {
+	mu    sync.RWMutex
+	mutes map[muteKey]models.Mute
+}
+
+type muteKey struct {
+	userID  string
+	mutedID string
+}
+
+func NewMemoryMuteStore() *MemoryMuteStore {
+	return &MemoryMuteStore{
+		mutes: make(map[muteKey]models.Mute),
+	}
+}
+
+func (s *MemoryMuteStore) Mute(ctx context.Context, userID, mutedID string) (*models.Mute, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := muteKey{userID, mutedID}
+	if _, ok := s.mutes[key]; ok {
+		return nil, ErrAlreadyMuted
+	}
+
+	mute := models.Mute{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		MutedID:   mutedID,
+		CreatedAt: time.Now(),
+	}
+	s.mutes[key] = mute
+	return &mute, nil
+}
+
+func (s *MemoryMuteStore) Unmute(ctx context.Context, userID, mutedID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := muteKey{userID, mutedID}
+	if _, ok := s.mutes[key]; !ok {
+		return ErrNotMuted
+	}
+	delete(s.mutes, key)
+	return nil
+}
+
+func (s *MemoryMuteStore) DeleteUserMutes(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.mutes {
+		if key.userID == userID || key.mutedID == userID {
+			delete(s.mutes, key)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryMuteStore) MutedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mutedIDs := make([]string, 0)
+	for key := range s.mutes {
+		if key.userID == userID {
+			mutedIDs = append(mutedIDs, key.mutedID)
+		}
+	}
+	return mutedIDs, nil
+}
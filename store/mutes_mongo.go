@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoMuteStore 基于MongoDB的静音关系存储
+type MongoMuteStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoMuteStore(collection *mongo.Collection) *MongoMuteStore {
+	return &MongoMuteStore{
+		collection: collection,
+	}
+}
+
+// Mute 依赖(user_id, muted_id)唯一索引防止并发请求重复静音
+func (s *MongoMuteStore) Mute(ctx context.Context, userID, mutedID string) (*models.Mute, error) {
+	mute := &models.Mute{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		MutedID:   mutedID,
+		CreatedAt: time.Now(),
+	}
+	if _, err := s.collection.InsertOne(ctx, mute); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrAlreadyMuted
+		}
+		return nil, err
+	}
+	return mute, nil
+}
+
+func (s *MongoMuteStore) Unmute(ctx context.Context, userID, mutedID string) error {
+	result, err := s.collection.DeleteOne(ctx, bson.M{
+		"user_id":  userID,
+		"muted_id": mutedID,
+	})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotMuted
+	}
+	return nil
+}
+
+func (s *MongoMuteStore) DeleteUserMutes(ctx context.Context, userID string) error {
+	_, err := s.collection.DeleteMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"user_id": userID},
+			{"muted_id": userID},
+		},
+	})
+	return err
+}
+
+func (s *MongoMuteStore) MutedUserIDs(ctx context.Context, userID string) ([]string, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{
+		"user_id": userID,
+	}, options.Find().SetProjection(bson.M{
+		"muted_id": 1,
+		"_id":      0,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mutes []struct {
+		MutedID string `bson:"muted_id"`
+	}
+	if err := cursor.All(ctx, &mutes); err != nil {
+		return nil, err
+	}
+
+	mutedIDs := make([]string, 0, len(mutes))
+	for _, mute := range mutes {
+		mutedIDs = append(mutedIDs, mute.MutedID)
+	}
+	return mutedIDs, nil
+}
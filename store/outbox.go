@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+)
+
+// DefaultOutboxRetention 未配置保留时间时已发布事件的保留时间，之后由published_at上的TTL索引删除
+const DefaultOutboxRetention = 7 * 24 * time.Hour
+
+// OutboxStore 定义发件箱的读取和状态更新接口，事件本身由FollowStore、BlockStore和FollowRequestStore
+// 在关注/取消关注、拉黑和创建关注请求时写入
+type OutboxStore interface {
+	// ClaimPending 领取最多limit个到期未发布的事件，领取后lease时间内不会被再次领取，
+	// 避免多个中继实例重复发布同一事件
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	// MarkPublished 标记事件已发布
+	MarkPublished(ctx context.Context, eventID string) error
+	// MarkFailed 记录发布失败，事件将在nextAttemptAt之后重试
+	MarkFailed(ctx context.Context, eventID, lastError string, nextAttemptAt time.Time) error
+}
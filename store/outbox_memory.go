@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryOutboxStore 基于内存的发件箱，由MemoryFollowStore、MemoryBlockStore和MemoryFollowRequestStore写入事件
+type MemoryOutboxStore struct {
+	mu     sync.Mutex
+	events map[string]*models.OutboxEvent
+}
+
+func NewMemoryOutboxStore() *MemoryOutboxStore {
+	return &MemoryOutboxStore{
+		events: make(map[string]*models.OutboxEvent),
+	}
+}
+
+// append 写入新事件
+func (s *MemoryOutboxStore) append(event *models.OutboxEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[event.ID] = event
+}
+
+func (s *MemoryOutboxStore) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	pending := make([]*models.OutboxEvent, 0)
+	for _, event := range s.events {
+		if !event.Published && !event.NextAttemptAt.After(now) {
+			pending = append(pending, event)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if !pending[i].OccurredAt.Equal(pending[j].OccurredAt) {
+			return pending[i].OccurredAt.Before(pending[j].OccurredAt)
+		}
+		return pending[i].ID < pending[j].ID
+	})
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	claimed := make([]models.OutboxEvent, 0, len(pending))
+	for _, event := range pending {
+		event.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, *event)
+	}
+	return claimed, nil
+}
+
+func (s *MemoryOutboxStore) MarkPublished(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event, ok := s.events[eventID]; ok {
+		now := time.Now()
+		event.Published = true
+		event.PublishedAt = &now
+		event.Attempts++
+		event.LastError = ""
+	}
+	return nil
+}
+
+func (s *MemoryOutboxStore) MarkFailed(ctx context.Context, eventID, lastError string, nextAttemptAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event, ok := s.events[eventID]; ok {
+		event.Attempts++
+		event.LastError = lastError
+		event.NextAttemptAt = nextAttemptAt
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoOutboxStore 基于MongoDB的发件箱
+type MongoOutboxStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoOutboxStore(collection *mongo.Collection) *MongoOutboxStore {
+	return &MongoOutboxStore{
+		collection: collection,
+	}
+}
+
+func (s *MongoOutboxStore) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	events := make([]models.OutboxEvent, 0, limit)
+	for len(events) < limit {
+		now := time.Now()
+
+		// 逐个领取，按发生时间顺序发布；领取时推迟next_attempt_at作为租约
+		var event models.OutboxEvent
+		err := s.collection.FindOneAndUpdate(ctx, bson.M{
+			"published":       false,
+			"next_attempt_at": bson.M{"$lte": now},
+		}, bson.M{
+			"$set": bson.M{"next_attempt_at": now.Add(lease)},
+		}, options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "occurred_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetReturnDocument(options.After),
+		).Decode(&event)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (s *MongoOutboxStore) MarkPublished(ctx context.Context, eventID string) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": eventID}, bson.M{
+		"$set": bson.M{
+			"published":    true,
+			"published_at": time.Now(),
+		},
+		"$inc":   bson.M{"attempts": 1},
+		"$unset": bson.M{"last_error": ""},
+	})
+	return err
+}
+
+func (s *MongoOutboxStore) MarkFailed(ctx context.Context, eventID, lastError string, nextAttemptAt time.Time) error {
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": eventID}, bson.M{
+		"$set": bson.M{
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		},
+		"$inc": bson.M{"attempts": 1},
+	})
+	return err
+}
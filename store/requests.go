@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+)
+
+var (
+	// ErrRequestPending 表示已存在待处理的关注请求
+	ErrRequestPending = errors.New("follow request already pending")
+	// ErrRequestNotFound 表示关注请求不存在或已被处理
+	ErrRequestNotFound = errors.New("follow request not found")
+)
+
+// FollowRequestPage 定义一页关注请求及其总数
+type FollowRequestPage struct {
+	Requests   []models.FollowRequest
+	TotalCount int64
+}
+
+// FollowRequestStore 定义关注请求的存储接口
+type FollowRequestStore interface {
+	// CreateRequest 创建requesterID对targetID的待处理关注请求
+	CreateRequest(ctx context.Context, requesterID, targetID string) (*models.FollowRequest, error)
+	// GetRequest 根据ID获取关注请求
+	GetRequest(ctx context.Context, requestID string) (*models.FollowRequest, error)
+	// ResolveRequest 将待处理的关注请求更新为指定状态，请求不是待处理状态时返回ErrRequestNotFound
+	ResolveRequest(ctx context.Context, requestID string, status models.FollowRequestStatus) (*models.FollowRequest, error)
+	// CancelBetween 撤回两个用户之间任意方向的待处理请求
+	CancelBetween(ctx context.Context, userA, userB string) error
+	// CancelUserRequests 撤回userID发出和收到的所有待处理请求，在用户注销账号时调用
+	CancelUserRequests(ctx context.Context, userID string) error
+	// ListIncoming 按时间倒序返回userID收到的待处理请求
+	ListIncoming(ctx context.Context, userID string, opts ListOptions) (*FollowRequestPage, error)
+	// ListOutgoing 按时间倒序返回userID发出的待处理请求
+	ListOutgoing(ctx context.Context, userID string, opts ListOptions) (*FollowRequestPage, error)
+}
@@ -0,0 +1,142 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryFollowRequestStore 基于内存的关注请求存储，创建请求时向outbox写入FollowRequestCreated事件
+type MemoryFollowRequestStore struct {
+	mu       sync.RWMutex
+	requests map[string]models.FollowRequest
+	outbox   *MemoryOutboxStore
+}
+
+// NewMemoryFollowRequestStore 创建关注请求存储，outbox通常为MemoryFollowStore.Outbox()
+func NewMemoryFollowRequestStore(outbox *MemoryOutboxStore) *MemoryFollowRequestStore {
+	return &MemoryFollowRequestStore{
+		requests: make(map[string]models.FollowRequest),
+		outbox:   outbox,
+	}
+}
+
+func (s *MemoryFollowRequestStore) CreateRequest(ctx context.Context, requesterID, targetID string) (*models.FollowRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, request := range s.requests {
+		if request.RequesterID == requesterID && request.TargetID == targetID && request.Status == models.FollowRequestPending {
+			return nil, ErrRequestPending
+		}
+	}
+
+	now := time.Now()
+	request := models.FollowRequest{
+		ID:          uuid.New().String(),
+		RequesterID: requesterID,
+		TargetID:    targetID,
+		Status:      models.FollowRequestPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.requests[request.ID] = request
+	s.outbox.append(models.NewOutboxEvent(uuid.New().String(), models.EventFollowRequestCreated, requesterID, targetID, now))
+	return &request, nil
+}
+
+func (s *MemoryFollowRequestStore) GetRequest(ctx context.Context, requestID string) (*models.FollowRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	request, ok := s.requests[requestID]
+	if !ok {
+		return nil, ErrRequestNotFound
+	}
+	return &request, nil
+}
+
+func (s *MemoryFollowRequestStore) ResolveRequest(ctx context.Context, requestID string, status models.FollowRequestStatus) (*models.FollowRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request, ok := s.requests[requestID]
+	if !ok || request.Status != models.FollowRequestPending {
+		return nil, ErrRequestNotFound
+	}
+	request.Status = status
+	request.UpdatedAt = time.Now()
+	s.requests[requestID] = request
+	return &request, nil
+}
+
+func (s *MemoryFollowRequestStore) CancelBetween(ctx context.Context, userA, userB string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, request := range s.requests {
+		if request.Status != models.FollowRequestPending {
+			continue
+		}
+		if (request.RequesterID == userA && request.TargetID == userB) ||
+			(request.RequesterID == userB && request.TargetID == userA) {
+			request.Status = models.FollowRequestCancelled
+			request.UpdatedAt = time.Now()
+			s.requests[id] = request
+		}
+	}
+	return nil
+}
+
+func (s *MemoryFollowRequestStore) CancelUserRequests(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, request := range s.requests {
+		if request.Status == models.FollowRequestPending && (request.RequesterID == userID || request.TargetID == userID) {
+			request.Status = models.FollowRequestCancelled
+			request.UpdatedAt = time.Now()
+			s.requests[id] = request
+		}
+	}
+	return nil
+}
+
+func (s *MemoryFollowRequestStore) ListIncoming(ctx context.Context, userID string, opts ListOptions) (*FollowRequestPage, error) {
+	return s.listPending(func(r models.FollowRequest) bool {
+		return r.TargetID == userID
+	}, opts), nil
+}
+
+func (s *MemoryFollowRequestStore) ListOutgoing(ctx context.Context, userID string, opts ListOptions) (*FollowRequestPage, error) {
+	return s.listPending(func(r models.FollowRequest) bool {
+		return r.RequesterID == userID
+	}, opts), nil
+}
+
+func (s *MemoryFollowRequestStore) listPending(match func(models.FollowRequest) bool, opts ListOptions) *FollowRequestPage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matched := make([]models.FollowRequest, 0)
+	for _, request := range s.requests {
+		if request.Status == models.FollowRequestPending && match(request) {
+			matched = append(matched, request)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	return &FollowRequestPage{
+		Requests:   paginate(matched, opts),
+		TotalCount: int64(len(matched)),
+	}
+}
@@ -0,0 +1,160 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoFollowRequestStore 基于MongoDB的关注请求存储，新请求和outbox中的FollowRequestCreated事件在同一事务中写入
+type MongoFollowRequestStore struct {
+	collection *mongo.Collection
+	outbox     *mongo.Collection
+}
+
+func NewMongoFollowRequestStore(collection, outbox *mongo.Collection) *MongoFollowRequestStore {
+	return &MongoFollowRequestStore{
+		collection: collection,
+		outbox:     outbox,
+	}
+}
+
+// CreateRequest 依赖(requester_id, target_id)待处理请求唯一索引保证同一对用户之间只存在一个待处理请求
+func (s *MongoFollowRequestStore) CreateRequest(ctx context.Context, requesterID, targetID string) (*models.FollowRequest, error) {
+	now := time.Now()
+	request := &models.FollowRequest{
+		ID:          uuid.New().String(),
+		RequesterID: requesterID,
+		TargetID:    targetID,
+		Status:      models.FollowRequestPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err := withTransaction(ctx, s.collection, func(sessCtx mongo.SessionContext) error {
+		if _, err := s.collection.InsertOne(sessCtx, request); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return ErrRequestPending
+			}
+			return err
+		}
+		return writeOutboxEvent(sessCtx, s.outbox, models.EventFollowRequestCreated, requesterID, targetID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *MongoFollowRequestStore) GetRequest(ctx context.Context, requestID string) (*models.FollowRequest, error) {
+	var request models.FollowRequest
+	err := s.collection.FindOne(ctx, bson.M{"_id": requestID}).Decode(&request)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (s *MongoFollowRequestStore) ResolveRequest(ctx context.Context, requestID string, status models.FollowRequestStatus) (*models.FollowRequest, error) {
+	var request models.FollowRequest
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{
+		"_id":    requestID,
+		"status": models.FollowRequestPending,
+	}, bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"updated_at": time.Now(),
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&request)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (s *MongoFollowRequestStore) CancelBetween(ctx context.Context, userA, userB string) error {
+	_, err := s.collection.UpdateMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"requester_id": userA, "target_id": userB},
+			{"requester_id": userB, "target_id": userA},
+		},
+		"status": models.FollowRequestPending,
+	}, bson.M{
+		"$set": bson.M{
+			"status":     models.FollowRequestCancelled,
+			"updated_at": time.Now(),
+		},
+	})
+	return err
+}
+
+func (s *MongoFollowRequestStore) CancelUserRequests(ctx context.Context, userID string) error {
+	_, err := s.collection.UpdateMany(ctx, bson.M{
+		"$or": []bson.M{
+			{"requester_id": userID},
+			{"target_id": userID},
+		},
+		"status": models.FollowRequestPending,
+	}, bson.M{
+		"$set": bson.M{
+			"status":     models.FollowRequestCancelled,
+			"updated_at": time.Now(),
+		},
+	})
+	return err
+}
+
+func (s *MongoFollowRequestStore) ListIncoming(ctx context.Context, userID string, opts ListOptions) (*FollowRequestPage, error) {
+	return s.listPending(ctx, "target_id", userID, opts)
+}
+
+func (s *MongoFollowRequestStore) ListOutgoing(ctx context.Context, userID string, opts ListOptions) (*FollowRequestPage, error) {
+	return s.listPending(ctx, "requester_id", userID, opts)
+}
+
+func (s *MongoFollowRequestStore) listPending(ctx context.Context, field, userID string, opts ListOptions) (*FollowRequestPage, error) {
+	filter := bson.M{
+		field:    userID,
+		"status": models.FollowRequestPending,
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	if opts.Offset > 0 {
+		findOptions.SetSkip(int64(opts.Offset))
+	}
+	if opts.Limit > 0 {
+		findOptions.SetLimit(int64(opts.Limit))
+	}
+
+	cursor, err := s.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	requests := []models.FollowRequest{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+
+	totalCount, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FollowRequestPage{
+		Requests:   requests,
+		TotalCount: totalCount,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// secondDegreeMaxSeeds 二度人脉查询最多使用最近关注的多少个用户作为中间人，限制聚合的开销
+const secondDegreeMaxSeeds = 1000
+
+func (s *MongoFollowStore) SecondDegree(ctx context.Context, userID string, opts SecondDegreeOptions) ([]SecondDegreeCandidate, error) {
+	candidates := []SecondDegreeCandidate{}
+
+	cursor, err := s.collection.Find(ctx, bson.M{"follower_id": userID}, options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(secondDegreeMaxSeeds).
+		SetProjection(bson.M{"following_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var seedFollows []models.Follow
+	if err := cursor.All(ctx, &seedFollows); err != nil {
+		return nil, err
+	}
+	if len(seedFollows) == 0 {
+		return candidates, nil
+	}
+
+	seeds := make([]string, 0, len(seedFollows))
+	for _, follow := range seedFollows {
+		seeds = append(seeds, follow.FollowingID)
+	}
+	excluded := append(append([]string{userID}, seeds...), opts.ExcludeUserIDs...)
+
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"follower_id":  bson.M{"$in": seeds},
+			"following_id": bson.M{"$nin": excluded},
+		}},
+		{"$sort": bson.D{{Key: "created_at", Value: -1}}},
+		{"$group": bson.M{
+			"_id":          "$following_id",
+			"mutual_count": bson.M{"$sum": 1},
+			"via":          bson.M{"$push": "$follower_id"},
+		}},
+		{"$sort": bson.D{{Key: "mutual_count", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+	if opts.Limit > 0 {
+		// 关注数超过secondDegreeMaxSeeds时，部分已关注的用户未被$nin排除，多取一些用于过滤
+		pipeline = append(pipeline, bson.M{"$limit": opts.Limit * 2})
+	}
+	pipeline = append(pipeline, bson.M{"$project": bson.M{
+		"mutual_count": 1,
+		"via":          bson.M{"$slice": bson.A{"$via", opts.MaxVia}},
+	}})
+
+	aggCursor, err := s.collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer aggCursor.Close(ctx)
+
+	var results []struct {
+		UserID      string   `bson:"_id"`
+		MutualCount int64    `bson:"mutual_count"`
+		Via         []string `bson:"via"`
+	}
+	if err := aggCursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	candidateIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		candidateIDs = append(candidateIDs, result.UserID)
+	}
+	states, err := s.FollowStates(ctx, userID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if states[result.UserID].Following {
+			continue
+		}
+		candidates = append(candidates, SecondDegreeCandidate{
+			UserID:      result.UserID,
+			MutualCount: result.MutualCount,
+			Via:         result.Via,
+		})
+		if opts.Limit > 0 && len(candidates) >= opts.Limit {
+			break
+		}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+)
+
+// SettingsStore 定义用户设置的存储接口
+type SettingsStore interface {
+	// GetSettings 获取userID的设置，未保存过时返回默认设置
+	GetSettings(ctx context.Context, userID string) (*models.UserSettings, error)
+	// SaveSettings 保存用户设置
+	SaveSettings(ctx context.Context, settings *models.UserSettings) error
+	// DeleteSettings 删除userID保存的设置，在用户注销账号时调用
+	DeleteSettings(ctx context.Context, userID string) error
+}
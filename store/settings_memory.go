@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sync"
+	"time"
+)
+
+// MemorySettingsStore 基于内存的用户设置存储
+type MemorySettingsStore struct {
+	mu       sync.RWMutex
+	settings map[string]models.UserSettings
+}
+
+func NewMemorySettingsStore() *MemorySettingsStore {
+	return &MemorySettingsStore{
+		settings: make(map[string]models.UserSettings),
+	}
+}
+
+func (s *MemorySettingsStore) GetSettings(ctx context.Context, userID string) (*models.UserSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	settings, ok := s.settings[userID]
+	if !ok {
+		return &models.UserSettings{UserID: userID}, nil
+	}
+	return &settings, nil
+}
+
+func (s *MemorySettingsStore) SaveSettings(ctx context.Context, settings *models.UserSettings) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings.UpdatedAt = time.Now()
+	s.settings[settings.UserID] = *settings
+	return nil
+}
+
+func (s *MemorySettingsStore) DeleteSettings(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.settings, userID)
+	return nil
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoSettingsStore 基于MongoDB的用户设置存储
+type MongoSettingsStore struct {
+	collection *mongo.Collection
+}
+
+func NewMongoSettingsStore(collection *mongo.Collection) *MongoSettingsStore {
+	return &MongoSettingsStore{
+		collection: collection,
+	}
+}
+
+func (s *MongoSettingsStore) GetSettings(ctx context.Context, userID string) (*models.UserSettings, error) {
+	var settings models.UserSettings
+	err := s.collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&settings)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &models.UserSettings{UserID: userID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (s *MongoSettingsStore) SaveSettings(ctx context.Context, settings *models.UserSettings) error {
+	settings.UpdatedAt = time.Now()
+	_, err := s.collection.ReplaceOne(ctx, bson.M{"_id": settings.UserID}, settings, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (s *MongoSettingsStore) DeleteSettings(ctx context.Context, userID string) error {
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": userID})
+	return err
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// recordStats 在被关注方当天的汇总中累加新增或流失的粉丝数，需在事务中调用
+func (s *MongoFollowStore) recordStats(ctx context.Context, followingID string, eventType models.EventType, at time.Time) error {
+	field := "gained"
+	if eventType == models.EventFollowDeleted {
+		field = "lost"
+	}
+	day := models.StatsDay(at)
+	_, err := s.stats.UpdateOne(ctx, bson.M{
+		"_id": models.FollowStatsDayID(followingID, day),
+	}, bson.M{
+		"$inc":         bson.M{field: 1},
+		"$setOnInsert": bson.M{"user_id": followingID, "day": day},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (s *MongoFollowStore) DailyStats(ctx context.Context, userID string, from, to time.Time) ([]models.FollowStatsDay, error) {
+	cursor, err := s.stats.Find(ctx, bson.M{
+		"user_id": userID,
+		"day": bson.M{
+			"$gte": models.StatsDay(from),
+			"$lte": models.StatsDay(to),
+		},
+	}, options.Find().SetSort(bson.D{{Key: "day", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	days := []models.FollowStatsDay{}
+	if err := cursor.All(ctx, &days); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+)
+
+var (
+	// ErrAlreadyFollowing 表示关注关系已存在
+	ErrAlreadyFollowing = errors.New("already following")
+	// ErrNotFollowing 表示关注关系不存在
+	ErrNotFollowing = errors.New("not following")
+)
+
+// MutualFilter 按关系另一方是否也关注了对方筛选关注关系
+type MutualFilter int
+
+const (
+	MutualAny     MutualFilter = iota // 不筛选
+	MutualOnly                        // 只保留互相关注的关系
+	MutualExclude                     // 只保留非互相关注的关系
+)
+
+// ListOptions 定义列表查询的分页参数，Limit为0时不限制数量。
+// 设置Cursor时使用游标分页并忽略Offset，Offset仅作为旧版分页方式保留
+type ListOptions struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor
+	// ExcludeUserIDs 中的用户不会出现在结果中，也不计入总数
+	ExcludeUserIDs []string
+	// Ascending 为true时按关注时间正序排列，Cursor同样按正序解释。ForEach系列方法不支持
+	Ascending bool
+	// Mutual 按是否互相关注筛选，仅ListFollowing和ListFollowers支持
+	Mutual MutualFilter
+	// ExcludeRefollowed 为true时跳过之后又重新关注的用户，仅ListUnfollowers支持
+	ExcludeRefollowed bool
+}
+
+// FollowPage 定义一页关注关系及其总数，NextCursor为空表示没有下一页
+type FollowPage struct {
+	Follows    []models.Follow
+	TotalCount int64
+	NextCursor string
+}
+
+// FollowCounts 定义用户的关注数和粉丝数
+type FollowCounts struct {
+	FollowersCount int64
+	FollowingCount int64
+}
+
+// FollowState 定义查看者与某个目标用户之间的关注状态
+type FollowState struct {
+	Following  bool // 查看者关注了目标用户
+	FollowedBy bool // 目标用户关注了查看者
+}
+
+// SecondDegreeOptions 定义二度人脉查询的参数
+type SecondDegreeOptions struct {
+	Limit int
+	// MaxVia 每个候选用户最多返回几个中间人，为0时不返回
+	MaxVia int
+	// ExcludeUserIDs 中的用户不会出现在结果中
+	ExcludeUserIDs []string
+}
+
+// SecondDegreeCandidate 二度人脉中的候选用户
+type SecondDegreeCandidate struct {
+	UserID string
+	// MutualCount 查询用户关注的人中关注了该用户的人数
+	MutualCount int64
+	// Via 关注了该用户的中间人，按关注时间倒序，最多MaxVia个
+	Via []string
+}
+
+// HistoryPage 定义一页关注关系历史记录，NextCursor为空表示没有下一页
+type HistoryPage struct {
+	Entries    []models.FollowHistory
+	NextCursor string
+}
+
+// FollowStore 定义关注关系的存储接口，HTTP和gRPC处理器共用同一数据路径
+type FollowStore interface {
+	// Follow 创建followerID对followingID的关注关系
+	Follow(ctx context.Context, followerID, followingID string) (*models.Follow, error)
+	// Unfollow 删除followerID对followingID的关注关系，并在历史记录中记录结束时间和原因
+	Unfollow(ctx context.Context, followerID, followingID string, reason models.UnfollowReason) error
+	// Exists 判断followerID是否关注了followingID
+	Exists(ctx context.Context, followerID, followingID string) (bool, error)
+	// ListFollowing 按关注时间倒序返回userID关注的用户
+	ListFollowing(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error)
+	// ListFollowers 按关注时间倒序返回userID的粉丝
+	ListFollowers(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error)
+	// ForEachFollowing 按关注时间倒序逐条遍历userID关注的用户，内存占用与关注数无关。
+	// 遵循opts中的Cursor、ExcludeUserIDs和Limit，fn返回错误时停止遍历并返回该错误
+	ForEachFollowing(ctx context.Context, userID string, opts ListOptions, fn func(models.Follow) error) error
+	// ForEachFollower 按关注时间倒序逐条遍历userID的粉丝，语义同ForEachFollowing
+	ForEachFollower(ctx context.Context, userID string, opts ListOptions, fn func(models.Follow) error) error
+	// ListMutual 按关注时间倒序返回与userID互相关注的用户（以userID发起的关注记录表示）
+	ListMutual(ctx context.Context, userID string, opts ListOptions) (*FollowPage, error)
+	// ListCommonFollowing 按userID的关注时间倒序返回userID和otherID都关注的用户（以userID发起的关注记录表示）
+	ListCommonFollowing(ctx context.Context, userID, otherID string, opts ListOptions) (*FollowPage, error)
+	// ListFollowingWhoFollow 按userID的关注时间倒序返回userID关注的人中也关注了targetID的用户（以userID发起的关注记录表示）
+	ListFollowingWhoFollow(ctx context.Context, userID, targetID string, opts ListOptions) (*FollowPage, error)
+	// Counts 返回userID的关注数和粉丝数
+	Counts(ctx context.Context, userID string) (*FollowCounts, error)
+	// FollowStates 批量返回viewerID与targetIDs之间的关注状态，没有任何关注关系的目标不在结果中
+	FollowStates(ctx context.Context, viewerID string, targetIDs []string) (map[string]FollowState, error)
+	// SecondDegree 返回userID关注的人所关注的用户，按MutualCount倒序排列，
+	// 不包含userID本人和userID已关注的用户
+	SecondDegree(ctx context.Context, userID string, opts SecondDegreeOptions) ([]SecondDegreeCandidate, error)
+	// DailyStats 按日期升序返回userID在[from, to]（UTC日期，含两端）内每天的粉丝变化，没有变化的日期不在结果中
+	DailyStats(ctx context.Context, userID string, from, to time.Time) ([]models.FollowStatsDay, error)
+	// ListUnfollowers 按取消关注时间倒序返回曾经关注userID、现已结束的关注关系历史，
+	// 每个用户只返回最近一次结束的关注关系，仅使用opts中的Limit、Cursor、ExcludeUserIDs和ExcludeRefollowed
+	ListUnfollowers(ctx context.Context, userID string, opts ListOptions) (*HistoryPage, error)
+	// RelationshipHistory 按关注时间倒序返回followerID对followingID的所有关注关系历史
+	RelationshipHistory(ctx context.Context, followerID, followingID string) ([]models.FollowHistory, error)
+}
+
+// CounterReconciler 根据关注关系重新计算冗余的关注数和粉丝数
+type CounterReconciler interface {
+	// ReconcileCounters 修正所有与关注关系不一致的计数，返回被修正的计数数量
+	ReconcileCounters(ctx context.Context) (int64, error)
+}
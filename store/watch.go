@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+)
+
+var (
+	// ErrInvalidResumeToken 表示恢复令牌格式不正确
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+	// ErrResumeTokenExpired 表示恢复令牌对应的位置已不在变更历史中，无法继续
+	ErrResumeTokenExpired = errors.New("resume token expired")
+)
+
+// FollowChange 关注关系的一次实时变更
+type FollowChange struct {
+	Type        models.EventType
+	FollowerID  string
+	FollowingID string
+	OccurredAt  time.Time
+	// ResumeToken 传给WatchOptions.ResumeToken可从该变更之后继续监听
+	ResumeToken string
+	// Checkpoint 为true时不是关注关系变更，只表示监听位置已越过一批不符合筛选条件的变更，
+	// 客户端应保存其ResumeToken，避免筛选条件很少命中时令牌因长期不更新而超出变更历史
+	Checkpoint bool
+}
+
+// WatchOptions 定义监听关注关系变更时的筛选条件
+type WatchOptions struct {
+	// UserID 不为空时只返回该用户作为关注者或被关注者的变更
+	UserID string
+	// Types 不为空时只返回这些类型的变更
+	Types []models.EventType
+	// ResumeToken 不为空时从该令牌对应的变更之后开始，否则从当前时刻开始
+	ResumeToken string
+}
+
+// FollowWatcher 定义关注关系的实时变更监听接口
+type FollowWatcher interface {
+	// WatchFollows 持续将变更传给fn，直到ctx结束或fn返回错误
+	WatchFollows(ctx context.Context, opts WatchOptions, fn func(FollowChange) error) error
+}
+
+// matches 判断变更是否满足筛选条件
+func (opts WatchOptions) matches(change FollowChange) bool {
+	if opts.UserID != "" && change.FollowerID != opts.UserID && change.FollowingID != opts.UserID {
+		return false
+	}
+	return opts.wantsType(change.Type)
+}
+
+// wantsType 判断是否需要返回eventType类型的变更
+func (opts WatchOptions) wantsType(eventType models.EventType) bool {
+	if len(opts.Types) == 0 {
+		return true
+	}
+	for _, t := range opts.Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"strconv"
+)
+
+// recordChange 记录一次变更并唤醒所有监听者，需持有写锁
+func (s *MemoryFollowStore) recordChange(change FollowChange) {
+	change.ResumeToken = strconv.Itoa(len(s.changes) + 1)
+	s.changes = append(s.changes, change)
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *MemoryFollowStore) WatchFollows(ctx context.Context, opts WatchOptions, fn func(FollowChange) error) error {
+	s.mu.RLock()
+	next := len(s.changes)
+	s.mu.RUnlock()
+
+	// 恢复令牌为变更的序号，从其后一条继续
+	if opts.ResumeToken != "" {
+		seq, err := strconv.Atoi(opts.ResumeToken)
+		if err != nil || seq < 0 || seq > next {
+			return ErrInvalidResumeToken
+		}
+		next = seq
+	}
+
+	for {
+		s.mu.RLock()
+		pending := s.changes[next:]
+		changed := s.changed
+		s.mu.RUnlock()
+
+		skipped := false
+		for _, change := range pending {
+			next++
+			skipped = !opts.matches(change)
+			if skipped {
+				continue
+			}
+			if err := fn(change); err != nil {
+				return err
+			}
+		}
+		// 最后一条变更不符合筛选条件时推送当前位置，与MongoDB实现的批次后令牌一致
+		if skipped {
+			if err := fn(FollowChange{ResumeToken: strconv.Itoa(next), Checkpoint: true}); err != nil {
+				return err
+			}
+		}
+		if len(pending) > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
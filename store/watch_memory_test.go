@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"testing"
+	"time"
+)
+
+var errStopWatching = errors.New("stop watching")
+
+// collectChanges 在后台监听变更，收到n条后停止并返回
+func collectChanges(t *testing.T, s *MemoryFollowStore, opts WatchOptions, n int, act func()) []FollowChange {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	changes := make([]FollowChange, 0, n)
+	done := make(chan error, 1)
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		done <- s.WatchFollows(ctx, opts, func(change FollowChange) error {
+			changes = append(changes, change)
+			if len(changes) == n {
+				return errStopWatching
+			}
+			return nil
+		})
+	}()
+	<-started
+	act()
+
+	if err := <-done; !errors.Is(err, errStopWatching) {
+		t.Fatalf("WatchFollows() error = %v, got %d of %d changes", err, len(changes), n)
+	}
+	return changes
+}
+
+func TestMemoryWatchFollowsFilters(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryFollowStore()
+	// 从指定位置开始，避免监听启动前后的竞争
+	opts := WatchOptions{UserID: "a", Types: []models.EventType{models.EventFollowDeleted}, ResumeToken: "0"}
+
+	changes := collectChanges(t, s, opts, 2, func() {
+		s.Follow(ctx, "a", "b")
+		s.Follow(ctx, "c", "d")
+		s.Unfollow(ctx, "a", "b", models.UnfollowReasonUser)
+		s.Unfollow(ctx, "c", "d", models.UnfollowReasonUser)
+	})
+
+	if changes[0].Checkpoint || changes[0].Type != models.EventFollowDeleted || changes[0].FollowerID != "a" {
+		t.Errorf("first change = %+v, want a's unfollow", changes[0])
+	}
+	// 最后一条变更被筛掉，推送监听位置
+	if !changes[1].Checkpoint || changes[1].ResumeToken != "4" {
+		t.Errorf("second change = %+v, want a checkpoint at 4", changes[1])
+	}
+}
+
+// TestMemoryWatchFollowsCheckpointResume 从检查点令牌恢复时不会重复推送之前的变更
+func TestMemoryWatchFollowsCheckpointResume(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryFollowStore()
+	s.Follow(ctx, "a", "b")
+	s.Follow(ctx, "c", "d")
+
+	checkpoint := collectChanges(t, s, WatchOptions{UserID: "a", ResumeToken: "0"}, 2, func() {})[1]
+	if !checkpoint.Checkpoint {
+		t.Fatalf("change = %+v, want a checkpoint", checkpoint)
+	}
+
+	changes := collectChanges(t, s, WatchOptions{ResumeToken: checkpoint.ResumeToken}, 1, func() {
+		s.Follow(ctx, "a", "e")
+	})
+	if changes[0].Checkpoint || changes[0].FollowingID != "e" {
+		t.Errorf("change after resume = %+v, want a -> e", changes[0])
+	}
+}
+
+func TestMemoryWatchFollowsInvalidToken(t *testing.T) {
+	s := NewMemoryFollowStore()
+	for _, token := range []string{"abc", "-1", "5"} {
+		err := s.WatchFollows(context.Background(), WatchOptions{ResumeToken: token}, func(FollowChange) error { return nil })
+		if !errors.Is(err, ErrInvalidResumeToken) {
+			t.Errorf("WatchFollows(%q) error = %v, want ErrInvalidResumeToken", token, err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"followservice/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// changeStreamHistoryLost MongoDB在恢复令牌已超出oplog范围时返回的错误码
+const changeStreamHistoryLost = 286
+
+// watchMaxAwaitTime 没有新变更时服务端等待的最长时间，也是推送监听位置的最短间隔，
+// 有变更时立即返回，不影响推送延迟
+const watchMaxAwaitTime = 10 * time.Second
+
+// followChangeEvent 关注关系集合变更流中的事件
+type followChangeEvent struct {
+	OperationType            string              `bson:"operationType"`
+	ClusterTime              primitive.Timestamp `bson:"clusterTime"`
+	FullDocument             *models.Follow      `bson:"fullDocument"`
+	FullDocumentBeforeChange *models.Follow      `bson:"fullDocumentBeforeChange"`
+}
+
+// EnableChangeStreamPreImages 为关注关系集合开启变更前镜像（需要MongoDB 6.0+），
+// 否则取消关注的变更中没有关注双方的ID，WatchFollows会跳过这些变更
+func (s *MongoFollowStore) EnableChangeStreamPreImages(ctx context.Context) error {
+	return s.collection.Database().RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: s.collection.Name()},
+		{Key: "changeStreamPreAndPostImages", Value: bson.M{"enabled": true}},
+	}).Err()
+}
+
+func (s *MongoFollowStore) WatchFollows(ctx context.Context, opts WatchOptions, fn func(FollowChange) error) error {
+	streamOptions := options.ChangeStream().SetFullDocumentBeforeChange(options.WhenAvailable)
+	if opts.ResumeToken != "" {
+		token, err := base64.RawURLEncoding.DecodeString(opts.ResumeToken)
+		if err != nil || bson.Raw(token).Validate() != nil {
+			return ErrInvalidResumeToken
+		}
+		streamOptions.SetResumeAfter(bson.Raw(token))
+	}
+
+	streamOptions.SetMaxAwaitTime(watchMaxAwaitTime)
+
+	stream, err := s.collection.Watch(ctx, watchPipeline(opts), streamOptions)
+	if err != nil {
+		return watchError(err)
+	}
+	defer stream.Close(ctx)
+
+	delivered := opts.ResumeToken
+	for {
+		if stream.TryNext(ctx) {
+			var event followChangeEvent
+			if err := stream.Decode(&event); err != nil {
+				return err
+			}
+
+			change, ok := event.toChange()
+			if !ok {
+				continue
+			}
+			change.ResumeToken = base64.RawURLEncoding.EncodeToString(stream.ResumeToken())
+			if err := fn(change); err != nil {
+				return err
+			}
+			delivered = change.ResumeToken
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := stream.Err(); err != nil {
+			return watchError(err)
+		}
+
+		// 当前批次已处理完，ResumeToken为服务端返回的批次后令牌，即使筛选后没有任何变更也会前进
+		token := base64.RawURLEncoding.EncodeToString(stream.ResumeToken())
+		if token == "" || token == delivered {
+			continue
+		}
+		if err := fn(FollowChange{ResumeToken: token, Checkpoint: true}); err != nil {
+			return err
+		}
+		delivered = token
+	}
+}
+
+// watchPipeline 在服务端按操作类型和用户筛选变更
+func watchPipeline(opts WatchOptions) mongo.Pipeline {
+	operations := []string{}
+	if opts.wantsType(models.EventFollowCreated) {
+		operations = append(operations, "insert")
+	}
+	if opts.wantsType(models.EventFollowDeleted) {
+		operations = append(operations, "delete")
+	}
+
+	match := bson.M{"operationType": bson.M{"$in": operations}}
+	if opts.UserID != "" {
+		match["$or"] = []bson.M{
+			{"fullDocument.follower_id": opts.UserID},
+			{"fullDocument.following_id": opts.UserID},
+			{"fullDocumentBeforeChange.follower_id": opts.UserID},
+			{"fullDocumentBeforeChange.following_id": opts.UserID},
+		}
+	}
+	return mongo.Pipeline{{{Key: "$match", Value: match}}}
+}
+
+// toChange 将变更流事件转换为FollowChange，缺少关注双方ID的事件返回false
+func (e followChangeEvent) toChange() (FollowChange, bool) {
+	switch e.OperationType {
+	case "insert":
+		if e.FullDocument == nil {
+			return FollowChange{}, false
+		}
+		return FollowChange{
+			Type:        models.EventFollowCreated,
+			FollowerID:  e.FullDocument.FollowerID,
+			FollowingID: e.FullDocument.FollowingID,
+			OccurredAt:  e.FullDocument.CreatedAt,
+		}, true
+	case "delete":
+		if e.FullDocumentBeforeChange == nil {
+			return FollowChange{}, false
+		}
+		return FollowChange{
+			Type:        models.EventFollowDeleted,
+			FollowerID:  e.FullDocumentBeforeChange.FollowerID,
+			FollowingID: e.FullDocumentBeforeChange.FollowingID,
+			OccurredAt:  time.Unix(int64(e.ClusterTime.T), 0),
+		}, true
+	}
+	return FollowChange{}, false
+}
+
+func watchError(err error) error {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(changeStreamHistoryLost) {
+		return ErrResumeTokenExpired
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+)
+
+var (
+	// ErrWebhookNotFound 表示回调地址不存在
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrDeliveryNotFound 表示死信记录不存在
+	ErrDeliveryNotFound = errors.New("delivery not found")
+)
+
+// WebhookStore 定义回调地址、待投递队列和死信的存储接口
+type WebhookStore interface {
+	// CreateWebhook 注册订阅events的回调地址
+	CreateWebhook(ctx context.Context, url, secret string, events []models.WebhookEvent) (*models.Webhook, error)
+	// GetWebhook 返回指定的回调地址
+	GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error)
+	// DeleteWebhook 删除回调地址，尚未投递的回调随之丢弃
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	// ListWebhooks 按注册时间返回所有回调地址
+	ListWebhooks(ctx context.Context) ([]models.Webhook, error)
+	// WebhooksForEvent 返回订阅了event的回调地址
+	WebhooksForEvent(ctx context.Context, event models.WebhookEvent) ([]models.Webhook, error)
+
+	// EnqueueDeliveries 将回调加入待投递队列，跳过ID已在队列中的回调
+	EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	// ClaimDeliveries 领取最多limit个到期的回调，领取后lease时间内不会被再次领取
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	// CompleteDelivery 投递成功后将回调移出队列
+	CompleteDelivery(ctx context.Context, deliveryID string) error
+	// RetryDelivery 记录投递失败，回调将在nextAttemptAt之后重试
+	RetryDelivery(ctx context.Context, deliveryID, lastError string, nextAttemptAt time.Time) error
+	// DeadLetter 将重试次数耗尽的回调移入死信
+	DeadLetter(ctx context.Context, deliveryID, lastError string) error
+
+	// ListDeadLetters 按进入死信的时间倒序返回死信
+	ListDeadLetters(ctx context.Context, opts ListOptions) ([]models.WebhookDelivery, int64, error)
+	// ReplayDeadLetter 将死信重新加入待投递队列并重置重试次数
+	ReplayDeadLetter(ctx context.Context, deliveryID string) error
+}
@@ -0,0 +1,211 @@
+package store
+
+import (
+	"context"
+	"followservice/models"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryWebhookStore 基于内存的回调存储
+type MemoryWebhookStore struct {
+	mu          sync.Mutex
+	webhooks    map[string]models.Webhook
+	deliveries  map[string]models.WebhookDelivery
+	deadLetters map[string]models.WebhookDelivery
+}
+
+func NewMemoryWebhookStore() *MemoryWebhookStore {
+	return &MemoryWebhookStore{
+		webhooks:    make(map[string]models.Webhook),
+		deliveries:  make(map[string]models.WebhookDelivery),
+		deadLetters: make(map[string]models.WebhookDelivery),
+	}
+}
+
+func (s *MemoryWebhookStore) CreateWebhook(ctx context.Context, url, secret string, events []models.WebhookEvent) (*models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook := models.Webhook{
+		ID:        uuid.New().String(),
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		CreatedAt: time.Now(),
+	}
+	s.webhooks[webhook.ID] = webhook
+	return &webhook, nil
+}
+
+func (s *MemoryWebhookStore) GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook, ok := s.webhooks[webhookID]
+	if !ok {
+		return nil, ErrWebhookNotFound
+	}
+	return &webhook, nil
+}
+
+func (s *MemoryWebhookStore) DeleteWebhook(ctx context.Context, webhookID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[webhookID]; !ok {
+		return ErrWebhookNotFound
+	}
+	delete(s.webhooks, webhookID)
+	for id, delivery := range s.deliveries {
+		if delivery.WebhookID == webhookID {
+			delete(s.deliveries, id)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryWebhookStore) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	return s.findWebhooks(func(models.Webhook) bool { return true }), nil
+}
+
+func (s *MemoryWebhookStore) WebhooksForEvent(ctx context.Context, event models.WebhookEvent) ([]models.Webhook, error) {
+	return s.findWebhooks(func(webhook models.Webhook) bool {
+		for _, e := range webhook.Events {
+			if e == event {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (s *MemoryWebhookStore) findWebhooks(match func(models.Webhook) bool) []models.Webhook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhooks := []models.Webhook{}
+	for _, webhook := range s.webhooks {
+		if match(webhook) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+	return webhooks
+}
+
+func (s *MemoryWebhookStore) EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		if _, ok := s.deliveries[delivery.ID]; ok {
+			continue
+		}
+		s.deliveries[delivery.ID] = delivery
+	}
+	return nil
+}
+
+func (s *MemoryWebhookStore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	due := []models.WebhookDelivery{}
+	for _, delivery := range s.deliveries {
+		if !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	for i := range due {
+		due[i].NextAttemptAt = now.Add(lease)
+		s.deliveries[due[i].ID] = due[i]
+	}
+	return due, nil
+}
+
+func (s *MemoryWebhookStore) CompleteDelivery(ctx context.Context, deliveryID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.deliveries, deliveryID)
+	return nil
+}
+
+func (s *MemoryWebhookStore) RetryDelivery(ctx context.Context, deliveryID, lastError string, nextAttemptAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if delivery, ok := s.deliveries[deliveryID]; ok {
+		delivery.Attempts++
+		delivery.LastError = lastError
+		delivery.NextAttemptAt = nextAttemptAt
+		s.deliveries[deliveryID] = delivery
+	}
+	return nil
+}
+
+func (s *MemoryWebhookStore) DeadLetter(ctx context.Context, deliveryID, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delivery, ok := s.deliveries[deliveryID]
+	if !ok {
+		return ErrDeliveryNotFound
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastError = lastError
+	delivery.FailedAt = &now
+	s.deadLetters[deliveryID] = delivery
+	delete(s.deliveries, deliveryID)
+	return nil
+}
+
+func (s *MemoryWebhookStore) ListDeadLetters(ctx context.Context, opts ListOptions) ([]models.WebhookDelivery, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deliveries := make([]models.WebhookDelivery, 0, len(s.deadLetters))
+	for _, delivery := range s.deadLetters {
+		deliveries = append(deliveries, delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].FailedAt.Equal(*deliveries[j].FailedAt) {
+			return deliveries[i].FailedAt.After(*deliveries[j].FailedAt)
+		}
+		return deliveries[i].ID > deliveries[j].ID
+	})
+	return paginate(deliveries, opts), int64(len(deliveries)), nil
+}
+
+func (s *MemoryWebhookStore) ReplayDeadLetter(ctx context.Context, deliveryID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delivery, ok := s.deadLetters[deliveryID]
+	if !ok {
+		return ErrDeliveryNotFound
+	}
+
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.FailedAt = nil
+	s.deliveries[deliveryID] = delivery
+	delete(s.deadLetters, deliveryID)
+	return nil
+}
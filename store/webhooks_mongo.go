@@ -0,0 +1,233 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"followservice/models"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoWebhookStore 基于MongoDB的回调存储，回调地址、待投递队列和死信分别保存在三个集合中
+type MongoWebhookStore struct {
+	webhooks    *mongo.Collection
+	deliveries  *mongo.Collection
+	deadLetters *mongo.Collection
+}
+
+func NewMongoWebhookStore(webhooks, deliveries, deadLetters *mongo.Collection) *MongoWebhookStore {
+	return &MongoWebhookStore{
+		webhooks:    webhooks,
+		deliveries:  deliveries,
+		deadLetters: deadLetters,
+	}
+}
+
+func (s *MongoWebhookStore) CreateWebhook(ctx context.Context, url, secret string, events []models.WebhookEvent) (*models.Webhook, error) {
+	webhook := &models.Webhook{
+		ID:        uuid.New().String(),
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		CreatedAt: time.Now(),
+	}
+	if _, err := s.webhooks.InsertOne(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *MongoWebhookStore) GetWebhook(ctx context.Context, webhookID string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := s.webhooks.FindOne(ctx, bson.M{"_id": webhookID}).Decode(&webhook)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (s *MongoWebhookStore) DeleteWebhook(ctx context.Context, webhookID string) error {
+	result, err := s.webhooks.DeleteOne(ctx, bson.M{"_id": webhookID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrWebhookNotFound
+	}
+	_, err = s.deliveries.DeleteMany(ctx, bson.M{"webhook_id": webhookID})
+	return err
+}
+
+func (s *MongoWebhookStore) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	return s.findWebhooks(ctx, bson.M{})
+}
+
+func (s *MongoWebhookStore) WebhooksForEvent(ctx context.Context, event models.WebhookEvent) ([]models.Webhook, error) {
+	return s.findWebhooks(ctx, bson.M{"events": event})
+}
+
+func (s *MongoWebhookStore) findWebhooks(ctx context.Context, filter bson.M) ([]models.Webhook, error) {
+	cursor, err := s.webhooks.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := []models.Webhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (s *MongoWebhookStore) EnqueueDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(deliveries))
+	for i := range deliveries {
+		documents[i] = deliveries[i]
+	}
+	// 无序插入使重复的回调不影响其余回调写入
+	_, err := s.deliveries.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if mongo.IsDuplicateKeyError(err) && !hasNonDuplicateWriteError(err) {
+		return nil
+	}
+	return err
+}
+
+// hasNonDuplicateWriteError 判断批量写入错误中是否包含重复键以外的错误
+func hasNonDuplicateWriteError(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) {
+		return true
+	}
+	if bulkErr.WriteConcernError != nil {
+		return true
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != 11000 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MongoWebhookStore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	deliveries := make([]models.WebhookDelivery, 0, limit)
+	for len(deliveries) < limit {
+		now := time.Now()
+
+		var delivery models.WebhookDelivery
+		err := s.deliveries.FindOneAndUpdate(ctx, bson.M{
+			"next_attempt_at": bson.M{"$lte": now},
+		}, bson.M{
+			"$set": bson.M{"next_attempt_at": now.Add(lease)},
+		}, options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+			SetReturnDocument(options.After),
+		).Decode(&delivery)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+func (s *MongoWebhookStore) CompleteDelivery(ctx context.Context, deliveryID string) error {
+	_, err := s.deliveries.DeleteOne(ctx, bson.M{"_id": deliveryID})
+	return err
+}
+
+func (s *MongoWebhookStore) RetryDelivery(ctx context.Context, deliveryID, lastError string, nextAttemptAt time.Time) error {
+	_, err := s.deliveries.UpdateOne(ctx, bson.M{"_id": deliveryID}, bson.M{
+		"$set": bson.M{
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		},
+		"$inc": bson.M{"attempts": 1},
+	})
+	return err
+}
+
+func (s *MongoWebhookStore) DeadLetter(ctx context.Context, deliveryID, lastError string) error {
+	var delivery models.WebhookDelivery
+	err := s.deliveries.FindOne(ctx, bson.M{"_id": deliveryID}).Decode(&delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrDeliveryNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastError = lastError
+	delivery.FailedAt = &now
+
+	// 先写入死信再移出队列，中途失败时最多重复一次投递，不会丢失回调
+	if _, err := s.deadLetters.ReplaceOne(ctx, bson.M{"_id": deliveryID}, delivery, options.Replace().SetUpsert(true)); err != nil {
+		return err
+	}
+	_, err = s.deliveries.DeleteOne(ctx, bson.M{"_id": deliveryID})
+	return err
+}
+
+func (s *MongoWebhookStore) ListDeadLetters(ctx context.Context, opts ListOptions) ([]models.WebhookDelivery, int64, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "failed_at", Value: -1}, {Key: "_id", Value: -1}})
+	if opts.Offset > 0 {
+		findOptions.SetSkip(int64(opts.Offset))
+	}
+	if opts.Limit > 0 {
+		findOptions.SetLimit(int64(opts.Limit))
+	}
+
+	cursor, err := s.deadLetters.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []models.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, err
+	}
+
+	totalCount, err := s.deadLetters.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, totalCount, nil
+}
+
+func (s *MongoWebhookStore) ReplayDeadLetter(ctx context.Context, deliveryID string) error {
+	var delivery models.WebhookDelivery
+	err := s.deadLetters.FindOne(ctx, bson.M{"_id": deliveryID}).Decode(&delivery)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrDeliveryNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	delivery.FailedAt = nil
+
+	if _, err := s.deliveries.ReplaceOne(ctx, bson.M{"_id": deliveryID}, delivery, options.Replace().SetUpsert(true)); err != nil {
+		return err
+	}
+	_, err = s.deadLetters.DeleteOne(ctx, bson.M{"_id": deliveryID})
+	return err
+}
@@ -0,0 +1,95 @@
+package suggestions
+
+import (
+	"context"
+	"followservice/store"
+)
+
+// Reason 推荐某个用户的原因
+type Reason string
+
+const (
+	// ReasonFollowedByFollowing 用户关注的人也关注了该用户
+	ReasonFollowedByFollowing Reason = "followed_by_following"
+	// ReasonSameCity 与用户在同一城市
+	ReasonSameCity Reason = "same_city"
+	// ReasonNewUser 最近注册的用户
+	ReasonNewUser Reason = "new_user"
+)
+
+// Suggestion 一个推荐关注的用户
+type Suggestion struct {
+	UserID string
+	Reason Reason
+	// Score 在同一来源内用于排序的分数
+	Score float64
+	// MutualCount 和 Via 仅在Reason为ReasonFollowedByFollowing时有值
+	MutualCount int64
+	Via         []string
+	// City 仅在Reason为ReasonSameCity时有值
+	City string
+}
+
+// Source 推荐来源，返回的候选不能包含userID本人及exclude中的用户
+type Source interface {
+	Suggest(ctx context.Context, userID string, exclude []string, limit int) ([]Suggestion, error)
+}
+
+// Engine 依次从各来源获取推荐，排在前面的来源优先，同一用户只保留第一次出现的推荐。
+// 与userID存在拉黑关系的用户、userID已发送待处理关注请求的用户以及userID标记过不感兴趣的用户不会被推荐
+type Engine struct {
+	sources    []Source
+	blocks     store.BlockStore
+	requests   store.FollowRequestStore
+	dismissals store.DismissalStore
+}
+
+func NewEngine(blocks store.BlockStore, requests store.FollowRequestStore, dismissals store.DismissalStore, sources ...Source) *Engine {
+	return &Engine{
+		sources:    sources,
+		blocks:     blocks,
+		requests:   requests,
+		dismissals: dismissals,
+	}
+}
+
+// Dismiss 记录userID对targetID不感兴趣，之后targetID不会再被推荐给userID
+func (e *Engine) Dismiss(ctx context.Context, userID, targetID string) error {
+	return e.dismissals.Dismiss(ctx, userID, targetID)
+}
+
+// Suggest 返回最多limit个推荐
+func (e *Engine) Suggest(ctx context.Context, userID string, limit int) ([]Suggestion, error) {
+	exclude, err := e.blocks.RelatedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	dismissed, err := e.dismissals.DismissedUserIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	exclude = append(exclude, dismissed...)
+	outgoing, err := e.requests.ListOutgoing(ctx, userID, store.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range outgoing.Requests {
+		exclude = append(exclude, request.TargetID)
+	}
+
+	suggestions := make([]Suggestion, 0, limit)
+	for _, source := range e.sources {
+		if len(suggestions) >= limit {
+			break
+		}
+		found, err := source.Suggest(ctx, userID, exclude, limit-len(suggestions))
+		if err != nil {
+			return nil, err
+		}
+		for _, suggestion := range found {
+			suggestions = append(suggestions, suggestion)
+			exclude = append(exclude, suggestion.UserID)
+		}
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,139 @@
+package suggestions
+
+import (
+	"context"
+	"followservice/store"
+	"reflect"
+	"testing"
+)
+
+// graphFixture u关注a、b、c、d；a、b、c都关注了x，a和b关注了y，c关注了z，d关注了u
+type graphFixture struct {
+	follows    *store.MemoryFollowStore
+	blocks     *store.MemoryBlockStore
+	requests   *store.MemoryFollowRequestStore
+	dismissals *store.MemoryDismissalStore
+}
+
+func newGraphFixture(t *testing.T) *graphFixture {
+	t.Helper()
+	follows := store.NewMemoryFollowStore()
+	f := &graphFixture{
+		follows:    follows,
+		blocks:     store.NewMemoryBlockStore(follows.Outbox()),
+		requests:   store.NewMemoryFollowRequestStore(follows.Outbox()),
+		dismissals: store.NewMemoryDismissalStore(),
+	}
+	edges := [][2]string{
+		{"u", "a"}, {"u", "b"}, {"u", "c"}, {"u", "d"},
+		{"a", "x"}, {"b", "x"}, {"c", "x"},
+		{"a", "y"}, {"b", "y"},
+		{"c", "z"},
+		{"d", "u"},
+	}
+	for _, edge := range edges {
+		if _, err := follows.Follow(context.Background(), edge[0], edge[1]); err != nil {
+			t.Fatalf("Follow(%s, %s) error = %v", edge[0], edge[1], err)
+		}
+	}
+	return f
+}
+
+func (f *graphFixture) engine() *Engine {
+	return NewEngine(f.blocks, f.requests, f.dismissals, NewGraphSource(f.follows))
+}
+
+func suggestedIDs(found []Suggestion) []string {
+	ids := make([]string, 0, len(found))
+	for _, suggestion := range found {
+		ids = append(ids, suggestion.UserID)
+	}
+	return ids
+}
+
+func TestGraphSuggestionsRankedByMutualCount(t *testing.T) {
+	f := newGraphFixture(t)
+	found, err := f.engine().Suggest(context.Background(), "u", 10)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+
+	// 已关注的a、b、c、d以及u本人不会被推荐
+	if got := suggestedIDs(found); !reflect.DeepEqual(got, []string{"x", "y", "z"}) {
+		t.Fatalf("suggestions = %v, want [x y z]", got)
+	}
+	x := found[0]
+	if x.Reason != ReasonFollowedByFollowing || x.MutualCount != 3 || len(x.Via) != 3 {
+		t.Errorf("x = %+v, want 3 mutual follows via a, b and c", x)
+	}
+}
+
+func TestGraphSuggestionsLimit(t *testing.T) {
+	f := newGraphFixture(t)
+	found, err := f.engine().Suggest(context.Background(), "u", 1)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if got := suggestedIDs(found); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("suggestions = %v, want [x]", got)
+	}
+}
+
+func TestGraphSuggestionsExclusions(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(*testing.T, *graphFixture)
+		want    []string
+	}{
+		{
+			name: "拉黑了对方",
+			prepare: func(t *testing.T, f *graphFixture) {
+				if _, err := f.blocks.Block(context.Background(), "u", "x"); err != nil {
+					t.Fatalf("Block() error = %v", err)
+				}
+			},
+			want: []string{"y", "z"},
+		},
+		{
+			name: "被对方拉黑",
+			prepare: func(t *testing.T, f *graphFixture) {
+				if _, err := f.blocks.Block(context.Background(), "y", "u"); err != nil {
+					t.Fatalf("Block() error = %v", err)
+				}
+			},
+			want: []string{"x", "z"},
+		},
+		{
+			name: "已发送关注请求",
+			prepare: func(t *testing.T, f *graphFixture) {
+				if _, err := f.requests.CreateRequest(context.Background(), "u", "z"); err != nil {
+					t.Fatalf("CreateRequest() error = %v", err)
+				}
+			},
+			want: []string{"x", "y"},
+		},
+		{
+			name: "标记过不感兴趣",
+			prepare: func(t *testing.T, f *graphFixture) {
+				if err := f.engine().Dismiss(context.Background(), "u", "x"); err != nil {
+					t.Fatalf("Dismiss() error = %v", err)
+				}
+			},
+			want: []string{"y", "z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newGraphFixture(t)
+			tt.prepare(t, f)
+			found, err := f.engine().Suggest(context.Background(), "u", 10)
+			if err != nil {
+				t.Fatalf("Suggest() error = %v", err)
+			}
+			if got := suggestedIDs(found); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suggestions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
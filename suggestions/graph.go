@@ -0,0 +1,43 @@
+package suggestions
+
+import (
+	"context"
+	"followservice/store"
+)
+
+// maxVia 每个推荐最多返回的中间人数量，用于展示"X 和其他N人关注了TA"
+const maxVia = 3
+
+// GraphSource 基于二度人脉的推荐：用户关注的人中关注了候选用户的人越多，排名越靠前
+type GraphSource struct {
+	follows store.FollowStore
+}
+
+func NewGraphSource(follows store.FollowStore) *GraphSource {
+	return &GraphSource{
+		follows: follows,
+	}
+}
+
+func (s *GraphSource) Suggest(ctx context.Context, userID string, exclude []string, limit int) ([]Suggestion, error) {
+	candidates, err := s.follows.SecondDegree(ctx, userID, store.SecondDegreeOptions{
+		Limit:          limit,
+		MaxVia:         maxVia,
+		ExcludeUserIDs: exclude,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]Suggestion, 0, len(candidates))
+	for _, candidate := range candidates {
+		suggestions = append(suggestions, Suggestion{
+			UserID:      candidate.UserID,
+			Reason:      ReasonFollowedByFollowing,
+			Score:       float64(candidate.MutualCount),
+			MutualCount: candidate.MutualCount,
+			Via:         candidate.Via,
+		})
+	}
+	return suggestions, nil
+}
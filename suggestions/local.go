@@ -0,0 +1,135 @@
+package suggestions
+
+import (
+	"context"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/store"
+	"time"
+)
+
+// DefaultNewUserWindow 未配置时注册多久以内的用户算作新用户
+const DefaultNewUserWindow = 7 * 24 * time.Hour
+
+// DefaultOnlineTTL 未配置时用户资料副本中的在线状态的有效期
+const DefaultOnlineTTL = 5 * time.Minute
+
+// localOverfetch 从用户资料中多取的倍数，用于过滤掉已关注的用户
+const localOverfetch = 2
+
+// CitySource 推荐与用户同城的用户，在线用户优先，其余按最近在线时间排序。
+// 用户没有关注任何人时二度人脉推荐为空，由该来源和NewUserSource补充
+type CitySource struct {
+	directory store.UserDirectory
+	follows   store.FollowStore
+	enricher  *enrichment.Enricher
+	// onlineTTL 用户资料副本中的在线状态只在获取用户信息或同步资料时更新，超过该时间未更新的视为不在线
+	onlineTTL time.Duration
+}
+
+func NewCitySource(directory store.UserDirectory, follows store.FollowStore, enricher *enrichment.Enricher, onlineTTL time.Duration) *CitySource {
+	if onlineTTL <= 0 {
+		onlineTTL = DefaultOnlineTTL
+	}
+	return &CitySource{
+		directory: directory,
+		follows:   follows,
+		enricher:  enricher,
+		onlineTTL: onlineTTL,
+	}
+}
+
+func (s *CitySource) Suggest(ctx context.Context, userID string, exclude []string, limit int) ([]Suggestion, error) {
+	// 用户城市从用户服务获取，用户信息获取失败或未填写城市时不推荐
+	profile, ok := s.enricher.Enrich(ctx, []string{userID}, enrichment.Options{})[userID]
+	if !ok || profile.User.City == "" {
+		return nil, nil
+	}
+
+	onlineSince := time.Now().Add(-s.onlineTTL)
+	profiles, err := s.directory.NearbyUsers(ctx, profile.User.City, onlineSince, store.DirectoryQuery{
+		Limit:          limit * localOverfetch,
+		ExcludeUserIDs: withUser(exclude, userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unfollowed(ctx, s.follows, userID, profiles, limit, func(p models.UserProfile) Suggestion {
+		score := float64(p.LastOnlineAt.Unix())
+		if p.OnlineAsOf(onlineSince) {
+			score = float64(time.Now().Unix())
+		}
+		return Suggestion{
+			UserID: p.UserID,
+			Reason: ReasonSameCity,
+			Score:  score,
+			City:   p.City,
+		}
+	})
+}
+
+// NewUserSource 推荐最近注册的用户，注册越晚排名越靠前
+type NewUserSource struct {
+	directory store.UserDirectory
+	follows   store.FollowStore
+	window    time.Duration
+}
+
+func NewNewUserSource(directory store.UserDirectory, follows store.FollowStore, window time.Duration) *NewUserSource {
+	if window <= 0 {
+		window = DefaultNewUserWindow
+	}
+	return &NewUserSource{
+		directory: directory,
+		follows:   follows,
+		window:    window,
+	}
+}
+
+func (s *NewUserSource) Suggest(ctx context.Context, userID string, exclude []string, limit int) ([]Suggestion, error) {
+	profiles, err := s.directory.NewUsers(ctx, time.Now().Add(-s.window), store.DirectoryQuery{
+		Limit:          limit * localOverfetch,
+		ExcludeUserIDs: withUser(exclude, userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unfollowed(ctx, s.follows, userID, profiles, limit, func(p models.UserProfile) Suggestion {
+		return Suggestion{
+			UserID: p.UserID,
+			Reason: ReasonNewUser,
+			Score:  float64(p.JoinedAt.Unix()),
+		}
+	})
+}
+
+// withUser 返回exclude加上userID的新切片，不修改调用方传入的exclude
+func withUser(exclude []string, userID string) []string {
+	excluded := make([]string, 0, len(exclude)+1)
+	excluded = append(excluded, exclude...)
+	return append(excluded, userID)
+}
+
+// unfollowed 按原顺序将profiles中userID未关注的用户转换为推荐，最多返回limit个
+func unfollowed(ctx context.Context, follows store.FollowStore, userID string, profiles []models.UserProfile, limit int, convert func(models.UserProfile) Suggestion) ([]Suggestion, error) {
+	userIDs := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		userIDs = append(userIDs, profile.UserID)
+	}
+	states, err := follows.FollowStates(ctx, userID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]Suggestion, 0, limit)
+	for _, profile := range profiles {
+		if len(suggestions) >= limit {
+			break
+		}
+		if states[profile.UserID].Following {
+			continue
+		}
+		suggestions = append(suggestions, convert(profile))
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,119 @@
+package suggestions
+
+import (
+	"context"
+	"followservice/enrichment"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// cityUsers 所有用户都在同一个城市
+type cityUsers struct {
+	proto.UserServiceClient
+	city string
+}
+
+func (u cityUsers) GetUserInfo(ctx context.Context, in *proto.GetUserInfoRequest, opts ...grpc.CallOption) (*proto.UserInfo, error) {
+	return &proto.UserInfo{Id: in.UserId, City: u.city}, nil
+}
+
+func newDirectory(t *testing.T, profiles ...models.UserProfile) *store.MemoryUserDirectory {
+	t.Helper()
+	directory := store.NewMemoryUserDirectory()
+	if err := directory.UpsertProfiles(context.Background(), profiles); err != nil {
+		t.Fatalf("UpsertProfiles() error = %v", err)
+	}
+	return directory
+}
+
+func TestCitySourceOnlineFirst(t *testing.T) {
+	now := time.Now()
+	directory := newDirectory(t,
+		// 最近在线但副本已过期，视为不在线
+		models.UserProfile{UserID: "stale", City: "上海", IsOnline: true, LastOnlineAt: now.Add(-time.Minute), UpdatedAt: now.Add(-time.Hour)},
+		models.UserProfile{UserID: "online", City: "上海", IsOnline: true, LastOnlineAt: now.Add(-time.Hour), UpdatedAt: now},
+		models.UserProfile{UserID: "offline", City: "上海", LastOnlineAt: now.Add(-2 * time.Hour), UpdatedAt: now},
+		models.UserProfile{UserID: "followed", City: "上海", IsOnline: true, UpdatedAt: now},
+		models.UserProfile{UserID: "u", City: "上海", IsOnline: true, UpdatedAt: now},
+		models.UserProfile{UserID: "beijing", City: "北京", IsOnline: true, UpdatedAt: now},
+	)
+	follows := store.NewMemoryFollowStore()
+	if _, err := follows.Follow(context.Background(), "u", "followed"); err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+	enricher := enrichment.NewEnricher(cityUsers{city: "上海"}, nil, 0, nil)
+
+	found, err := NewCitySource(directory, follows, enricher, 5*time.Minute).Suggest(context.Background(), "u", nil, 10)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if got := suggestedIDs(found); !reflect.DeepEqual(got, []string{"online", "stale", "offline"}) {
+		t.Fatalf("suggestions = %v, want [online stale offline]", got)
+	}
+	for _, suggestion := range found {
+		if suggestion.Reason != ReasonSameCity || suggestion.City != "上海" {
+			t.Errorf("suggestion = %+v, want same_city in 上海", suggestion)
+		}
+	}
+}
+
+func TestCitySourceWithoutCity(t *testing.T) {
+	directory := newDirectory(t, models.UserProfile{UserID: "a", UpdatedAt: time.Now()})
+	enricher := enrichment.NewEnricher(cityUsers{}, nil, 0, nil)
+
+	found, err := NewCitySource(directory, store.NewMemoryFollowStore(), enricher, 0).Suggest(context.Background(), "u", nil, 10)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("suggestions = %v, want none for a user without a city", suggestedIDs(found))
+	}
+}
+
+func TestNewUserSourceNewestFirst(t *testing.T) {
+	now := time.Now()
+	directory := newDirectory(t,
+		models.UserProfile{UserID: "old", JoinedAt: now.Add(-30 * 24 * time.Hour)},
+		models.UserProfile{UserID: "week", JoinedAt: now.Add(-6 * 24 * time.Hour)},
+		models.UserProfile{UserID: "today", JoinedAt: now.Add(-time.Hour)},
+		models.UserProfile{UserID: "dismissed", JoinedAt: now.Add(-2 * time.Hour)},
+	)
+
+	found, err := NewNewUserSource(directory, store.NewMemoryFollowStore(), 0).Suggest(context.Background(), "u", []string{"dismissed"}, 10)
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if got := suggestedIDs(found); !reflect.DeepEqual(got, []string{"today", "week"}) {
+		t.Errorf("suggestions = %v, want [today week]", got)
+	}
+}
+
+// TestLocalSourcesKeepExclude 来源排除自己时不能写入调用方exclude的底层数组
+func TestLocalSourcesKeepExclude(t *testing.T) {
+	directory := newDirectory(t, models.UserProfile{UserID: "a", City: "上海", JoinedAt: time.Now(), UpdatedAt: time.Now()})
+	follows := store.NewMemoryFollowStore()
+	enricher := enrichment.NewEnricher(cityUsers{city: "上海"}, nil, 0, nil)
+	sources := map[string]Source{
+		"city":     NewCitySource(directory, follows, enricher, 0),
+		"new user": NewNewUserSource(directory, follows, 0),
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			backing := []string{"x", "sentinel"}
+			exclude := backing[:1]
+			if _, err := source.Suggest(context.Background(), "u", exclude, 10); err != nil {
+				t.Fatalf("Suggest() error = %v", err)
+			}
+			if backing[1] != "sentinel" {
+				t.Errorf("Suggest() overwrote the caller's slice with %q", backing[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package timeline
+
+import (
+	"container/heap"
+	"context"
+	"followservice/proto"
+)
+
+// sourceHeap 以各作者的下一条帖子为键的大顶堆，堆顶为最新的帖子
+type sourceHeap []*authorPosts
+
+func (h sourceHeap) Len() int { return len(h) }
+
+func (h sourceHeap) Less(i, j int) bool {
+	a, b := h[i].head(), h[j].head()
+	return olderThan(b, postTime(a), a.Id)
+}
+
+func (h sourceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *sourceHeap) Push(x any) { *h = append(*h, x.(*authorPosts)) }
+
+func (h *sourceHeap) Pop() any {
+	old := *h
+	source := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return source
+}
+
+// merge 从各作者的帖子中按created_at倒序取出最多limit条，同一作者最多perAuthorLimit条。
+// 下一条帖子属于已达上限的作者时提前结束本页，使该作者剩余的帖子从下一页开始，不会被跳过。
+// hasMore表示还有未取出的帖子
+func merge(ctx context.Context, sources []*authorPosts, limit, perAuthorLimit int) ([]*proto.Post, bool) {
+	h := make(sourceHeap, 0, len(sources))
+	for _, source := range sources {
+		if source.head() != nil {
+			h = append(h, source)
+		}
+	}
+	heap.Init(&h)
+
+	posts := make([]*proto.Post, 0, limit)
+	for h.Len() > 0 && len(posts) < limit {
+		source := h[0]
+		if source.delivered >= perAuthorLimit {
+			break
+		}
+		posts = append(posts, source.head())
+		source.delivered++
+
+		// 作者没有更多帖子时不再参与归并
+		source.next(ctx)
+		if source.head() == nil {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return posts, h.Len() > 0
+}
@@ -0,0 +1,156 @@
+package timeline
+
+import (
+	"context"
+	"followservice/proto"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testBase = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// post 构造发布时间为testBase之后minutes分钟的帖子
+func post(id string, minutes int) *proto.Post {
+	return &proto.Post{
+		Id:        id,
+		CreatedAt: timestamppb.New(testBase.Add(time.Duration(minutes) * time.Minute)),
+	}
+}
+
+// source 构造已读取完所有帖子的作者，posts需按created_at倒序排列
+func source(authorID string, posts ...*proto.Post) *authorPosts {
+	return &authorPosts{
+		authorID:  authorID,
+		buffered:  posts,
+		exhausted: true,
+	}
+}
+
+func postIDs(posts []*proto.Post) []string {
+	ids := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.Id)
+	}
+	return ids
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name           string
+		sources        func() []*authorPosts
+		limit          int
+		perAuthorLimit int
+		want           []string
+		hasMore        bool
+	}{
+		{
+			name: "按发布时间倒序合并",
+			sources: func() []*authorPosts {
+				return []*authorPosts{
+					source("a", post("a2", 9), post("a1", 5)),
+					source("b", post("b2", 8), post("b1", 2)),
+				}
+			},
+			limit:          10,
+			perAuthorLimit: 10,
+			want:           []string{"a2", "b2", "a1", "b1"},
+		},
+		{
+			name: "恰好取完所有帖子",
+			sources: func() []*authorPosts {
+				return []*authorPosts{
+					source("a", post("a2", 9), post("a1", 5)),
+					source("b", post("b2", 8), post("b1", 2)),
+				}
+			},
+			limit:          4,
+			perAuthorLimit: 10,
+			want:           []string{"a2", "b2", "a1", "b1"},
+		},
+		{
+			name: "达到limit",
+			sources: func() []*authorPosts {
+				return []*authorPosts{
+					source("a", post("a2", 9), post("a1", 5)),
+					source("b", post("b2", 8), post("b1", 2)),
+				}
+			},
+			limit:          2,
+			perAuthorLimit: 10,
+			want:           []string{"a2", "b2"},
+			hasMore:        true,
+		},
+		{
+			name: "作者达到上限时提前结束本页",
+			sources: func() []*authorPosts {
+				return []*authorPosts{
+					source("a", post("a3", 9), post("a2", 8), post("a1", 7)),
+					source("b", post("b1", 1)),
+				}
+			},
+			limit:          10,
+			perAuthorLimit: 2,
+			want:           []string{"a3", "a2"},
+			hasMore:        true,
+		},
+		{
+			name: "其他作者的帖子更新时继续合并",
+			sources: func() []*authorPosts {
+				return []*authorPosts{
+					source("a", post("a2", 9), post("a1", 3)),
+					source("b", post("b2", 8), post("b1", 1)),
+				}
+			},
+			limit:          10,
+			perAuthorLimit: 1,
+			want:           []string{"a2", "b2"},
+			hasMore:        true,
+		},
+		{
+			name: "发布时间相同时按ID倒序",
+			sources: func() []*authorPosts {
+				return []*authorPosts{
+					source("a", post("p2", 5)),
+					source("b", post("p3", 5), post("p1", 5)),
+				}
+			},
+			limit:          10,
+			perAuthorLimit: 10,
+			want:           []string{"p3", "p2", "p1"},
+		},
+		{
+			name: "跳过没有帖子的作者",
+			sources: func() []*authorPosts {
+				return []*authorPosts{
+					source("a"),
+					source("b", post("b1", 1)),
+				}
+			},
+			limit:          10,
+			perAuthorLimit: 10,
+			want:           []string{"b1"},
+		},
+		{
+			name:           "没有作者",
+			sources:        func() []*authorPosts { return nil },
+			limit:          10,
+			perAuthorLimit: 10,
+			want:           []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, hasMore := merge(context.Background(), tt.sources(), tt.limit, tt.perAuthorLimit)
+			if got := postIDs(posts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("posts = %v, want %v", got, tt.want)
+			}
+			if hasMore != tt.hasMore {
+				t.Errorf("hasMore = %v, want %v", hasMore, tt.hasMore)
+			}
+		})
+	}
+}
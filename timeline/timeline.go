@@ -0,0 +1,235 @@
+package timeline
+
+import (
+	"context"
+	"followservice/models"
+	"followservice/proto"
+	"followservice/store"
+	"log"
+	"sync"
+	"time"
+)
+
+// 未配置时使用的默认值，每页最多约 200 × (40 / 20) = 400 次帖子服务请求
+const (
+	DefaultMaxAuthors     = 200
+	DefaultPerAuthorLimit = 3
+	DefaultScanLimit      = 40
+	DefaultConcurrency    = 16
+)
+
+// fetchBatchSize 每次向帖子服务请求的帖子数
+const fetchBatchSize = 20
+
+// Options 控制信息流的合并方式。
+// 帖子服务的GetUserPosts只支持按offset分页，每一页都需要从每个作者的最新帖子开始向前扫描到游标位置，
+// 因此每页最多发起 MaxAuthors × ⌈ScanLimit / fetchBatchSize⌉ 次请求，调大MaxAuthors和ScanLimit时需考虑帖子服务的负载
+type Options struct {
+	// MaxAuthors 只合并最近关注的MaxAuthors个用户的帖子
+	MaxAuthors int
+	// PerAuthorLimit 每页中同一作者最多出现的帖子数，超出时本页提前结束，剩余的帖子出现在后续页中
+	PerAuthorLimit int
+	// ScanLimit 翻页时为定位游标，每个作者最多向前扫描的帖子数，更早的帖子不再出现
+	ScanLimit int
+	// Concurrency 同时向帖子服务发起的最大请求数
+	Concurrency int
+}
+
+// Page 一页信息流，NextCursor为空表示没有下一页
+type Page struct {
+	Posts      []*proto.Post
+	NextCursor string
+	// AuthorsTruncated 未静音的关注用户超过MaxAuthors，更早关注的用户的帖子不在信息流中
+	AuthorsTruncated bool
+	// ScanTruncated 至少一个作者向前扫描达到ScanLimit，该作者更早的帖子不会出现在本页和后续页中
+	ScanTruncated bool
+}
+
+// Service 根据关注关系构建"我关注的人"的信息流。
+// 不预先写入收件箱，每次请求时从帖子服务拉取每个关注用户的最新帖子，按created_at倒序多路归并，
+// 已静音的用户不会出现在信息流中
+type Service struct {
+	follows store.FollowStore
+	mutes   store.MuteStore
+	posts   proto.PostServiceClient
+	opts    Options
+}
+
+func NewService(follows store.FollowStore, mutes store.MuteStore, posts proto.PostServiceClient, opts Options) *Service {
+	if opts.MaxAuthors <= 0 {
+		opts.MaxAuthors = DefaultMaxAuthors
+	}
+	if opts.PerAuthorLimit <= 0 {
+		opts.PerAuthorLimit = DefaultPerAuthorLimit
+	}
+	if opts.ScanLimit <= 0 {
+		opts.ScanLimit = DefaultScanLimit
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	return &Service{
+		follows: follows,
+		mutes:   mutes,
+		posts:   posts,
+		opts:    opts,
+	}
+}
+
+// Options 返回实际使用的配置，未配置的字段为默认值
+func (s *Service) Options() Options {
+	return s.opts
+}
+
+// Feed 返回viewerID的信息流中位于cursor之后的limit条帖子，cursor为nil时从最新的帖子开始。
+// 获取某个作者的帖子失败时跳过该作者，不影响其他作者的帖子
+func (s *Service) Feed(ctx context.Context, viewerID string, cursor *store.Cursor, limit int) (*Page, error) {
+	authorIDs, authorsTruncated, err := s.authors(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]*authorPosts, 0, len(authorIDs))
+	for _, authorID := range authorIDs {
+		sources = append(sources, &authorPosts{
+			service:  s,
+			viewerID: viewerID,
+			authorID: authorID,
+			cursor:   cursor,
+		})
+	}
+
+	// 并发获取每个作者位于游标之后的第一批帖子
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, s.opts.Concurrency)
+	for _, source := range sources {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(source *authorPosts) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			source.fill(ctx)
+		}(source)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	posts, hasMore := merge(ctx, sources, limit, s.opts.PerAuthorLimit)
+	page := &Page{Posts: posts, AuthorsTruncated: authorsTruncated}
+	for _, source := range sources {
+		page.ScanTruncated = page.ScanTruncated || source.scanTruncated
+	}
+	if hasMore && len(posts) > 0 {
+		last := posts[len(posts)-1]
+		page.NextCursor = store.EncodeCursor(postTime(last), last.Id)
+	}
+	return page, nil
+}
+
+// authors 返回viewerID最近关注的、未静音的最多MaxAuthors个用户，以及是否还有更早关注的用户未被合并
+func (s *Service) authors(ctx context.Context, viewerID string) ([]string, bool, error) {
+	mutedIDs, err := s.mutes.MutedUserIDs(ctx, viewerID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// 多取一个用户用于判断是否超出MaxAuthors
+	authorIDs := make([]string, 0)
+	err = s.follows.ForEachFollowing(ctx, viewerID, store.ListOptions{
+		Limit:          s.opts.MaxAuthors + 1,
+		ExcludeUserIDs: mutedIDs,
+	}, func(follow models.Follow) error {
+		authorIDs = append(authorIDs, follow.FollowingID)
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(authorIDs) > s.opts.MaxAuthors {
+		return authorIDs[:s.opts.MaxAuthors], true, nil
+	}
+	return authorIDs, false, nil
+}
+
+// authorPosts 按created_at倒序逐批读取一个作者位于游标之后的帖子
+type authorPosts struct {
+	service  *Service
+	viewerID string
+	authorID string
+	cursor   *store.Cursor
+
+	buffered      []*proto.Post
+	offset        int32
+	exhausted     bool
+	scanTruncated bool // 因达到ScanLimit停止读取，作者可能还有更早的帖子
+	delivered     int  // 本页已取出的帖子数
+}
+
+// head 返回下一条帖子，没有更多帖子时返回nil
+func (a *authorPosts) head() *proto.Post {
+	if len(a.buffered) == 0 {
+		return nil
+	}
+	return a.buffered[0]
+}
+
+// next 消费当前帖子，缓冲区为空时继续向帖子服务请求
+func (a *authorPosts) next(ctx context.Context) {
+	a.buffered = a.buffered[1:]
+	if len(a.buffered) == 0 {
+		a.fill(ctx)
+	}
+}
+
+// fill 请求帖子直到缓冲区中有位于游标之后的帖子，或作者没有更多帖子，或已扫描ScanLimit条帖子
+func (a *authorPosts) fill(ctx context.Context) {
+	for len(a.buffered) == 0 && !a.exhausted {
+		batch := min(fetchBatchSize, a.service.opts.ScanLimit-int(a.offset))
+		response, err := a.service.posts.GetUserPosts(ctx, &proto.GetUserPostsRequest{
+			UserId:        a.authorID,
+			Limit:         int32(batch),
+			Offset:        a.offset,
+			CurrentUserId: a.viewerID,
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("获取用户 %s 的帖子失败: %v", a.authorID, err)
+			}
+			a.exhausted = true
+			return
+		}
+
+		a.offset += int32(len(response.Posts))
+		if len(response.Posts) < batch {
+			a.exhausted = true
+		} else if int(a.offset) >= a.service.opts.ScanLimit {
+			a.exhausted = true
+			a.scanTruncated = true
+		}
+		for _, post := range response.Posts {
+			if post.CreatedAt != nil && (a.cursor == nil || olderThan(post, a.cursor.CreatedAt, a.cursor.ID)) {
+				a.buffered = append(a.buffered, post)
+			}
+		}
+	}
+}
+
+func postTime(post *proto.Post) time.Time {
+	return post.CreatedAt.AsTime()
+}
+
+// olderThan 判断post在按(created_at, id)倒序的信息流中是否位于(createdAt, id)之后
+func olderThan(post *proto.Post, createdAt time.Time, id string) bool {
+	postCreatedAt := postTime(post)
+	if !postCreatedAt.Equal(createdAt) {
+		return postCreatedAt.Before(createdAt)
+	}
+	return post.Id < id
+}
@@ -0,0 +1,116 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"followservice/proto"
+	"followservice/store"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// fakePosts 按offset分页返回每个作者的帖子，posts需按created_at倒序排列
+type fakePosts struct {
+	proto.PostServiceClient
+	posts map[string][]*proto.Post
+}
+
+func (f fakePosts) GetUserPosts(ctx context.Context, in *proto.GetUserPostsRequest, opts ...grpc.CallOption) (*proto.GetUserPostsResponse, error) {
+	posts := f.posts[in.UserId]
+	start := min(int(in.Offset), len(posts))
+	end := min(start+int(in.Limit), len(posts))
+	return &proto.GetUserPostsResponse{Posts: posts[start:end]}, nil
+}
+
+// authorTimeline 构造作者的count条帖子，ID为<authorID><序号>，序号越大越新
+func authorTimeline(authorID string, count int) []*proto.Post {
+	posts := make([]*proto.Post, 0, count)
+	for i := count; i > 0; i-- {
+		posts = append(posts, post(fmt.Sprintf("%s%02d", authorID, i), i))
+	}
+	return posts
+}
+
+func newFeed(t *testing.T, posts map[string][]*proto.Post, opts Options) *Service {
+	t.Helper()
+	follows := store.NewMemoryFollowStore()
+	for authorID := range posts {
+		if _, err := follows.Follow(context.Background(), "viewer", authorID); err != nil {
+			t.Fatalf("Follow() error = %v", err)
+		}
+	}
+	return NewService(follows, store.NewMemoryMuteStore(), fakePosts{posts: posts}, opts)
+}
+
+func TestFeedAuthorsTruncated(t *testing.T) {
+	posts := map[string][]*proto.Post{
+		"a": authorTimeline("a", 1),
+		"b": authorTimeline("b", 1),
+		"c": authorTimeline("c", 1),
+	}
+	tests := []struct {
+		name       string
+		maxAuthors int
+		wantPosts  int
+		truncated  bool
+	}{
+		{name: "关注的人超过上限", maxAuthors: 2, wantPosts: 2, truncated: true},
+		{name: "关注的人恰好等于上限", maxAuthors: 3, wantPosts: 3, truncated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := newFeed(t, posts, Options{MaxAuthors: tt.maxAuthors}).Feed(context.Background(), "viewer", nil, 10)
+			if err != nil {
+				t.Fatalf("Feed() error = %v", err)
+			}
+			if len(page.Posts) != tt.wantPosts || page.AuthorsTruncated != tt.truncated {
+				t.Errorf("got %d posts, AuthorsTruncated = %v, want %d posts, %v", len(page.Posts), page.AuthorsTruncated, tt.wantPosts, tt.truncated)
+			}
+			if page.ScanTruncated {
+				t.Error("ScanTruncated = true, want false")
+			}
+		})
+	}
+}
+
+// TestFeedScanTruncated a有10条帖子，scan_limit为4时只能读到a10到a07，更早的帖子不会出现，每页都标记
+func TestFeedScanTruncated(t *testing.T) {
+	feed := newFeed(t, map[string][]*proto.Post{
+		"a": authorTimeline("a", 10),
+		"b": authorTimeline("b", 1),
+	}, Options{PerAuthorLimit: 10, ScanLimit: 4})
+	ctx := context.Background()
+
+	first, err := feed.Feed(ctx, "viewer", nil, 3)
+	if err != nil {
+		t.Fatalf("Feed() error = %v", err)
+	}
+	if got := postIDs(first.Posts); !reflect.DeepEqual(got, []string{"a10", "a09", "a08"}) {
+		t.Fatalf("first page = %v", got)
+	}
+	if !first.ScanTruncated {
+		t.Error("first page ScanTruncated = false, want true")
+	}
+
+	cursor, err := store.DecodeCursor(first.NextCursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	second, err := feed.Feed(ctx, "viewer", cursor, 3)
+	if err != nil {
+		t.Fatalf("Feed() error = %v", err)
+	}
+	if got := postIDs(second.Posts); !reflect.DeepEqual(got, []string{"a07", "b01"}) {
+		t.Errorf("second page = %v, want [a07 b01]", got)
+	}
+	if !second.ScanTruncated || second.NextCursor != "" {
+		t.Errorf("second page ScanTruncated = %v, NextCursor = %q, want truncated last page", second.ScanTruncated, second.NextCursor)
+	}
+
+	if opts := feed.Options(); opts.MaxAuthors != DefaultMaxAuthors || opts.ScanLimit != 4 {
+		t.Errorf("Options() = %+v", opts)
+	}
+}
@@ -0,0 +1,80 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"followservice/events"
+	"followservice/models"
+	"followservice/store"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payload 回调请求体
+type Payload struct {
+	Event        models.WebhookEvent `json:"event"`
+	EventID      string              `json:"eventId"`      // 发件箱事件ID，同一事件的回调相同
+	UserID       string              `json:"userId"`       // 发起操作的用户
+	TargetUserID string              `json:"targetUserId"` // 被关注、取消关注、拉黑或收到关注请求的用户
+	OccurredAt   time.Time           `json:"occurredAt"`
+}
+
+// webhookEvents 发件箱事件类型与回调事件的对应关系
+var webhookEvents = map[models.EventType]models.WebhookEvent{
+	models.EventFollowCreated:        models.WebhookEventFollow,
+	models.EventFollowDeleted:        models.WebhookEventUnfollow,
+	models.EventBlockCreated:         models.WebhookEventBlock,
+	models.EventFollowRequestCreated: models.WebhookEventFollowRequest,
+}
+
+// Dispatcher 实现events.EventPublisher，由发件箱中继任务调用，为订阅了事件的每个回调地址创建待投递的回调，
+// 实际投递由后台任务完成
+type Dispatcher struct {
+	store store.WebhookStore
+}
+
+func NewDispatcher(webhookStore store.WebhookStore) *Dispatcher {
+	return &Dispatcher{
+		store: webhookStore,
+	}
+}
+
+// Publish 将事件加入订阅者的投递队列，没有订阅者时不做任何操作。
+// 回调ID由事件ID和回调地址ID确定，中继任务重试同一事件时不会重复创建尚未投递完成的回调
+func (d *Dispatcher) Publish(ctx context.Context, message events.Message) error {
+	event, ok := webhookEvents[message.Type]
+	if !ok {
+		return nil
+	}
+
+	webhooks, err := d.store.WebhooksForEvent(ctx, event)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	body, err := json.Marshal(Payload{
+		Event:        event,
+		EventID:      message.ID,
+		UserID:       message.FollowerID,
+		TargetUserID: message.FollowingID,
+		OccurredAt:   message.OccurredAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:            uuid.NewSHA1(uuid.NameSpaceOID, []byte(message.ID+"/"+webhook.ID)).String(),
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Payload:       string(body),
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+	return d.store.EnqueueDeliveries(ctx, deliveries)
+}
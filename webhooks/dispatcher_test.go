@@ -0,0 +1,67 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"followservice/events"
+	"followservice/models"
+	"followservice/store"
+	"testing"
+	"time"
+)
+
+func TestDispatcherPublish(t *testing.T) {
+	ctx := context.Background()
+	webhookStore := store.NewMemoryWebhookStore()
+	follows, _ := webhookStore.CreateWebhook(ctx, "https://a.example/hook", "s1", []models.WebhookEvent{models.WebhookEventFollow})
+	webhookStore.CreateWebhook(ctx, "https://b.example/hook", "s2", []models.WebhookEvent{models.WebhookEventBlock})
+	dispatcher := NewDispatcher(webhookStore)
+
+	message := events.Message{
+		ID:          "event-1",
+		Type:        models.EventFollowCreated,
+		FollowerID:  "a",
+		FollowingID: "b",
+		OccurredAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	// 中继重试同一事件时不会重复创建回调
+	for i := 0; i < 2; i++ {
+		if err := dispatcher.Publish(ctx, message); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	deliveries, err := webhookStore.ClaimDeliveries(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimDeliveries() error = %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].WebhookID != follows.ID {
+		t.Fatalf("deliveries = %+v, want one for the follow subscriber", deliveries)
+	}
+
+	var payload Payload
+	if err := json.Unmarshal([]byte(deliveries[0].Payload), &payload); err != nil {
+		t.Fatalf("payload %q: %v", deliveries[0].Payload, err)
+	}
+	want := Payload{Event: models.WebhookEventFollow, EventID: "event-1", UserID: "a", TargetUserID: "b", OccurredAt: message.OccurredAt}
+	if payload != want {
+		t.Errorf("payload = %+v, want %+v", payload, want)
+	}
+}
+
+// TestDispatcherIgnoresUnsubscribedEvents 没有订阅者或事件没有对应回调类型时不创建回调
+func TestDispatcherIgnoresUnsubscribedEvents(t *testing.T) {
+	ctx := context.Background()
+	webhookStore := store.NewMemoryWebhookStore()
+	webhookStore.CreateWebhook(ctx, "https://a.example/hook", "s1", []models.WebhookEvent{models.WebhookEventFollow})
+	dispatcher := NewDispatcher(webhookStore)
+
+	for _, eventType := range []models.EventType{models.EventFollowDeleted, models.EventType("Unknown")} {
+		if err := dispatcher.Publish(ctx, events.Message{ID: string(eventType), Type: eventType}); err != nil {
+			t.Fatalf("Publish(%s) error = %v", eventType, err)
+		}
+	}
+	if deliveries, _ := webhookStore.ClaimDeliveries(ctx, 10, time.Minute); len(deliveries) != 0 {
+		t.Errorf("deliveries = %+v, want none", deliveries)
+	}
+}
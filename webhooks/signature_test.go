@@ -0,0 +1,47 @@
+package webhooks
+
+import "testing"
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"follow"}`)
+	signature := Sign("secret", 1700000000, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		want      bool
+	}{
+		{name: "签名匹配", secret: "secret", timestamp: 1700000000, body: body, want: true},
+		{name: "密钥不同", secret: "other", timestamp: 1700000000, body: body},
+		{name: "时间戳被修改", secret: "secret", timestamp: 1700000001, body: body},
+		{name: "请求体被修改", secret: "secret", timestamp: 1700000000, body: []byte(`{"event":"block"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Verify(tt.secret, signature, tt.timestamp, tt.body); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSignKnownValue 签名格式是对外约定，接收方按README中的算法自行校验
+func TestSignKnownValue(t *testing.T) {
+	const want = "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got := Sign("secret", 1700000000, []byte("{}")); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	b, _ := GenerateSecret()
+	if len(a) != 64 || a == b {
+		t.Errorf("secrets %q and %q, want two distinct 64-char hex strings", a, b)
+	}
+}